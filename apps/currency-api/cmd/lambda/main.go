@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/currency-api/internal/transport/http"
+	lambdaadapter "github.com/ajs/currency-api/internal/transport/lambda"
+	"github.com/ajs/go-common/logger"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log := logger.New("error")
+		log.Fatal("Failed to load config", err)
+	}
+
+	log := logger.New(cfg.LogLevel, logger.WithFormat(cfg.LogFormat), logger.WithProduction(cfg.IsProduction()))
+
+	server := http.NewServer(cfg, log)
+
+	handler, err := server.Handler()
+	if err != nil {
+		log.Fatal("Failed to build HTTP handler", err)
+	}
+
+	adapter := lambdaadapter.NewAdapter(handler)
+
+	server.LogStartup()
+	lambda.Start(adapter.Proxy)
+}
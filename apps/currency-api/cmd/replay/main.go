@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ajs/currency-api/internal/tools/httpdiff"
+	"github.com/ajs/currency-api/internal/tools/replay"
+)
+
+// Replays access-log requests (see middleware.AccessLog) against a target
+// environment and, when -baseline is set, reports response differences.
+//
+//	go run ./cmd/replay -input access.log -target http://staging:8080 -baseline http://prod:8080 -speed 10
+func main() {
+	input := flag.String("input", "-", "file with JSON access log lines ('-' for stdin)")
+	target := flag.String("target", "", "base URL of the environment under test (required)")
+	baseline := flag.String("baseline", "", "base URL of the reference environment to diff against")
+	speed := flag.Float64("speed", 1, "pacing multiplier relative to the recording (0 = as fast as possible)")
+	ignore := flag.String("ignore", "timestamp", "comma-separated JSON fields ignored when diffing")
+	tolerance := flag.Float64("tolerance", 0, "absolute tolerance for numeric differences")
+	verbose := flag.Bool("verbose", false, "print every result, not only failures and mismatches")
+	flag.Parse()
+
+	if *target == "" {
+		fmt.Fprintln(os.Stderr, "replay: -target is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	reader, err := openInput(*input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+		os.Exit(1)
+	}
+	defer reader.Close()
+
+	requests, err := replay.ReadRequests(reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	replayer := replay.NewReplayer(replay.Config{
+		Target:   *target,
+		Baseline: *baseline,
+		Speed:    *speed,
+		Diff: httpdiff.Options{
+			IgnoreFields:   splitList(*ignore),
+			FloatTolerance: *tolerance,
+		},
+	})
+
+	encoder := json.NewEncoder(os.Stdout)
+	summary, err := replayer.Run(ctx, requests, func(result replay.Result) {
+		if *verbose || result.Error != "" || len(result.Differences) > 0 {
+			_ = encoder.Encode(result)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: interrupted: %v\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "replayed %d requests: %d failed, %d mismatched\n",
+		summary.Total, summary.Failed, summary.Mismatched)
+
+	if err != nil || summary.Failed > 0 || summary.Mismatched > 0 {
+		os.Exit(1)
+	}
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	return file, nil
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
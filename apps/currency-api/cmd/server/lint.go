@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/infrastructure/chain"
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/currency-api/internal/infrastructure/configfile"
+	"github.com/ajs/currency-api/internal/infrastructure/pricing"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+)
+
+const usage = "usage: server [config lint [-pricing-rules file] [-chain-rules file] [-mock-overrides file]]"
+
+// runCommand runs a maintenance subcommand instead of the server.
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) >= 2 && args[0] == "config" && args[1] == "lint" {
+		return runConfigLint(args[2:], stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "unknown command %q\n%s\n", strings.Join(args, " "), usage)
+	return 2
+}
+
+type lintedFile struct {
+	setting string
+	path    string
+	load    func(path string) error
+}
+
+// runConfigLint loads the configuration the way startup does and checks every
+// configured config file, printing each problem as file:line:column. Flags
+// check files that are not configured in the environment, e.g. in CI.
+func runConfigLint(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("config lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pricingRules := flags.String("pricing-rules", "", "pricing rules file to check instead of PRICING_RULES_FILE")
+	chainRules := flags.String("chain-rules", "", "chain rules file to check instead of CHAIN_RULES_FILE")
+	mockOverrides := flags.String("mock-overrides", "", "mock overrides file to check instead of MOCK_OVERRIDES_FILE")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	failed := false
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(stderr, "environment: %v\n", err)
+		failed = true
+		cfg = &config.Config{}
+	}
+
+	pricingTimeout := cfg.PricingRuleTimeout
+	if pricingTimeout <= 0 {
+		pricingTimeout = time.Second
+	}
+
+	files := []lintedFile{
+		{setting: "PRICING_RULES_FILE", path: firstNonEmpty(*pricingRules, cfg.PricingRulesFile), load: func(path string) error {
+			_, err := pricing.LoadExprPricingRules(path, pricingTimeout)
+			return err
+		}},
+		{setting: "CHAIN_RULES_FILE", path: firstNonEmpty(*chainRules, cfg.ChainRulesFile), load: func(path string) error {
+			_, err := chain.LoadRules(path)
+			return err
+		}},
+		{setting: "MOCK_OVERRIDES_FILE", path: firstNonEmpty(*mockOverrides, cfg.MockOverridesFile), load: func(path string) error {
+			_, err := repositories.LoadMockOverrides(path)
+			return err
+		}},
+	}
+
+	checked := 0
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		checked++
+
+		err := file.load(file.path)
+		if err == nil {
+			fmt.Fprintf(stdout, "%s: %s is valid\n", file.setting, file.path)
+			continue
+		}
+		failed = true
+
+		var validationErr *configfile.ValidationError
+		if !errors.As(err, &validationErr) {
+			fmt.Fprintf(stderr, "%s: %v\n", file.path, err)
+			continue
+		}
+		for _, fieldErr := range validationErr.Errors {
+			fmt.Fprintf(stderr, "%s:%d:%d: %s %s\n", file.path, fieldErr.Line, fieldErr.Column, fieldErr.Field, fieldErr.Message)
+		}
+	}
+
+	if checked == 0 {
+		fmt.Fprintln(stdout, "No config files configured")
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
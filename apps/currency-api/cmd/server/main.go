@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/currency-api/internal/infrastructure/discovery"
 	"github.com/ajs/currency-api/internal/transport/http"
 	"github.com/ajs/go-common/logger"
 
@@ -23,31 +26,75 @@ import (
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log := logger.New("error")
 		log.Fatal("Failed to load config", err)
 	}
 
-	log := logger.New(cfg.LogLevel)
+	log := logger.New(cfg.LogLevel, logger.WithFormat(cfg.LogFormat), logger.WithProduction(cfg.IsProduction()))
 
 	server := http.NewServer(cfg, log)
 
+	registrar, err := discovery.NewRegistrar(cfg, log)
+	if err != nil {
+		log.Fatal("Failed to configure service discovery", err)
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	started := make(chan error, 1)
 	go func() {
-		if err := server.Start(); err != nil {
-			log.Fatal("Failed to start server", err)
-		}
+		started <- server.Start()
 	}()
 
-	<-ctx.Done()
+	// Announce the instance only once its ports are bound, so gateways never
+	// route to an address nothing listens on.
+	select {
+	case <-server.Listening():
+		if err := registrar.Register(ctx); err != nil {
+			log.Error("Failed to register service", err)
+		}
+	case err := <-started:
+		log.Fatal("Failed to start server", err)
+	case <-ctx.Done():
+	}
 
-	if err := server.Shutdown(ctx); err != nil {
+	// A listener that fails later still deregisters the instance before the
+	// process exits.
+	failed := false
+	select {
+	case <-ctx.Done():
+	case err := <-started:
+		log.Error("Server failed", err)
+		failed = true
+	}
+
+	deregisterCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := registrar.Deregister(deregisterCtx); err != nil {
+		log.Error("Failed to deregister service", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Error("Server forced to shutdown", err)
 	}
 
+	if failed {
+		os.Exit(1)
+	}
 	log.Info("Server stopped gracefully")
 }
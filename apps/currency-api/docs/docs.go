@@ -23,9 +23,357 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/audits/precision": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nightly report on a sample of one UTC day's conversions, recomputed with exact arithmetic from the currency rates and fees they were served with. Conversions more than one unit in the last place away from the exact result count as discrepancies, and the largest are listed in examples. For conversions adjusted by a pricing rule, counted in priced, the amount handed to the rule is checked instead of the result. Requires a bearer token when ADMIN_TOKEN is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Conversion precision audit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UTC day to report on (YYYY-MM-DD), defaults to yesterday",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PrecisionAuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/config": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runtime configuration of this instance with secrets redacted. Every setting names where its value came from: the built-in default, an environment variable, or a remote source such as ssm:/currency-api/prod. Requires a bearer token when ADMIN_TOKEN is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Effective configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ConfigResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/fees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fee and amount limit table this instance applies to conversions, and the versions staged to take effect later. Requires a bearer token when ADMIN_TOKEN is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Fee schedule",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeeScheduleResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the fee and amount limit table. The schedule is stored as a new version and applied by every instance as soon as effective_from has passed (immediately when omitted), without a deploy. Each publish is recorded in the audit trail with its reason and changes, attributed to the holder of the bearer token; an actor given in the body is kept as reported_actor. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Publish a fee schedule",
+                "parameters": [
+                    {
+                        "description": "Complete fee schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeeScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.FeeSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeeScheduleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/fees/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Published fee schedule versions, newest first, with who published them, why, and what changed compared with the version before. Requires a bearer token when ADMIN_TOKEN is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Fee schedule audit trail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum entries to return (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeeAuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/providers/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daily comparison of the primary rate source and the providers configured in COMPARE_RATE_PROVIDERS: availability, latency, deviation from the median of all providers, and month-to-date quota used. Requires a bearer token when ADMIN_TOKEN is set.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Rate provider comparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UTC day to report on (YYYY-MM-DD), defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProviderReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/changelog": {
+            "get": {
+                "description": "Machine-readable list of API contract changes, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get API changelog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only entries for this exact version (e.g., 2.0.0)",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries newer than this version (exclusive)",
+                        "name": "since_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries on or after this date (YYYY-MM-DD)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries on or before this date (YYYY-MM-DD)",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangelogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangelogErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/exchange": {
             "get": {
-                "description": "Convert one cryptocurrency to another using predefined exchange rates",
+                "description": "Convert one cryptocurrency to another using predefined exchange rates. With a network, the result is cut to the target token's decimals on that chain and compared with the chain's dust limit. A positive conversion that rounds to zero carries an AMOUNT_UNDERFLOW warning with the unrounded value and the smallest meaningful amount. The fee schedule in effect is charged on the result and reported in fee; amounts outside its limits are rejected with AMOUNT_BELOW_MINIMUM or AMOUNT_ABOVE_MAXIMUM. A tenant pricing rule that fails answers 500 with PRICING_RULE_FAILED, and one that produces a negative or non-numeric amount answers 422 with INVALID_PRICED_AMOUNT.",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,6 +420,18 @@ const docTemplate = `{
                         "name": "amount",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chain the target asset is delivered on (e.g. ethereum, arbitrum, polygon)",
+                        "name": "network",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant API key (TENANT_API_KEYS); the key's tenant pricing rule is applied",
+                        "name": "X-API-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -84,221 +444,1742 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HTTPError"
+                            "$ref": "#/definitions/handlers.ExchangeErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExchangeErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExchangeErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExchangeErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/rates": {
-            "get": {
-                "description": "Get exchange rates for a list of currencies (minimum 2 required)",
+        "/api/v1/exchange/bulk": {
+            "post": {
+                "description": "Accept a CSV of pair,amount[,date] rows (pair as FROM-TO or FROM/TO, optional header row) either as multipart field \"file\" or as a text/csv body. Rows are converted by an async job; poll the job's status URL and download the results once completed.",
                 "consumes": [
-                    "application/json"
+                    "text/csv",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Rates"
+                    "Exchange"
                 ],
-                "summary": "Get exchange rates",
+                "summary": "Upload a bulk conversion file",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file (multipart uploads)",
+                        "name": "file",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "description": "Comma-separated list of currency codes (e.g., USD,EUR,GBP)",
-                        "name": "currencies",
-                        "in": "query",
-                        "required": true
+                        "description": "Tenant API key (TENANT_API_KEYS); the key's tenant pricing rule is applied",
+                        "name": "X-API-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/handlers.RatesResponse"
+                            "$ref": "#/definitions/handlers.JobResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                            "$ref": "#/definitions/handlers.BulkConversionErrorResponse"
                         }
-                    }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkConversionErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkConversionErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkConversionErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}": {
+            "get": {
+                "description": "Report the status and progress of an async job. result_url is set once a job with downloadable output has completed. Jobs created with a tenant API key are only found with the same key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel a pending or running job. Pending jobs are cancelled immediately (200); running jobs stop at their next checkpoint (202). Jobs created with a tenant API key are only found with the same key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Cancel a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}/events": {
+            "get": {
+                "description": "Server-sent events with the job's state: a \"progress\" event whenever the status or progress changes, then one final \"completed\", \"failed\" or \"cancelled\" event before the stream closes. Each event's data is a JobResponse. Jobs created with a tenant API key are only found with the same key.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Stream job progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}/result": {
+            "get": {
+                "description": "Download the output of a completed job, e.g. the CSV of a bulk conversion. When signed downloads are enabled, use the result_url from the status response; the link needs no API key. Otherwise jobs created with a tenant API key are only found with the same key.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Download job result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job output",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/templates/validate": {
+            "post": {
+                "description": "Check a custom message template for alert subscriptions by rendering it against sample data. Templates use Go text/template syntax over fields such as .Pair, .Threshold, .Rate and .Rates; format \"json\" also requires the output to be valid JSON, as webhook payloads must be.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Validate a notification template",
+                "parameters": [
+                    {
+                        "description": "Template to validate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationTemplateValidationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationTemplateValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationTemplateValidationResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationTemplateValidationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rates": {
+            "get": {
+                "description": "Get exchange rates for a list of currencies (minimum 2 required). Fiat-only requests include the market state and when rates are next expected to change. Problems with the request answer 400; failures of the rates provider answer 502 or 503 with a retryable flag. Rates served from the cache while the provider is failing carry stale metadata.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rates"
+                ],
+                "summary": "Get exchange rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of currency codes (e.g., USD,EUR,GBP)",
+                        "name": "currencies",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rates/stream": {
+            "get": {
+                "description": "Server-sent events with the rates between the given currencies. The first event is a \"snapshot\" with every pair; \"rates\" events then carry only the pairs that changed, at most one per pair per coalescing interval (STREAM_COALESCE_INTERVAL, default 500ms). Clients that read too slowly skip the deltas they missed and receive a new \"snapshot\" once they catch up. When the server ends the stream it sends a final \"close\" event with the reason. With STREAM_API_KEYS configured an API key is required; connections are limited in pairs and per key.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Rates"
+                ],
+                "summary": "Stream exchange rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of currency codes (e.g., USD,EUR,GBP)",
+                        "name": "currencies",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Streaming API key",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Streaming API key, for clients such as EventSource that cannot set headers",
+                        "name": "api_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesStreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatesErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Latest notification deliveries of a webhook or notification subscription, newest first, with every attempt's latency, response code and error. Answers 501 until this service produces notifications. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List subscription deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum deliveries to return (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a recorded delivery's notification again. The result is recorded as a new delivery that references the original; a failed send is reported in its status, not as an error response. Answers 501 until this service produces notifications. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Redeliver a notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeliveryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get the current health status of the API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Report whether rate snapshots meet their freshness SLOs and whether the background workers are running. Violations, including workers given up on after too many crashes, are listed and mark the instance as degraded without failing the probe.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entities.AmountLimit": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.AppliedFee": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "schedule_version": {
+                    "type": "integer"
+                },
+                "spread_bps": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.ChainPreview": {
+            "type": "object",
+            "properties": {
+                "below_dust_limit": {
+                    "type": "boolean"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "dust_limit": {
+                    "type": "number"
+                },
+                "network": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ChangelogEntry": {
+            "type": "object",
+            "properties": {
+                "breaking": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-16"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Machine-readable changelog of API contract changes"
+                },
+                "endpoint": {
+                    "type": "string",
+                    "example": "GET /api/v1/changelog"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "added"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2.1.0"
+                }
+            }
+        },
+        "entities.ConversionRecord": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entities.Fee"
+                },
+                "fee_schedule_version": {
+                    "type": "integer"
+                },
+                "from": {
+                    "$ref": "#/definitions/entities.Currency"
+                },
+                "pricing_input": {
+                    "type": "number"
+                },
+                "pricing_rule": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "number"
+                },
+                "to": {
+                    "$ref": "#/definitions/entities.Currency"
+                }
+            }
+        },
+        "entities.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "rate_to_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "mocked": {
+                    "type": "boolean"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ExchangeResult": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "chain": {
+                    "$ref": "#/definitions/entities.ChainPreview"
+                },
+                "fee": {
+                    "$ref": "#/definitions/entities.AppliedFee"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "pricing_rule": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "warning": {
+                    "$ref": "#/definitions/entities.PrecisionWarning"
+                }
+            }
+        },
+        "entities.Fee": {
+            "type": "object",
+            "properties": {
+                "flat": {
+                    "type": "number"
+                },
+                "spread_bps": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.FeeAuditEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "reported_actor": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.FeeSchedule": {
+            "type": "object",
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "fees": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entities.Fee"
+                    }
+                },
+                "limits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entities.AmountLimit"
+                    }
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "published_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.PrecisionDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "conversion": {
+                    "$ref": "#/definitions/entities.ConversionRecord"
+                },
+                "expected": {
+                    "type": "number"
+                },
+                "ulps": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.PrecisionWarning": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "AMOUNT_UNDERFLOW"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "min_meaningful_amount": {
+                    "type": "number"
+                },
+                "unrounded_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.AdminErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "a valid bearer token is required"
+                }
+            }
+        },
+        "handlers.BulkConversionErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "expected pair,amount[,date]"
+                },
+                "example": {
+                    "type": "string",
+                    "example": "pair,amount,date"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.ChangelogErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "since must be a date in YYYY-MM-DD format"
+                },
+                "example": {
+                    "type": "string",
+                    "example": "GET /api/v1/changelog?since_version=2.0.0"
+                }
+            }
+        },
+        "handlers.ChangelogResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ChangelogEntry"
+                    }
+                }
+            }
+        },
+        "handlers.ConfigResponse": {
+            "type": "object",
+            "properties": {
+                "environment": {
+                    "type": "string",
+                    "example": "production"
+                },
+                "instance_id": {
+                    "type": "string",
+                    "example": "currency-api-7d9f8b6c5-x2k4q"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "eu-west-1"
+                },
+                "settings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ConfigSettingResponse"
+                    }
+                }
+            }
+        },
+        "handlers.ConfigSettingResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "OPEN_EXCHANGE_API_KEY"
+                },
+                "redacted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "type": "string",
+                    "example": "ssm:/currency-api/prod"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "[REDACTED]"
+                }
+            }
+        },
+        "handlers.DeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeliveryResponse"
+                    }
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "sub-42"
+                }
+            }
+        },
+        "handlers.DeliveryAttemptResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected status 503: "
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 184
+                },
+                "response_code": {
+                    "type": "integer",
+                    "example": 503
+                }
+            }
+        },
+        "handlers.DeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeliveryAttemptResponse"
+                    }
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "slack"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "webhook 1: failed to send slack notification after 3 attempt(s): unexpected status 503: "
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c9a1e2b3d4c5e6f708192"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "rate_alert"
+                },
+                "redelivery_of": {
+                    "type": "string",
+                    "example": "2a7d4e0f9b8c1d2e3f4a5b6c"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
+                }
+            }
+        },
+        "handlers.EndpointsInfo": {
+            "type": "object",
+            "properties": {
+                "exchange": {
+                    "type": "string",
+                    "example": "/exchange?from=WBTC\u0026to=USDT\u0026amount=1.0"
+                },
+                "health": {
+                    "type": "string",
+                    "example": "/health"
+                },
+                "rates": {
+                    "type": "string",
+                    "example": "/rates?currencies=USD,EUR,GBP"
+                }
+            }
+        },
+        "handlers.EnvironmentInfo": {
+            "type": "object",
+            "properties": {
+                "gin_mode": {
+                    "type": "string",
+                    "example": "debug"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "development"
+                },
+                "port": {
+                    "type": "string",
+                    "example": "8080"
+                }
+            }
+        },
+        "handlers.ExchangeErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "NON_POSITIVE_AMOUNT"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "amount must be positive"
+                },
+                "example": {
+                    "type": "string",
+                    "example": "GET /api/v1/exchange?from=WBTC\u0026to=USDT\u0026amount=1.0"
+                },
+                "min_amount": {
+                    "type": "string",
+                    "example": "0.00000001"
+                },
+                "suggestion": {
+                    "type": "string",
+                    "example": "Use an amount of at least 0.00000001"
+                }
+            }
+        },
+        "handlers.FeeAuditResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.FeeAuditEntry"
+                    }
+                }
+            }
+        },
+        "handlers.FeeScheduleErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid fee schedule"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.FeeScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Actor is recorded as reported_actor; the audit trail attributes the\npublish to the holder of the bearer token.",
+                    "type": "string",
+                    "example": "pricing-ops@example.com"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-10-16T18:00:00Z"
+                },
+                "fees": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entities.Fee"
+                    }
+                },
+                "limits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entities.AmountLimit"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Widen WBTC spreads during volatility"
+                }
+            }
+        },
+        "handlers.FeeScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/entities.FeeSchedule"
+                },
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.FeeSchedule"
+                    }
+                }
+            }
+        },
+        "handlers.FreshnessStatus": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "asset_class": {
+                    "type": "string",
+                    "example": "fiat"
+                },
+                "burn_rate": {
+                    "type": "number",
+                    "example": 0
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "objective_seconds": {
+                    "type": "number",
+                    "example": 3600
+                },
+                "violation": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "$ref": "#/definitions/handlers.EndpointsInfo"
+                },
+                "environment": {
+                    "$ref": "#/definitions/handlers.EnvironmentInfo"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "framework": {
+                    "type": "string",
+                    "example": "gin-gonic"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "1.24"
+                },
+                "leadership": {
+                    "$ref": "#/definitions/handlers.LeadershipInfo"
+                },
+                "nx_plugin": {
+                    "type": "string",
+                    "example": "@naxodev/gonx"
+                },
+                "service": {
+                    "type": "string",
+                    "example": "currency-exchange-api"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2026-10-16T09:15:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2.0.0"
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Get the current health status of the API",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
-                        }
-                    }
+        "handlers.JobErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "job not found"
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.ExchangeRate": {
+        },
+        "handlers.JobProgress": {
             "type": "object",
             "properties": {
-                "from": {
+                "failed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 33.3
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "handlers.JobResponse": {
+            "type": "object",
+            "properties": {
+                "cancel_requested": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_at": {
                     "type": "string"
                 },
-                "rate": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "to": {
+                "error": {
+                    "type": "string",
+                    "example": "interrupted by instance shutdown"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b"
+                },
+                "progress": {
+                    "$ref": "#/definitions/handlers.JobProgress"
+                },
+                "result_url": {
+                    "type": "string",
+                    "example": "/api/v1/jobs/9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b/result"
+                },
+                "result_url_expires_at": {
+                    "type": "string"
+                },
+                "started_at": {
                     "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "status_url": {
+                    "type": "string",
+                    "example": "/api/v1/jobs/9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "bulk_conversion"
                 }
             }
         },
-        "entities.ExchangeResult": {
+        "handlers.LeadershipInfo": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "instance_id": {
+                    "type": "string",
+                    "example": "currency-api-7d9f-1"
                 },
-                "from": {
-                    "type": "string"
+                "is_leader": {
+                    "type": "boolean"
                 },
-                "to": {
+                "jobs": {
+                    "description": "Jobs only run on the leader; every other background worker runs on\neach instance.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "precision_audit",
+                        "provider_poller"
+                    ]
+                },
+                "leader": {
+                    "type": "string",
+                    "example": "currency-api-7d9f-1"
+                },
+                "leader_since": {
                     "type": "string"
                 }
             }
         },
-        "handlers.EndpointsInfo": {
+        "handlers.MarketStateResponse": {
             "type": "object",
             "properties": {
-                "exchange": {
+                "next_open_at": {
                     "type": "string",
-                    "example": "/exchange?from=WBTC\u0026to=USDT\u0026amount=1.0"
+                    "example": "2026-10-18T21:00:00Z"
                 },
-                "health": {
+                "next_update_at": {
                     "type": "string",
-                    "example": "/health"
+                    "example": "2026-10-18T21:00:00Z"
                 },
-                "rates": {
+                "status": {
                     "type": "string",
-                    "example": "/rates?currencies=USD,EUR,GBP"
+                    "example": "closed"
                 }
             }
         },
-        "handlers.EnvironmentInfo": {
+        "handlers.NotificationTemplateValidationRequest": {
             "type": "object",
             "properties": {
-                "gin_mode": {
+                "format": {
                     "type": "string",
-                    "example": "debug"
+                    "example": "text"
                 },
-                "mode": {
+                "kind": {
                     "type": "string",
-                    "example": "development"
+                    "example": "rate_alert"
                 },
-                "port": {
+                "template": {
                     "type": "string",
-                    "example": "8080"
+                    "example": "{{.Pair}} is {{.Direction}} {{.Threshold}} (now {{round .Rate 2}})"
                 }
             }
         },
-        "handlers.HTTPError": {
+        "handlers.NotificationTemplateValidationResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "error": {
+                    "type": "string",
+                    "example": "function \"call\" is not allowed"
+                },
+                "line": {
                     "type": "integer",
-                    "example": 400
+                    "example": 1
                 },
-                "message": {
+                "preview": {
                     "type": "string",
-                    "example": "Invalid input provided"
+                    "example": "WBTC/USDT is above 57000 (now 57094.31)"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "handlers.HealthResponse": {
+        "handlers.PrecisionAuditResponse": {
             "type": "object",
             "properties": {
-                "endpoints": {
-                    "$ref": "#/definitions/handlers.EndpointsInfo"
+                "checked": {
+                    "type": "integer",
+                    "example": 1228
                 },
-                "environment": {
-                    "$ref": "#/definitions/handlers.EnvironmentInfo"
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-15"
                 },
-                "features": {
+                "discrepancies": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "examples": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/entities.PrecisionDiscrepancy"
                     }
                 },
-                "framework": {
+                "max_ulps": {
                     "type": "string",
-                    "example": "gin-gonic"
+                    "example": "0.5"
                 },
-                "go_version": {
-                    "type": "string",
-                    "example": "1.24"
+                "priced": {
+                    "type": "integer",
+                    "example": 40
                 },
-                "nx_plugin": {
-                    "type": "string",
-                    "example": "@naxodev/gonx"
+                "ran_at": {
+                    "type": "string"
                 },
-                "service": {
-                    "type": "string",
-                    "example": "currency-exchange-api"
+                "sampled": {
+                    "type": "integer",
+                    "example": 1240
                 },
-                "status": {
+                "skipped": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.ProviderReportResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string",
-                    "example": "healthy"
+                    "example": "2026-10-16"
                 },
-                "timestamp": {
-                    "type": "integer"
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ProviderStatsResponse"
+                    }
+                }
+            }
+        },
+        "handlers.ProviderStatsResponse": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "type": "number",
+                    "example": 0.9979
                 },
-                "version": {
+                "deviation_samples": {
+                    "type": "integer",
+                    "example": 96
+                },
+                "failures": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "latency_p50_ms": {
+                    "type": "number",
+                    "example": 182.4
+                },
+                "latency_p95_ms": {
+                    "type": "number",
+                    "example": 611.9
+                },
+                "mean_deviation": {
+                    "type": "number",
+                    "example": 0.00042
+                },
+                "primary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "provider": {
                     "type": "string",
-                    "example": "2.0.0"
+                    "example": "openexchangerates"
+                },
+                "quota_used": {
+                    "type": "integer",
+                    "example": 23011
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 1440
                 }
             }
         },
         "handlers.RatesErrorResponse": {
             "type": "object",
             "properties": {
+                "budget_bytes": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "cache": {
+                    "$ref": "#/definitions/handlers.UnsupportedCurrencyCacheResponse"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "RESPONSE_TOO_LARGE"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "XYZ"
+                },
                 "error": {
                     "type": "string",
                     "example": "currencies parameter is required"
                 },
+                "estimated_bytes": {
+                    "type": "integer",
+                    "example": 1724312
+                },
                 "example": {
                     "type": "string",
                     "example": "GET /rates?currencies=USD,EUR,GBP"
+                },
+                "max_currencies": {
+                    "type": "integer",
+                    "example": 132
+                },
+                "retry_after_seconds": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "retryable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "suggestion": {
+                    "type": "string",
+                    "example": "Request at most 132 currencies per call and split the rest across several requests"
                 }
             }
         },
         "handlers.RatesResponse": {
             "type": "object",
             "properties": {
+                "market": {
+                    "$ref": "#/definitions/handlers.MarketStateResponse"
+                },
                 "rates": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/entities.ExchangeRate"
                     }
                 },
+                "replication_lag_seconds": {
+                    "type": "number",
+                    "example": 12.5
+                },
                 "source_info": {
                     "type": "string",
                     "example": "🔑 API key provided: Using live rates"
+                },
+                "stale": {
+                    "$ref": "#/definitions/handlers.StaleRatesResponse"
+                }
+            }
+        },
+        "handlers.RatesStreamEvent": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ExchangeRate"
+                    }
+                }
+            }
+        },
+        "handlers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "freshness": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FreshnessStatus"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2026-10-16T09:15:00Z"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "workers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.WorkerStatus"
+                    }
+                }
+            }
+        },
+        "handlers.StaleRatesResponse": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "type": "integer",
+                    "example": 540
+                },
+                "fetched_at": {
+                    "type": "string",
+                    "example": "2026-10-16T08:30:00Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "upstream_unavailable"
+                }
+            }
+        },
+        "handlers.UnsupportedCurrencyCacheResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-10-16T12:05:00Z"
+                },
+                "hit": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.WebhookErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "delivery not found"
                 }
             }
+        },
+        "handlers.WorkerStatus": {
+            "type": "object",
+            "properties": {
+                "last_error": {
+                    "type": "string",
+                    "example": "panic: assignment to entry in nil map"
+                },
+                "last_failure": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "rates_stream_refresher"
+                },
+                "restarts": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
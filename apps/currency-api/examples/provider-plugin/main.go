@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ajs/currency-api/pkg/providerplugin"
+)
+
+// A minimal provider plugin serving a fixed rate table. Build it and point
+// RATE_PROVIDER_PLUGIN at the binary:
+//
+//	go build -o ./tmp/static-provider ./examples/provider-plugin
+//	RATE_PROVIDER_PLUGIN=./tmp/static-provider go run ./cmd/server
+type staticProvider struct{}
+
+func (staticProvider) Name() string {
+	return "static-example"
+}
+
+func (staticProvider) GetRates(ctx context.Context, currencies []string) (map[string]float64, error) {
+	table := map[string]float64{
+		"USD": 1.0,
+		"EUR": 0.9,
+		"GBP": 0.8,
+		"PLN": 3.9,
+	}
+
+	rates := make(map[string]float64)
+	for _, currency := range currencies {
+		if rate, exists := table[currency]; exists {
+			rates[currency] = rate
+		}
+	}
+	return rates, nil
+}
+
+func main() {
+	if err := providerplugin.Serve(staticProvider{}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/jobs"
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+const BulkConversionJobType = "bulk_conversion"
+
+// BulkExchangeCommand uploads a CSV of pair,amount[,date] rows, e.g.
+// "WBTC-USDT,1.5,2026-10-16". A leading header row is optional.
+type BulkExchangeCommand struct {
+	CSV    io.Reader
+	Tenant string
+}
+
+// BulkUploadError rejects an upload that cannot be processed at all. Problems
+// with individual rows are reported per row in the results instead.
+type BulkUploadError struct {
+	Line    int
+	Message string
+}
+
+func (e *BulkUploadError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// JobSubmitter queues work on the async job system.
+type JobSubmitter interface {
+	Submit(ctx context.Context, jobType, tenant string, total int, run jobs.Runner) (entities.Job, error)
+}
+
+type BulkExchangeCommandHandler struct {
+	exchange *queries.ExchangeQueryHandler
+	jobs     JobSubmitter
+	maxRows  int
+	now      func() time.Time
+}
+
+// NewBulkExchangeCommandHandler limits uploads to maxRows rows; zero disables
+// the limit.
+func NewBulkExchangeCommandHandler(exchange *queries.ExchangeQueryHandler, jobs JobSubmitter, maxRows int) *BulkExchangeCommandHandler {
+	return &BulkExchangeCommandHandler{
+		exchange: exchange,
+		jobs:     jobs,
+		maxRows:  maxRows,
+		now:      time.Now,
+	}
+}
+
+// Handle validates the upload and submits a job converting its rows. The
+// returned job is the pending snapshot.
+func (h *BulkExchangeCommandHandler) Handle(ctx context.Context, cmd BulkExchangeCommand) (entities.Job, error) {
+	rows, err := h.parse(cmd.CSV)
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	return h.jobs.Submit(ctx, BulkConversionJobType, cmd.Tenant, len(rows), func(ctx context.Context, progress jobs.Progress) (*entities.JobResult, error) {
+		return h.convertAll(ctx, cmd.Tenant, rows, progress)
+	})
+}
+
+func (h *BulkExchangeCommandHandler) parse(r io.Reader) ([]entities.BulkConversionRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []entities.BulkConversionRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &BulkUploadError{Line: parseErr.Line, Message: parseErr.Err.Error()}
+			}
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "pair") {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			return nil, &BulkUploadError{Line: line, Message: "expected pair,amount[,date]"}
+		}
+
+		if h.maxRows > 0 && len(rows) == h.maxRows {
+			return nil, &BulkUploadError{Message: fmt.Sprintf("uploads are limited to %d rows; split the file", h.maxRows)}
+		}
+
+		row := entities.BulkConversionRow{Line: line, Amount: strings.TrimSpace(record[1])}
+		row.From, row.To = splitPair(record[0])
+		if len(record) == 3 {
+			row.Date = strings.TrimSpace(record[2])
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, &BulkUploadError{Message: "upload contains no rows"}
+	}
+
+	return rows, nil
+}
+
+func (h *BulkExchangeCommandHandler) convertAll(ctx context.Context, tenant string, rows []entities.BulkConversionRow, progress jobs.Progress) (*entities.JobResult, error) {
+	results := make([]entities.BulkConversionResult, 0, len(rows))
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result := h.convert(ctx, tenant, row)
+		results = append(results, result)
+
+		failed := 0
+		if result.Error != "" {
+			failed = 1
+		}
+		progress.Advance(1, failed)
+	}
+
+	data, err := renderBulkResults(results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render results: %w", err)
+	}
+
+	return &entities.JobResult{
+		ContentType: "text/csv; charset=utf-8",
+		Filename:    "bulk-conversion-results.csv",
+		Data:        data,
+	}, nil
+}
+
+func (h *BulkExchangeCommandHandler) convert(ctx context.Context, tenant string, row entities.BulkConversionRow) entities.BulkConversionResult {
+	result := entities.BulkConversionResult{Row: row}
+
+	if row.From == "" || row.To == "" {
+		result.Error = "pair must be FROM-TO or FROM/TO"
+		return result
+	}
+
+	if row.Date != "" {
+		date, err := time.Parse("2006-01-02", row.Date)
+		if err != nil {
+			result.Error = "date must be YYYY-MM-DD"
+			return result
+		}
+		if today := h.now().UTC().Format("2006-01-02"); date.Format("2006-01-02") != today {
+			result.Error = fmt.Sprintf("historical rates are not available; only today's date (%s) is supported", today)
+			return result
+		}
+	}
+
+	exchanged, err := h.exchange.Handle(ctx, queries.ExchangeQuery{
+		From:   row.From,
+		To:     row.To,
+		Amount: row.Amount,
+		Tenant: tenant,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Amount = exchanged.Amount
+	result.PricingRule = exchanged.PricingRule
+	return result
+}
+
+func splitPair(pair string) (string, string) {
+	pair = strings.TrimSpace(pair)
+	for _, separator := range []string{"-", "/"} {
+		if from, to, found := strings.Cut(pair, separator); found {
+			return strings.TrimSpace(from), strings.TrimSpace(to)
+		}
+	}
+	return "", ""
+}
+
+func renderBulkResults(results []entities.BulkConversionResult) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"line", "pair", "amount", "date", "converted_amount", "pricing_rule", "error"}); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		converted := ""
+		if result.Error == "" {
+			converted = result.Amount.String()
+		}
+
+		record := []string{
+			strconv.Itoa(result.Row.Line),
+			result.Row.From + "-" + result.Row.To,
+			result.Row.Amount,
+			result.Row.Date,
+			converted,
+			result.PricingRule,
+			result.Error,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/jobs"
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	domainrepositories "github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBulkHandler(t *testing.T, maxRows int) (*BulkExchangeCommandHandler, *queries.GetJobQueryHandler) {
+	repo := repositories.NewMemoryJobRepository()
+	manager := jobs.NewManager(repo, 1, 10, logger.New("error"))
+	manager.Start()
+	t.Cleanup(func() { manager.Close() })
+
+	handler := NewBulkExchangeCommandHandler(queries.NewExchangeQueryHandler(), manager, maxRows)
+	handler.now = func() time.Time { return time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC) }
+	return handler, queries.NewGetJobQueryHandler(repo)
+}
+
+func waitForCompletion(t *testing.T, query *queries.GetJobQueryHandler, id, tenant string) entities.Job {
+	t.Helper()
+	var job entities.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = query.Handle(context.Background(), queries.GetJobQuery{ID: id, Tenant: tenant})
+		require.NoError(t, err)
+		return job.Status == entities.JobCompleted
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestBulkExchangeCommandHandler_Handle(t *testing.T) {
+	handler, query := newTestBulkHandler(t, 100)
+	upload := strings.Join([]string{
+		"pair,amount,date",
+		"WBTC-USDT,1.0",
+		"usdt/wbtc, 57037.22, 2026-10-16",
+		"WBTC-XYZ,1",
+		"WBTC-USDT,-1",
+		"WBTCUSDT,1",
+		"WBTC-USDT,1,2024-01-01",
+		"WBTC-USDT,1,16/10/2026",
+	}, "\n")
+
+	job, err := handler.Handle(context.Background(), BulkExchangeCommand{CSV: strings.NewReader(upload), Tenant: "acme"})
+
+	require.NoError(t, err)
+	assert.Len(t, job.ID, 32)
+	assert.Equal(t, entities.JobPending, job.Status)
+	assert.Equal(t, BulkConversionJobType, job.Type)
+	assert.Equal(t, 7, job.Total)
+	assert.Equal(t, "acme", job.Tenant)
+
+	job = waitForCompletion(t, query, job.ID, "acme")
+	assert.Equal(t, 7, job.Processed)
+	assert.Equal(t, 5, job.Failed)
+	assert.False(t, job.CompletedAt.IsZero())
+
+	_, err = query.Handle(context.Background(), queries.GetJobQuery{ID: job.ID, Tenant: "globex"})
+	assert.ErrorIs(t, err, domainrepositories.ErrJobNotFound)
+	_, err = query.HandleResult(context.Background(), queries.GetJobQuery{ID: job.ID})
+	assert.ErrorIs(t, err, domainrepositories.ErrJobNotFound)
+	_, err = query.HandleResult(context.Background(), queries.GetJobQuery{ID: job.ID, SignedLink: true})
+	assert.NoError(t, err)
+
+	result, err := query.HandleResult(context.Background(), queries.GetJobQuery{ID: job.ID, Tenant: "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", result.ContentType)
+	assert.Equal(t, "bulk-conversion-results.csv", result.Filename)
+
+	records, err := csv.NewReader(strings.NewReader(string(result.Data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 8)
+	assert.Equal(t, []string{"line", "pair", "amount", "date", "converted_amount", "pricing_rule", "error"}, records[0])
+
+	expected, err := queries.NewExchangeQueryHandler().Handle(context.Background(), queries.ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "WBTC-USDT", "1.0", "", expected.Amount.String(), expected.PricingRule, ""}, records[1])
+
+	assert.Equal(t, "usdt-wbtc", records[2][1])
+	assert.Equal(t, "2026-10-16", records[2][3])
+	assert.NotEmpty(t, records[2][4])
+	assert.Empty(t, records[2][6])
+
+	assert.Equal(t, "unsupported currency XYZ", records[3][6])
+	assert.Equal(t, "amount must be positive", records[4][6])
+	assert.Equal(t, "pair must be FROM-TO or FROM/TO", records[5][6])
+	assert.Equal(t, "historical rates are not available; only today's date (2026-10-16) is supported", records[6][6])
+	assert.Equal(t, "date must be YYYY-MM-DD", records[7][6])
+	assert.Empty(t, records[7][4])
+}
+
+func TestBulkExchangeCommandHandler_Handle_RejectsUpload(t *testing.T) {
+	tests := []struct {
+		name          string
+		upload        string
+		expectedError string
+	}{
+		{name: "empty", upload: "", expectedError: "upload contains no rows"},
+		{name: "header only", upload: "pair,amount\n", expectedError: "upload contains no rows"},
+		{name: "missing amount", upload: "WBTC-USDT,1\nWBTC-USDT\n", expectedError: "line 2: expected pair,amount[,date]"},
+		{name: "too many columns", upload: "WBTC-USDT,1,2026-10-16,extra\n", expectedError: "line 1: expected pair,amount[,date]"},
+		{name: "malformed csv", upload: "WBTC-USDT,\"1\n", expectedError: "line 1: extraneous or missing \" in quoted-field"},
+		{name: "too many rows", upload: "WBTC-USDT,1\nWBTC-USDT,2\nWBTC-USDT,3\nWBTC-USDT,4\n", expectedError: "uploads are limited to 3 rows; split the file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestBulkHandler(t, 3)
+
+			_, err := handler.Handle(context.Background(), BulkExchangeCommand{CSV: strings.NewReader(tt.upload)})
+
+			var uploadErr *BulkUploadError
+			require.ErrorAs(t, err, &uploadErr)
+			assert.Equal(t, tt.expectedError, uploadErr.Error())
+		})
+	}
+}
+
+func TestGetJobQueryHandler_HandleResult_NotCompleted(t *testing.T) {
+	repo := repositories.NewMemoryJobRepository()
+	require.NoError(t, repo.Save(context.Background(), entities.Job{ID: "job-1", Status: entities.JobRunning}))
+	require.NoError(t, repo.Save(context.Background(), entities.Job{ID: "job-2", Status: entities.JobCompleted}))
+	query := queries.NewGetJobQueryHandler(repo)
+
+	_, err := query.HandleResult(context.Background(), queries.GetJobQuery{ID: "job-1"})
+	assert.ErrorIs(t, err, queries.ErrJobNotCompleted)
+
+	_, err = query.HandleResult(context.Background(), queries.GetJobQuery{ID: "job-2"})
+	assert.ErrorIs(t, err, queries.ErrJobHasNoResult)
+
+	_, err = query.HandleResult(context.Background(), queries.GetJobQuery{ID: "missing"})
+	assert.Error(t, err)
+}
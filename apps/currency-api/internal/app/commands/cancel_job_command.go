@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// CancelJobCommand cancels a job of Tenant; jobs submitted for another
+// tenant are reported as not found.
+type CancelJobCommand struct {
+	ID     string
+	Tenant string
+}
+
+// JobCanceller stops pending and running jobs of a tenant.
+type JobCanceller interface {
+	Cancel(ctx context.Context, id, tenant string) (entities.Job, error)
+}
+
+type CancelJobCommandHandler struct {
+	canceller JobCanceller
+}
+
+func NewCancelJobCommandHandler(canceller JobCanceller) *CancelJobCommandHandler {
+	return &CancelJobCommandHandler{canceller: canceller}
+}
+
+func (h *CancelJobCommandHandler) Handle(ctx context.Context, cmd CancelJobCommand) (entities.Job, error) {
+	return h.canceller.Cancel(ctx, cmd.ID, cmd.Tenant)
+}
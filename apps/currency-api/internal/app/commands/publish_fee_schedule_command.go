@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/shopspring/decimal"
+)
+
+var maxSpreadBps = decimal.NewFromInt(10000)
+
+// PublishFeeScheduleCommand replaces the whole fee and limit table. Fees are
+// keyed by "FROM-TO" pair or "*" for every other pair, limits by source
+// currency. A zero or past EffectiveFrom takes effect immediately. Actor is
+// the authenticated publisher, ReportedActor an optional unverified claim.
+type PublishFeeScheduleCommand struct {
+	Fees          map[string]entities.Fee
+	Limits        map[string]entities.AmountLimit
+	EffectiveFrom time.Time
+	Actor         string
+	ReportedActor string
+	Reason        string
+}
+
+// FeeScheduleError rejects a schedule, listing every problem found.
+type FeeScheduleError struct {
+	Problems []string
+}
+
+func (e *FeeScheduleError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+type PublishFeeScheduleCommandHandler struct {
+	repo repositories.FeeScheduleRepository
+	now  func() time.Time
+}
+
+func NewPublishFeeScheduleCommandHandler(repo repositories.FeeScheduleRepository) *PublishFeeScheduleCommandHandler {
+	return &PublishFeeScheduleCommandHandler{repo: repo, now: time.Now}
+}
+
+// Handle validates and publishes the schedule as a new version and records
+// an audit entry with the changes against the previous version.
+func (h *PublishFeeScheduleCommandHandler) Handle(ctx context.Context, cmd PublishFeeScheduleCommand) (entities.FeeSchedule, error) {
+	now := h.now().UTC().Truncate(time.Second)
+	schedule := entities.FeeSchedule{
+		EffectiveFrom: cmd.EffectiveFrom.UTC().Truncate(time.Second),
+		PublishedAt:   now,
+		PublishedBy:   strings.TrimSpace(cmd.Actor),
+		Fees:          make(map[string]entities.Fee, len(cmd.Fees)),
+		Limits:        make(map[string]entities.AmountLimit, len(cmd.Limits)),
+	}
+	if schedule.EffectiveFrom.Before(now) {
+		schedule.EffectiveFrom = now
+	}
+	for key, fee := range cmd.Fees {
+		schedule.Fees[strings.ToUpper(strings.TrimSpace(key))] = fee
+	}
+	for currency, limit := range cmd.Limits {
+		schedule.Limits[strings.ToUpper(strings.TrimSpace(currency))] = limit
+	}
+
+	if err := validateFeeSchedule(schedule, cmd.Reason); err != nil {
+		return entities.FeeSchedule{}, err
+	}
+
+	versions, err := h.repo.Versions(ctx)
+	if err != nil {
+		return entities.FeeSchedule{}, err
+	}
+	var previous entities.FeeSchedule
+	if len(versions) > 0 {
+		previous = versions[len(versions)-1]
+	}
+
+	return h.repo.Publish(ctx, schedule, entities.FeeAuditEntry{
+		Actor:         schedule.PublishedBy,
+		ReportedActor: strings.TrimSpace(cmd.ReportedActor),
+		Reason:        strings.TrimSpace(cmd.Reason),
+		EffectiveFrom: schedule.EffectiveFrom,
+		RecordedAt:    now,
+		Changes:       diffFeeSchedules(previous, schedule),
+	})
+}
+
+func validateFeeSchedule(schedule entities.FeeSchedule, reason string) error {
+	var problems []string
+	if schedule.PublishedBy == "" {
+		problems = append(problems, "actor is required")
+	}
+	if strings.TrimSpace(reason) == "" {
+		problems = append(problems, "reason is required")
+	}
+
+	for _, key := range sortedKeys(schedule.Fees) {
+		fee := schedule.Fees[key]
+		if key != entities.DefaultFeeKey {
+			from, to, _ := strings.Cut(key, "-")
+			if !supportedCurrency(from) || !supportedCurrency(to) {
+				problems = append(problems, fmt.Sprintf("fees.%s must be a pair of supported currencies such as WBTC-USDT, or %s", key, entities.DefaultFeeKey))
+				continue
+			}
+		}
+		if fee.SpreadBps.IsNegative() || fee.SpreadBps.GreaterThanOrEqual(maxSpreadBps) {
+			problems = append(problems, fmt.Sprintf("fees.%s.spread_bps must be at least 0 and below %s", key, maxSpreadBps.String()))
+		}
+		if fee.Flat.IsNegative() {
+			problems = append(problems, fmt.Sprintf("fees.%s.flat cannot be negative", key))
+		}
+	}
+
+	for _, currency := range sortedKeys(schedule.Limits) {
+		limit := schedule.Limits[currency]
+		if !supportedCurrency(currency) {
+			problems = append(problems, fmt.Sprintf("limits.%s is not a supported currency", currency))
+			continue
+		}
+		if limit.Min.IsNegative() || limit.Max.IsNegative() {
+			problems = append(problems, fmt.Sprintf("limits.%s cannot be negative", currency))
+		}
+		if limit.Max.IsPositive() && limit.Max.LessThan(limit.Min) {
+			problems = append(problems, fmt.Sprintf("limits.%s.max cannot be below min", currency))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &FeeScheduleError{Problems: problems}
+	}
+	return nil
+}
+
+func supportedCurrency(code string) bool {
+	_, err := entities.GetCurrency(code)
+	return err == nil
+}
+
+// diffFeeSchedules describes what next changes compared with previous, one
+// line per fee or limit, e.g. "fees.WBTC-USDT.spread_bps: 25 -> 40".
+func diffFeeSchedules(previous, next entities.FeeSchedule) []string {
+	changes := []string{}
+	describe := func(prefix string, before, after map[string]string, existed, exists bool) {
+		switch {
+		case !existed && exists:
+			changes = append(changes, fmt.Sprintf("%s added: %s", prefix, renderFields(after)))
+		case existed && !exists:
+			changes = append(changes, fmt.Sprintf("%s removed", prefix))
+		case existed && exists:
+			for _, field := range sortedKeys(after) {
+				if before[field] != after[field] {
+					changes = append(changes, fmt.Sprintf("%s.%s: %s -> %s", prefix, field, before[field], after[field]))
+				}
+			}
+		}
+	}
+
+	for _, key := range unionKeys(previous.Fees, next.Fees) {
+		before, existed := previous.Fees[key]
+		after, exists := next.Fees[key]
+		describe("fees."+key, feeFields(before), feeFields(after), existed, exists)
+	}
+	for _, key := range unionKeys(previous.Limits, next.Limits) {
+		before, existed := previous.Limits[key]
+		after, exists := next.Limits[key]
+		describe("limits."+key, limitFields(before), limitFields(after), existed, exists)
+	}
+	return changes
+}
+
+func feeFields(fee entities.Fee) map[string]string {
+	return map[string]string{"spread_bps": fee.SpreadBps.String(), "flat": fee.Flat.String()}
+}
+
+func limitFields(limit entities.AmountLimit) map[string]string {
+	return map[string]string{"min": limit.Min.String(), "max": limit.Max.String()}
+}
+
+func renderFields(fields map[string]string) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range sortedKeys(fields) {
+		parts = append(parts, field+"="+fields[field])
+	}
+	return strings.Join(parts, " ")
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	union := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		union[key] = struct{}{}
+	}
+	for key := range b {
+		union[key] = struct{}{}
+	}
+	return sortedKeys(union)
+}
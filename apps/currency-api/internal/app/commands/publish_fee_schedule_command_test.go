@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bps(value string) entities.Fee {
+	return entities.Fee{SpreadBps: decimal.RequireFromString(value), Flat: decimal.Zero}
+}
+
+func TestPublishFeeScheduleCommandHandler_Handle(t *testing.T) {
+	repo := repositories.NewMemoryFeeScheduleRepository()
+	handler := NewPublishFeeScheduleCommandHandler(repo)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 500, time.UTC)
+	handler.now = func() time.Time { return now }
+
+	first, err := handler.Handle(context.Background(), PublishFeeScheduleCommand{
+		Fees:          map[string]entities.Fee{"*": bps("25"), " wbtc-usdt ": bps("40")},
+		Limits:        map[string]entities.AmountLimit{"usdt": {Min: decimal.RequireFromString("10")}},
+		EffectiveFrom: now.Add(-time.Hour),
+		Actor:         " ops@example.com ",
+		Reason:        "launch",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Version)
+	assert.Equal(t, now.Truncate(time.Second), first.EffectiveFrom, "a past effective time applies now")
+	assert.Equal(t, "ops@example.com", first.PublishedBy)
+	assert.Contains(t, first.Fees, "WBTC-USDT")
+	assert.Contains(t, first.Limits, "USDT")
+
+	effectiveFrom := now.Add(time.Hour)
+	second, err := handler.Handle(context.Background(), PublishFeeScheduleCommand{
+		Fees:          map[string]entities.Fee{"*": bps("25"), "WBTC-USDT": bps("60"), "BEER-USDT": bps("10")},
+		EffectiveFrom: effectiveFrom,
+		Actor:         "ops@example.com",
+		ReportedActor: " pricing-ops@example.com ",
+		Reason:        "volatility",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, effectiveFrom.Truncate(time.Second), second.EffectiveFrom)
+
+	audit, err := repo.Audit(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, audit, 2)
+	assert.Equal(t, []string{
+		"fees.* added: flat=0 spread_bps=25",
+		"fees.WBTC-USDT added: flat=0 spread_bps=40",
+		"limits.USDT added: max=0 min=10",
+	}, audit[1].Changes)
+	assert.Equal(t, entities.FeeAuditEntry{
+		Version:       2,
+		Actor:         "ops@example.com",
+		ReportedActor: "pricing-ops@example.com",
+		Reason:        "volatility",
+		EffectiveFrom: second.EffectiveFrom,
+		RecordedAt:    now.Truncate(time.Second),
+		Changes: []string{
+			"fees.BEER-USDT added: flat=0 spread_bps=10",
+			"fees.WBTC-USDT.spread_bps: 40 -> 60",
+			"limits.USDT removed",
+		},
+	}, audit[0])
+}
+
+func TestPublishFeeScheduleCommandHandler_Handle_Invalid(t *testing.T) {
+	repo := repositories.NewMemoryFeeScheduleRepository()
+	handler := NewPublishFeeScheduleCommandHandler(repo)
+
+	_, err := handler.Handle(context.Background(), PublishFeeScheduleCommand{
+		Fees: map[string]entities.Fee{
+			"WBTC":      bps("10"),
+			"WBTC-USDT": {SpreadBps: decimal.RequireFromString("10000"), Flat: decimal.RequireFromString("-1")},
+		},
+		Limits: map[string]entities.AmountLimit{
+			"XYZ":  {},
+			"USDT": {Min: decimal.RequireFromString("100"), Max: decimal.RequireFromString("10")},
+		},
+	})
+
+	var scheduleErr *FeeScheduleError
+	require.ErrorAs(t, err, &scheduleErr)
+	assert.Equal(t, []string{
+		"actor is required",
+		"reason is required",
+		"fees.WBTC must be a pair of supported currencies such as WBTC-USDT, or *",
+		"fees.WBTC-USDT.spread_bps must be at least 0 and below 10000",
+		"fees.WBTC-USDT.flat cannot be negative",
+		"limits.USDT.max cannot be below min",
+		"limits.XYZ is not a supported currency",
+	}, scheduleErr.Problems)
+
+	versions, err := repo.Versions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
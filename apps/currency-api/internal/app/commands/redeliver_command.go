@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// RedeliverCommand sends a recorded notification again.
+type RedeliverCommand struct {
+	SubscriptionID string
+	DeliveryID     string
+}
+
+// Deliverer sends a notification and records the delivery.
+type Deliverer interface {
+	Deliver(ctx context.Context, notification entities.Notification, redeliveryOf string) (entities.Delivery, error)
+}
+
+type RedeliverCommandHandler struct {
+	repo      repositories.DeliveryRepository
+	deliverer Deliverer
+}
+
+func NewRedeliverCommandHandler(repo repositories.DeliveryRepository, deliverer Deliverer) *RedeliverCommandHandler {
+	return &RedeliverCommandHandler{repo: repo, deliverer: deliverer}
+}
+
+// Handle returns the new delivery. A failed send is not an error here; it is
+// reported by the delivery's status.
+func (h *RedeliverCommandHandler) Handle(ctx context.Context, cmd RedeliverCommand) (entities.Delivery, error) {
+	original, err := h.repo.Get(ctx, cmd.SubscriptionID, cmd.DeliveryID)
+	if err != nil {
+		return entities.Delivery{}, err
+	}
+
+	delivery, _ := h.deliverer.Deliver(ctx, original.Notification, original.ID)
+	return delivery, nil
+}
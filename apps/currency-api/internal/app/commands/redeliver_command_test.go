@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	domainrepositories "github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDeliverer struct {
+	err error
+}
+
+func (d *stubDeliverer) Deliver(ctx context.Context, notification entities.Notification, redeliveryOf string) (entities.Delivery, error) {
+	delivery := entities.Delivery{ID: "d2", SubscriptionID: notification.SubscriptionID, Notification: notification, Status: entities.DeliverySucceeded, RedeliveryOf: redeliveryOf}
+	if d.err != nil {
+		delivery.Status = entities.DeliveryFailed
+		delivery.Error = d.err.Error()
+	}
+	return delivery, d.err
+}
+
+func TestRedeliverCommandHandler_Handle(t *testing.T) {
+	repo := repositories.NewMemoryDeliveryRepository(10)
+	original := entities.Delivery{
+		ID:             "d1",
+		SubscriptionID: "sub-1",
+		Notification:   entities.Notification{SubscriptionID: "sub-1", Kind: entities.NotificationRateAlert},
+		Status:         entities.DeliveryFailed,
+	}
+	require.NoError(t, repo.Save(context.Background(), original))
+
+	handler := NewRedeliverCommandHandler(repo, &stubDeliverer{err: errors.New("unexpected status 503: ")})
+	delivery, err := handler.Handle(context.Background(), RedeliverCommand{SubscriptionID: "sub-1", DeliveryID: "d1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.DeliveryFailed, delivery.Status)
+	assert.Equal(t, "d1", delivery.RedeliveryOf)
+	assert.Equal(t, "sub-1", delivery.Notification.SubscriptionID)
+
+	_, err = handler.Handle(context.Background(), RedeliverCommand{SubscriptionID: "sub-2", DeliveryID: "d1"})
+	assert.ErrorIs(t, err, domainrepositories.ErrDeliveryNotFound)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	config           *config.Config
+	logger           logger.Logger
+	providerReporter services.ProviderReporter
+	precisionAudits  *queries.GetPrecisionAuditQueryHandler
+	now              func() time.Time
+}
+
+type AdminHandlerOption func(*AdminHandler)
+
+func WithProviderReporter(reporter services.ProviderReporter) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.providerReporter = reporter
+	}
+}
+
+// WithPrecisionAudits serves the reports of the nightly precision audit.
+func WithPrecisionAudits(handler *queries.GetPrecisionAuditQueryHandler) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.precisionAudits = handler
+	}
+}
+
+func NewAdminHandler(cfg *config.Config, log logger.Logger, opts ...AdminHandlerOption) *AdminHandler {
+	h := &AdminHandler{
+		config: cfg,
+		logger: log,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// @Summary Effective configuration
+// @Description Runtime configuration of this instance with secrets redacted. Every setting names where its value came from: the built-in default, an environment variable, or a remote source such as ssm:/currency-api/prod. Requires a bearer token when ADMIN_TOKEN is set.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ConfigResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Router /admin/config [get]
+func (h *AdminHandler) GetConfig(c *gin.Context) {
+	settings := h.config.Settings()
+
+	response := ConfigResponse{
+		Environment: h.config.Environment,
+		Region:      h.config.Region,
+		InstanceID:  h.config.InstanceID,
+		Settings:    make([]ConfigSettingResponse, len(settings)),
+	}
+	for i, setting := range settings {
+		response.Settings[i] = ConfigSettingResponse{
+			Key:      setting.Key,
+			Value:    setting.Value,
+			Source:   setting.Source,
+			Redacted: setting.Redacted,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Rate provider comparison
+// @Description Daily comparison of the primary rate source and the providers configured in COMPARE_RATE_PROVIDERS: availability, latency, deviation from the median of all providers, and month-to-date quota used. Requires a bearer token when ADMIN_TOKEN is set.
+// @Tags Admin
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param date query string false "UTC day to report on (YYYY-MM-DD), defaults to today"
+// @Param format query string false "Response format" Enums(json,csv)
+// @Success 200 {object} ProviderReportResponse
+// @Failure 400 {object} AdminErrorResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 404 {object} AdminErrorResponse
+// @Router /admin/providers/report [get]
+func (h *AdminHandler) GetProviderReport(c *gin.Context) {
+	if h.providerReporter == nil {
+		c.JSON(http.StatusNotFound, AdminErrorResponse{Error: "provider reports are not enabled"})
+		return
+	}
+
+	date := h.now().UTC()
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, AdminErrorResponse{Error: "date must be formatted as YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, AdminErrorResponse{Error: "format must be one of: json, csv"})
+		return
+	}
+
+	response := newProviderReportResponse(h.providerReporter.Report(date))
+	if format == "json" {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	body, err := renderProviderReport(response)
+	if err != nil {
+		h.logger.Error("Failed to render provider report", err)
+		c.JSON(http.StatusInternalServerError, AdminErrorResponse{Error: "failed to render provider report"})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="provider-report-%s.csv"`, response.Date))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+}
+
+// @Summary Conversion precision audit
+// @Description Nightly report on a sample of one UTC day's conversions, recomputed with exact arithmetic from the currency rates and fees they were served with. Conversions more than one unit in the last place away from the exact result count as discrepancies, and the largest are listed in examples. For conversions adjusted by a pricing rule, counted in priced, the amount handed to the rule is checked instead of the result. Requires a bearer token when ADMIN_TOKEN is set.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "UTC day to report on (YYYY-MM-DD), defaults to yesterday"
+// @Success 200 {object} PrecisionAuditResponse
+// @Failure 400 {object} AdminErrorResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 404 {object} AdminErrorResponse
+// @Failure 500 {object} AdminErrorResponse
+// @Router /admin/audits/precision [get]
+func (h *AdminHandler) GetPrecisionAudit(c *gin.Context) {
+	if h.precisionAudits == nil {
+		c.JSON(http.StatusNotFound, AdminErrorResponse{Error: "precision audits are not enabled"})
+		return
+	}
+
+	report, ok, err := h.precisionAudits.Handle(c.Request.Context(), queries.GetPrecisionAuditQuery{Date: c.Query("date")})
+	if errors.Is(err, queries.ErrInvalidAuditDate) {
+		c.JSON(http.StatusBadRequest, AdminErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to load precision audit", err)
+		c.JSON(http.StatusInternalServerError, AdminErrorResponse{Error: "failed to load precision audit"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, AdminErrorResponse{Error: "no precision audit has run for that day"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PrecisionAuditResponse{
+		Date:          report.Date.Format(time.DateOnly),
+		RanAt:         report.RanAt,
+		Sampled:       report.Sampled,
+		Checked:       report.Checked,
+		Skipped:       report.Skipped,
+		Priced:        report.Priced,
+		Discrepancies: report.Discrepancies,
+		MaxULPs:       report.MaxULPs,
+		Examples:      report.Examples,
+	})
+}
+
+func newProviderReportResponse(report entities.ProviderReport) ProviderReportResponse {
+	response := ProviderReportResponse{
+		Date:      report.Date.Format(time.DateOnly),
+		Providers: make([]ProviderStatsResponse, len(report.Providers)),
+	}
+	for i, stats := range report.Providers {
+		response.Providers[i] = ProviderStatsResponse{
+			Provider:         stats.Provider,
+			Primary:          stats.Primary,
+			Requests:         stats.Requests,
+			Failures:         stats.Failures,
+			Availability:     stats.Availability,
+			LatencyP50Ms:     float64(stats.LatencyP50.Microseconds()) / 1000,
+			LatencyP95Ms:     float64(stats.LatencyP95.Microseconds()) / 1000,
+			MeanDeviation:    stats.MeanDeviation,
+			DeviationSamples: stats.DeviationSamples,
+			QuotaUsed:        stats.QuotaUsed,
+		}
+	}
+	return response
+}
+
+func renderProviderReport(report ProviderReportResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"date", "provider", "primary", "requests", "failures", "availability", "latency_p50_ms", "latency_p95_ms", "mean_deviation", "deviation_samples", "quota_used"}); err != nil {
+		return nil, err
+	}
+
+	for _, stats := range report.Providers {
+		record := []string{
+			report.Date,
+			stats.Provider,
+			strconv.FormatBool(stats.Primary),
+			strconv.Itoa(stats.Requests),
+			strconv.Itoa(stats.Failures),
+			strconv.FormatFloat(stats.Availability, 'f', -1, 64),
+			strconv.FormatFloat(stats.LatencyP50Ms, 'f', -1, 64),
+			strconv.FormatFloat(stats.LatencyP95Ms, 'f', -1, 64),
+			strconv.FormatFloat(stats.MeanDeviation, 'f', -1, 64),
+			strconv.Itoa(stats.DeviationSamples),
+			strconv.Itoa(stats.QuotaUsed),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/app/commands"
+	"github.com/ajs/currency-api/internal/app/jobs"
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const bulkUploadExample = "pair,amount,date\nWBTC-USDT,1.5,2026-10-16\nUSDT/GATE,250"
+
+type BulkExchangeHandler struct {
+	commandHandler *commands.BulkExchangeCommandHandler
+	maxUploadBytes int64
+	logger         logger.Logger
+}
+
+func NewBulkExchangeHandler(commandHandler *commands.BulkExchangeCommandHandler, maxUploadBytes int64, logger logger.Logger) *BulkExchangeHandler {
+	return &BulkExchangeHandler{
+		commandHandler: commandHandler,
+		maxUploadBytes: maxUploadBytes,
+		logger:         logger,
+	}
+}
+
+// @Summary Upload a bulk conversion file
+// @Description Accept a CSV of pair,amount[,date] rows (pair as FROM-TO or FROM/TO, optional header row) either as multipart field "file" or as a text/csv body. Rows are converted by an async job; poll the job's status URL and download the results once completed.
+// @Tags Exchange
+// @Accept text/csv,multipart/form-data
+// @Produce json
+// @Param file formData file false "CSV file (multipart uploads)"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); the key's tenant pricing rule is applied"
+// @Success 202 {object} JobResponse
+// @Failure 400 {object} BulkConversionErrorResponse
+// @Failure 401 {object} BulkConversionErrorResponse
+// @Failure 413 {object} BulkConversionErrorResponse
+// @Failure 503 {object} BulkConversionErrorResponse
+// @Router /api/v1/exchange/bulk [post]
+func (h *BulkExchangeHandler) Create(c *gin.Context) {
+	if h.maxUploadBytes > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes)
+	}
+
+	upload, err := h.openUpload(c)
+	if err != nil {
+		h.rejectUpload(c, err)
+		return
+	}
+	defer upload.Close()
+
+	job, err := h.commandHandler.Handle(c.Request.Context(), commands.BulkExchangeCommand{
+		CSV:    upload,
+		Tenant: auth.Tenant(c.Request.Context()),
+	})
+	if err != nil {
+		h.rejectUpload(c, err)
+		return
+	}
+
+	response := newJobResponse(job, nil, nil)
+	c.Header("Location", response.StatusURL)
+	c.JSON(http.StatusAccepted, response)
+}
+
+func (h *BulkExchangeHandler) openUpload(c *gin.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		return c.Request.Body, nil
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, &commands.BulkUploadError{Message: `multipart uploads must contain the CSV in field "file"`}
+	}
+	return file.Open()
+}
+
+func (h *BulkExchangeHandler) rejectUpload(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, BulkConversionErrorResponse{
+			Error: fmt.Sprintf("uploads are limited to %d bytes; split the file", h.maxUploadBytes),
+		})
+		return
+	}
+
+	var uploadErr *commands.BulkUploadError
+	if errors.As(err, &uploadErr) {
+		h.logger.Warn("Invalid bulk conversion upload", "error", err)
+		c.JSON(http.StatusBadRequest, BulkConversionErrorResponse{
+			Error:   uploadErr.Message,
+			Line:    uploadErr.Line,
+			Example: bulkUploadExample,
+		})
+		return
+	}
+
+	if errors.Is(err, jobs.ErrQueueFull) {
+		h.logger.Warn("Rejected bulk conversion", "error", err)
+		c.JSON(http.StatusServiceUnavailable, BulkConversionErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Error("Failed to accept bulk conversion", err)
+	c.JSON(http.StatusBadRequest, BulkConversionErrorResponse{Error: "failed to read upload"})
+}
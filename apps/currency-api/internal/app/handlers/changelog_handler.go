@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type ChangelogHandler struct {
+	queryHandler *queries.GetChangelogQueryHandler
+	logger       logger.Logger
+}
+
+func NewChangelogHandler(queryHandler *queries.GetChangelogQueryHandler, logger logger.Logger) *ChangelogHandler {
+	return &ChangelogHandler{
+		queryHandler: queryHandler,
+		logger:       logger,
+	}
+}
+
+// @Summary		Get API changelog
+// @Description	Machine-readable list of API contract changes, newest first
+// @Tags			System
+// @Accept			json
+// @Produce		json
+// @Param			version			query		string	false	"Only entries for this exact version (e.g., 2.0.0)"
+// @Param			since_version	query		string	false	"Only entries newer than this version (exclusive)"
+// @Param			since			query		string	false	"Only entries on or after this date (YYYY-MM-DD)"
+// @Param			until			query		string	false	"Only entries on or before this date (YYYY-MM-DD)"
+// @Success		200				{object}	ChangelogResponse
+// @Failure		400				{object}	ChangelogErrorResponse
+// @Router			/api/v1/changelog [get]
+func (h *ChangelogHandler) GetChangelog(c *gin.Context) {
+	query := queries.GetChangelogQuery{
+		Version:      c.Query("version"),
+		SinceVersion: c.Query("since_version"),
+		Since:        c.Query("since"),
+		Until:        c.Query("until"),
+	}
+
+	entries, err := h.queryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		h.logger.Warn("Invalid changelog request", "error", err)
+		c.JSON(http.StatusBadRequest, ChangelogErrorResponse{
+			Error:   err.Error(),
+			Example: "GET /api/v1/changelog?since_version=2.0.0",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ChangelogResponse{
+		Count:   len(entries),
+		Entries: entries,
+	})
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/ajs/currency-api/internal/infrastructure/encoding"
 	"github.com/ajs/go-common/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -11,25 +15,43 @@ import (
 type ExchangeHandler struct {
 	queryHandler *queries.ExchangeQueryHandler
 	logger       logger.Logger
+	encoder      encoding.JSONEncoder
 }
 
-func NewExchangeHandler(queryHandler *queries.ExchangeQueryHandler, logger logger.Logger) *ExchangeHandler {
-	return &ExchangeHandler{
+type ExchangeHandlerOption func(*ExchangeHandler)
+
+func WithExchangeJSONEncoder(encoder encoding.JSONEncoder) ExchangeHandlerOption {
+	return func(h *ExchangeHandler) {
+		h.encoder = encoder
+	}
+}
+
+func NewExchangeHandler(queryHandler *queries.ExchangeQueryHandler, logger logger.Logger, opts ...ExchangeHandlerOption) *ExchangeHandler {
+	h := &ExchangeHandler{
 		queryHandler: queryHandler,
 		logger:       logger,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // @Summary Exchange cryptocurrencies
-// @Description Convert one cryptocurrency to another using predefined exchange rates
+// @Description Convert one cryptocurrency to another using predefined exchange rates. With a network, the result is cut to the target token's decimals on that chain and compared with the chain's dust limit. A positive conversion that rounds to zero carries an AMOUNT_UNDERFLOW warning with the unrounded value and the smallest meaningful amount. The fee schedule in effect is charged on the result and reported in fee; amounts outside its limits are rejected with AMOUNT_BELOW_MINIMUM or AMOUNT_ABOVE_MAXIMUM. A tenant pricing rule that fails answers 500 with PRICING_RULE_FAILED, and one that produces a negative or non-numeric amount answers 422 with INVALID_PRICED_AMOUNT.
 // @Tags Exchange
 // @Accept json
 // @Produce json
 // @Param from query string true "Source cryptocurrency code" Enums(BEER,FLOKI,GATE,USDT,WBTC)
 // @Param to query string true "Target cryptocurrency code" Enums(BEER,FLOKI,GATE,USDT,WBTC)
 // @Param amount query number true "Amount to exchange" minimum(0.000001)
+// @Param network query string false "Chain the target asset is delivered on (e.g. ethereum, arbitrum, polygon)"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); the key's tenant pricing rule is applied"
 // @Success 200 {object} entities.ExchangeResult
-// @Failure 400 {object} HTTPError
+// @Failure 400 {object} ExchangeErrorResponse
+// @Failure 401 {object} ExchangeErrorResponse
+// @Failure 422 {object} ExchangeErrorResponse
+// @Failure 500 {object} ExchangeErrorResponse
 // @Router /api/v1/exchange [get]
 func (h *ExchangeHandler) Exchange(c *gin.Context) {
 	from := c.Query("from")
@@ -37,17 +59,58 @@ func (h *ExchangeHandler) Exchange(c *gin.Context) {
 	amount := c.Query("amount")
 
 	query := queries.ExchangeQuery{
-		From:   from,
-		To:     to,
-		Amount: amount,
+		From:    from,
+		To:      to,
+		Amount:  amount,
+		Tenant:  auth.Tenant(c.Request.Context()),
+		Network: c.Query("network"),
 	}
 
 	result, err := h.queryHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		h.logger.Error("Failed to process exchange", err)
-		c.JSON(http.StatusBadRequest, gin.H{})
+		var validationErr *queries.ExchangeValidationError
+		if errors.As(err, &validationErr) {
+			h.logger.Warn("Invalid exchange request", "error", err, "code", validationErr.Code)
+			c.JSON(http.StatusBadRequest, newExchangeErrorResponse(validationErr))
+			return
+		}
+
+		switch {
+		case errors.Is(err, services.ErrInvalidPricedAmount):
+			h.logger.Error("Pricing rule produced an invalid amount", err, "tenant", query.Tenant)
+			c.JSON(http.StatusUnprocessableEntity, ExchangeErrorResponse{
+				Error: "The pricing rule for this conversion produced an invalid amount. This is not a problem with your request.",
+				Code:  queries.ErrCodeInvalidPricedAmount,
+			})
+		case errors.Is(err, services.ErrPricingRuleFailed):
+			h.logger.Error("Pricing rule failed", err, "tenant", query.Tenant)
+			c.JSON(http.StatusInternalServerError, ExchangeErrorResponse{
+				Error: "The pricing rule for this conversion could not be evaluated. This is not a problem with your request.",
+				Code:  queries.ErrCodePricingRuleFailed,
+			})
+		default:
+			h.logger.Error("Failed to process exchange", err)
+			c.JSON(http.StatusInternalServerError, ExchangeErrorResponse{
+				Error: "Failed to process the exchange.",
+				Code:  queries.ErrCodeInternal,
+			})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeJSON(c, h.logger, h.encoder, http.StatusOK, result)
+}
+
+func newExchangeErrorResponse(err *queries.ExchangeValidationError) ExchangeErrorResponse {
+	response := ExchangeErrorResponse{
+		Error:      err.Message,
+		Code:       err.Code,
+		Suggestion: err.Suggestion,
+		Example:    err.Example,
+	}
+	if !err.MinAmount.IsZero() {
+		minAmount := err.MinAmount
+		response.MinAmount = &minAmount
+	}
+	return response
 }
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/app/commands"
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type FeesHandler struct {
+	scheduleHandler *queries.GetFeeScheduleQueryHandler
+	auditHandler    *queries.ListFeeAuditQueryHandler
+	publishHandler  *commands.PublishFeeScheduleCommandHandler
+	logger          logger.Logger
+}
+
+func NewFeesHandler(scheduleHandler *queries.GetFeeScheduleQueryHandler, auditHandler *queries.ListFeeAuditQueryHandler, publishHandler *commands.PublishFeeScheduleCommandHandler, logger logger.Logger) *FeesHandler {
+	return &FeesHandler{
+		scheduleHandler: scheduleHandler,
+		auditHandler:    auditHandler,
+		publishHandler:  publishHandler,
+		logger:          logger,
+	}
+}
+
+// @Summary Fee schedule
+// @Description Fee and amount limit table this instance applies to conversions, and the versions staged to take effect later. Requires a bearer token when ADMIN_TOKEN is set.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} FeeScheduleResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Router /admin/fees [get]
+func (h *FeesHandler) Get(c *gin.Context) {
+	view := h.scheduleHandler.Handle(c.Request.Context())
+	c.JSON(http.StatusOK, FeeScheduleResponse{Current: view.Current, Pending: view.Pending})
+}
+
+// @Summary Publish a fee schedule
+// @Description Replace the fee and amount limit table. The schedule is stored as a new version and applied by every instance as soon as effective_from has passed (immediately when omitted), without a deploy. Each publish is recorded in the audit trail with its reason and changes, attributed to the holder of the bearer token; an actor given in the body is kept as reported_actor. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body FeeScheduleRequest true "Complete fee schedule"
+// @Success 201 {object} entities.FeeSchedule
+// @Failure 400 {object} FeeScheduleErrorResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 403 {object} AdminErrorResponse
+// @Failure 500 {object} AdminErrorResponse
+// @Router /admin/fees [put]
+func (h *FeesHandler) Publish(c *gin.Context) {
+	var request FeeScheduleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, FeeScheduleErrorResponse{Error: "invalid fee schedule: " + err.Error()})
+		return
+	}
+
+	actor, _ := auth.Principal(c.Request.Context())
+	cmd := commands.PublishFeeScheduleCommand{
+		Fees:          request.Fees,
+		Limits:        request.Limits,
+		Actor:         actor,
+		ReportedActor: request.Actor,
+		Reason:        request.Reason,
+	}
+	if request.EffectiveFrom != nil {
+		cmd.EffectiveFrom = *request.EffectiveFrom
+	}
+
+	schedule, err := h.publishHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		var scheduleErr *commands.FeeScheduleError
+		if errors.As(err, &scheduleErr) {
+			c.JSON(http.StatusBadRequest, FeeScheduleErrorResponse{Error: "invalid fee schedule", Problems: scheduleErr.Problems})
+			return
+		}
+
+		h.logger.Error("Failed to publish fee schedule", err)
+		c.JSON(http.StatusInternalServerError, AdminErrorResponse{Error: "failed to publish fee schedule"})
+		return
+	}
+
+	h.logger.Info("Fee schedule published", "version", schedule.Version, "effective_from", schedule.EffectiveFrom, "actor", schedule.PublishedBy)
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// @Summary Fee schedule audit trail
+// @Description Published fee schedule versions, newest first, with who published them, why, and what changed compared with the version before. Requires a bearer token when ADMIN_TOKEN is set.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum entries to return (1-500, default 50)"
+// @Success 200 {object} FeeAuditResponse
+// @Failure 400 {object} AdminErrorResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 500 {object} AdminErrorResponse
+// @Router /admin/fees/audit [get]
+func (h *FeesHandler) Audit(c *gin.Context) {
+	entries, err := h.auditHandler.Handle(c.Request.Context(), queries.ListFeeAuditQuery{Limit: c.Query("limit")})
+	if errors.Is(err, queries.ErrInvalidFeeAuditLimit) {
+		c.JSON(http.StatusBadRequest, AdminErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to load fee schedule audit", err)
+		c.JSON(http.StatusInternalServerError, AdminErrorResponse{Error: "failed to load fee schedule audit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, FeeAuditResponse{Count: len(entries), Entries: entries})
+}
@@ -1,24 +1,72 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/currency-api/internal/infrastructure/election"
+	"github.com/ajs/currency-api/internal/infrastructure/freshness"
+	"github.com/ajs/currency-api/internal/infrastructure/supervisor"
+	"github.com/ajs/currency-api/internal/version"
 	"github.com/ajs/go-common/logger"
+	"github.com/ajs/go-common/timefmt"
 	"github.com/gin-gonic/gin"
 )
 
 type HealthHandler struct {
-	config *config.Config
-	logger logger.Logger
+	config     *config.Config
+	logger     logger.Logger
+	freshness  FreshnessReporter
+	leadership LeadershipReporter
+	workers    WorkerReporter
 }
 
-func NewHealthHandler(cfg *config.Config, log logger.Logger) *HealthHandler {
-	return &HealthHandler{
+// FreshnessReporter reports per asset class snapshot freshness for readiness.
+type FreshnessReporter interface {
+	Status() []freshness.ClassStatus
+}
+
+// LeadershipReporter reports which instance runs the leader-only jobs.
+type LeadershipReporter interface {
+	Leadership() election.Status
+}
+
+// WorkerReporter reports the state of the supervised background workers.
+type WorkerReporter interface {
+	Status() []supervisor.WorkerStatus
+}
+
+type HealthHandlerOption func(*HealthHandler)
+
+func WithFreshnessReporter(reporter FreshnessReporter) HealthHandlerOption {
+	return func(h *HealthHandler) {
+		h.freshness = reporter
+	}
+}
+
+func WithLeadershipReporter(reporter LeadershipReporter) HealthHandlerOption {
+	return func(h *HealthHandler) {
+		h.leadership = reporter
+	}
+}
+
+func WithWorkerReporter(reporter WorkerReporter) HealthHandlerOption {
+	return func(h *HealthHandler) {
+		h.workers = reporter
+	}
+}
+
+func NewHealthHandler(cfg *config.Config, log logger.Logger, opts ...HealthHandlerOption) *HealthHandler {
+	h := &HealthHandler{
 		config: cfg,
 		logger: log,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // @Summary Health check
@@ -32,12 +80,12 @@ func (h *HealthHandler) Health(c *gin.Context) {
 	response := gin.H{
 		"status":    "healthy",
 		"service":   "currency-exchange-api",
-		"version":   "2.0.0",
-		"timestamp": time.Now().Unix(),
+		"version":   version.Version,
+		"timestamp": timefmt.UTC(time.Now()),
 		"environment": map[string]interface{}{
 			"mode":     h.config.Environment,
 			"gin_mode": h.config.GinMode,
-			"port":     h.config.Port,
+			"port":     h.config.ReadPort,
 		},
 		"framework":  "gin-gonic",
 		"nx_plugin":  "@naxodev/gonx",
@@ -60,5 +108,87 @@ func (h *HealthHandler) Health(c *gin.Context) {
 		},
 	}
 
+	if h.leadership != nil {
+		response["leadership"] = newLeadershipInfo(h.leadership.Leadership())
+	}
+
 	c.JSON(http.StatusOK, response)
 }
+
+// @Summary Readiness check
+// @Description Report whether rate snapshots meet their freshness SLOs and whether the background workers are running. Violations, including workers given up on after too many crashes, are listed and mark the instance as degraded without failing the probe.
+// @Tags System
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	response := ReadinessResponse{
+		Status:    "ready",
+		Timestamp: timefmt.UTC(time.Now()),
+		Freshness: []FreshnessStatus{},
+	}
+
+	if h.freshness != nil {
+		for _, status := range h.freshness.Status() {
+			response.Freshness = append(response.Freshness, newFreshnessStatus(status))
+			if status.Violation {
+				response.Status = "degraded"
+				response.Violations = append(response.Violations, fmt.Sprintf(
+					"%s rates are %s old (objective %s)", status.AssetClass, status.Age.Round(time.Second), status.Objective,
+				))
+			}
+		}
+	}
+
+	if h.workers != nil {
+		for _, status := range h.workers.Status() {
+			response.Workers = append(response.Workers, newWorkerStatus(status))
+			if status.State == supervisor.StateFailed {
+				response.Status = "degraded"
+				response.Violations = append(response.Violations, fmt.Sprintf(
+					"%s worker gave up after %d restarts: %s", status.Name, status.Restarts, status.LastError,
+				))
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func newLeadershipInfo(status election.Status) LeadershipInfo {
+	result := LeadershipInfo{
+		InstanceID: status.InstanceID,
+		Leader:     status.Leader,
+		IsLeader:   status.IsLeader,
+		Jobs:       status.Jobs,
+	}
+	if status.IsLeader {
+		result.LeaderSince = timefmt.Optional(status.Since, nil)
+	}
+	return result
+}
+
+func newFreshnessStatus(status freshness.ClassStatus) FreshnessStatus {
+	result := FreshnessStatus{
+		AssetClass:       status.AssetClass,
+		ObjectiveSeconds: status.Objective.Seconds(),
+		Violation:        status.Violation,
+		BurnRate:         status.BurnRate,
+	}
+	if !status.LastUpdated.IsZero() {
+		ageSeconds := status.Age.Seconds()
+		result.LastUpdated = timefmt.Optional(status.LastUpdated, nil)
+		result.AgeSeconds = &ageSeconds
+	}
+	return result
+}
+
+func newWorkerStatus(status supervisor.WorkerStatus) WorkerStatus {
+	return WorkerStatus{
+		Name:        status.Name,
+		State:       status.State,
+		Restarts:    status.Restarts,
+		LastError:   status.LastError,
+		LastFailure: timefmt.Optional(status.LastFailure, nil),
+	}
+}
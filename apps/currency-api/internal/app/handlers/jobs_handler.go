@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/commands"
+	"github.com/ajs/currency-api/internal/app/jobs"
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/ajs/currency-api/internal/infrastructure/signedurl"
+	"github.com/ajs/go-common/logger"
+	"github.com/ajs/go-common/timefmt"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// jobEventsPollInterval bounds how stale an event stream gets for jobs
+	// running on another instance, whose transitions are not published here.
+	jobEventsPollInterval = 2 * time.Second
+	jobEventsHeartbeat    = 15 * time.Second
+)
+
+// ResultURLSigner turns a result path into a short-lived download link.
+type ResultURLSigner interface {
+	Sign(objectPath string) signedurl.SignedURL
+}
+
+// JobWatcher streams job state transitions as they happen on this instance.
+type JobWatcher interface {
+	Subscribe(ctx context.Context, id string) <-chan entities.Job
+}
+
+type JobsHandler struct {
+	queryHandler  *queries.GetJobQueryHandler
+	cancelHandler *commands.CancelJobCommandHandler
+	logger        logger.Logger
+	signer        ResultURLSigner
+	watcher       JobWatcher
+}
+
+type JobsHandlerOption func(*JobsHandler)
+
+func WithJobResultSigner(signer ResultURLSigner) JobsHandlerOption {
+	return func(h *JobsHandler) {
+		h.signer = signer
+	}
+}
+
+func WithJobWatcher(watcher JobWatcher) JobsHandlerOption {
+	return func(h *JobsHandler) {
+		h.watcher = watcher
+	}
+}
+
+func NewJobsHandler(queryHandler *queries.GetJobQueryHandler, cancelHandler *commands.CancelJobCommandHandler, logger logger.Logger, opts ...JobsHandlerOption) *JobsHandler {
+	h := &JobsHandler{
+		queryHandler:  queryHandler,
+		cancelHandler: cancelHandler,
+		logger:        logger,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// @Summary Get job status
+// @Description Report the status and progress of an async job. result_url is set once a job with downloadable output has completed. Jobs created with a tenant API key are only found with the same key.
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found"
+// @Param tz query string false "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)"
+// @Success 200 {object} JobResponse
+// @Failure 400 {object} JobErrorResponse
+// @Failure 404 {object} JobErrorResponse
+// @Router /api/v1/jobs/{id} [get]
+func (h *JobsHandler) Get(c *gin.Context) {
+	loc, err := timefmt.ParseZone(c.Query("tz"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, JobErrorResponse{Error: err.Error()})
+		return
+	}
+
+	job, err := h.queryHandler.Handle(c.Request.Context(), queries.GetJobQuery{ID: c.Param("id"), Tenant: auth.Tenant(c.Request.Context())})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newJobResponse(job, h.signer, loc))
+}
+
+// @Summary Stream job progress
+// @Description Server-sent events with the job's state: a "progress" event whenever the status or progress changes, then one final "completed", "failed" or "cancelled" event before the stream closes. Each event's data is a JobResponse. Jobs created with a tenant API key are only found with the same key.
+// @Tags Jobs
+// @Produce text/event-stream
+// @Param id path string true "Job ID"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found"
+// @Param tz query string false "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} JobErrorResponse
+// @Failure 404 {object} JobErrorResponse
+// @Router /api/v1/jobs/{id}/events [get]
+func (h *JobsHandler) Events(c *gin.Context) {
+	loc, err := timefmt.ParseZone(c.Query("tz"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, JobErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	query := queries.GetJobQuery{ID: c.Param("id"), Tenant: auth.Tenant(ctx)}
+
+	var updates <-chan entities.Job
+	if h.watcher != nil {
+		updates = h.watcher.Subscribe(ctx, query.ID)
+	}
+
+	job, err := h.queryHandler.Handle(ctx, query)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	// Streams outlive the server's write timeout; heartbeats detect dead clients.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	poll := time.NewTicker(jobEventsPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(jobEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	h.writeJobEvent(c, job, loc)
+	sent := job
+
+	for !sent.Status.Finished() {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			job = update
+		case <-poll.C:
+			job, err = h.queryHandler.Handle(ctx, query)
+			if err != nil {
+				h.logger.Error("Failed to refresh job for event stream", err, "job", query.ID)
+				return
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+			continue
+		}
+
+		if jobChanged(sent, job) {
+			h.writeJobEvent(c, job, loc)
+			sent = job
+		}
+	}
+}
+
+// @Summary Cancel a job
+// @Description Cancel a pending or running job. Pending jobs are cancelled immediately (200); running jobs stop at their next checkpoint (202). Jobs created with a tenant API key are only found with the same key.
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found"
+// @Success 200 {object} JobResponse
+// @Success 202 {object} JobResponse
+// @Failure 404 {object} JobErrorResponse
+// @Failure 409 {object} JobErrorResponse
+// @Router /api/v1/jobs/{id} [delete]
+func (h *JobsHandler) Cancel(c *gin.Context) {
+	job, err := h.cancelHandler.Handle(c.Request.Context(), commands.CancelJobCommand{ID: c.Param("id"), Tenant: auth.Tenant(c.Request.Context())})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if job.Status.Finished() {
+		status = http.StatusOK
+	}
+	c.JSON(status, newJobResponse(job, h.signer, nil))
+}
+
+// @Summary Download job result
+// @Description Download the output of a completed job, e.g. the CSV of a bulk conversion. When signed downloads are enabled, use the result_url from the status response; the link needs no API key. Otherwise jobs created with a tenant API key are only found with the same key.
+// @Tags Jobs
+// @Produce octet-stream
+// @Param id path string true "Job ID"
+// @Param X-API-Key header string false "Tenant API key (TENANT_API_KEYS); only jobs created with the same key are found"
+// @Success 200 {file} file "Job output"
+// @Failure 404 {object} JobErrorResponse
+// @Failure 409 {object} JobErrorResponse
+// @Router /api/v1/jobs/{id}/result [get]
+func (h *JobsHandler) GetResult(c *gin.Context) {
+	result, err := h.queryHandler.HandleResult(c.Request.Context(), queries.GetJobQuery{
+		ID:         c.Param("id"),
+		Tenant:     auth.Tenant(c.Request.Context()),
+		SignedLink: h.signer != nil,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, result.Filename))
+	c.Data(http.StatusOK, result.ContentType, result.Data)
+}
+
+func (h *JobsHandler) writeJobEvent(c *gin.Context, job entities.Job, loc *time.Location) {
+	event := "progress"
+	if job.Status.Finished() {
+		event = string(job.Status)
+	}
+	c.SSEvent(event, newJobResponse(job, h.signer, loc))
+	c.Writer.Flush()
+}
+
+func jobChanged(previous, current entities.Job) bool {
+	return previous.Status != current.Status ||
+		previous.Processed != current.Processed ||
+		previous.Failed != current.Failed ||
+		previous.CancelRequested != current.CancelRequested
+}
+
+func (h *JobsHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, JobErrorResponse{Error: err.Error()})
+	case errors.Is(err, jobs.ErrJobFinished),
+		errors.Is(err, queries.ErrJobNotCompleted),
+		errors.Is(err, queries.ErrJobHasNoResult):
+		c.JSON(http.StatusConflict, JobErrorResponse{Error: err.Error()})
+	default:
+		h.logger.Error("Failed to handle job request", err, "job", c.Param("id"))
+		c.JSON(http.StatusInternalServerError, JobErrorResponse{Error: "failed to load job"})
+	}
+}
+
+// newJobResponse displays the job's timestamps in loc, or in UTC when loc is
+// nil.
+func newJobResponse(job entities.Job, signer ResultURLSigner, loc *time.Location) JobResponse {
+	statusPath := "/api/v1/jobs/" + job.ID
+
+	response := JobResponse{
+		ID:     job.ID,
+		Type:   job.Type,
+		Status: string(job.Status),
+		Progress: JobProgress{
+			Total:     job.Total,
+			Processed: job.Processed,
+			Failed:    job.Failed,
+		},
+		Error:           job.Error,
+		CancelRequested: job.CancelRequested,
+		CreatedAt:       timefmt.In(job.CreatedAt, loc),
+		StartedAt:       timefmt.Optional(job.StartedAt, loc),
+		CompletedAt:     timefmt.Optional(job.CompletedAt, loc),
+		StatusURL:       statusPath,
+	}
+	if job.Total > 0 {
+		response.Progress.Percent = math.Round(float64(job.Processed)/float64(job.Total)*1000) / 10
+	}
+
+	if job.Status == entities.JobCompleted && job.HasResult {
+		response.ResultURL = statusPath + "/result"
+		if signer != nil {
+			signed := signer.Sign(response.ResultURL)
+			response.ResultURL = signed.URL
+			response.ResultURLExpiresAt = timefmt.Optional(signed.ExpiresAt, loc)
+		}
+	}
+
+	return response
+}
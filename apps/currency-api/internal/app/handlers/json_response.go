@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/infrastructure/encoding"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// writeJSON renders v with the configured encoder, falling back to gin's
+// encoding/json renderer when none is set.
+func writeJSON(c *gin.Context, log logger.Logger, encoder encoding.JSONEncoder, status int, v any) {
+	if encoder == nil {
+		c.JSON(status, v)
+		return
+	}
+
+	data, err := encoder.Marshal(v)
+	if err != nil {
+		log.Error("Failed to encode response", err, "encoder", encoder.Name())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationTemplatesHandler struct {
+	queryHandler *queries.ValidateNotificationTemplateQueryHandler
+	logger       logger.Logger
+}
+
+func NewNotificationTemplatesHandler(queryHandler *queries.ValidateNotificationTemplateQueryHandler, logger logger.Logger) *NotificationTemplatesHandler {
+	return &NotificationTemplatesHandler{
+		queryHandler: queryHandler,
+		logger:       logger,
+	}
+}
+
+// @Summary Validate a notification template
+// @Description Check a custom message template for alert subscriptions by rendering it against sample data. Templates use Go text/template syntax over fields such as .Pair, .Threshold, .Rate and .Rates; format "json" also requires the output to be valid JSON, as webhook payloads must be.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param request body NotificationTemplateValidationRequest true "Template to validate"
+// @Success 200 {object} NotificationTemplateValidationResponse
+// @Failure 400 {object} NotificationTemplateValidationResponse
+// @Failure 422 {object} NotificationTemplateValidationResponse
+// @Router /api/v1/notifications/templates/validate [post]
+func (h *NotificationTemplatesHandler) Validate(c *gin.Context) {
+	var request NotificationTemplateValidationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, NotificationTemplateValidationResponse{Error: "request body must be a JSON object with kind and template"})
+		return
+	}
+
+	preview, err := h.queryHandler.Handle(c.Request.Context(), queries.ValidateNotificationTemplateQuery{
+		Kind:     request.Kind,
+		Format:   request.Format,
+		Template: request.Template,
+	})
+	if err != nil {
+		var templateErr *services.TemplateError
+		if errors.As(err, &templateErr) {
+			c.JSON(http.StatusUnprocessableEntity, NotificationTemplateValidationResponse{
+				Error: templateErr.Message,
+				Line:  templateErr.Line,
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, NotificationTemplateValidationResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, NotificationTemplateValidationResponse{Valid: true, Preview: preview})
+}
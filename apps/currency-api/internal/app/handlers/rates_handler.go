@@ -1,34 +1,69 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/encoding"
 	"github.com/ajs/go-common/logger"
+	"github.com/ajs/go-common/timefmt"
 	"github.com/gin-gonic/gin"
 )
 
 type RatesHandler struct {
-	queryHandler *queries.GetRatesQueryHandler
-	logger       logger.Logger
+	queryHandler   *queries.GetRatesQueryHandler
+	logger         logger.Logger
+	encoder        encoding.JSONEncoder
+	marketCalendar services.MarketCalendar
+	now            func() time.Time
 }
 
-func NewRatesHandler(queryHandler *queries.GetRatesQueryHandler, logger logger.Logger) *RatesHandler {
-	return &RatesHandler{
+type RatesHandlerOption func(*RatesHandler)
+
+func WithRatesJSONEncoder(encoder encoding.JSONEncoder) RatesHandlerOption {
+	return func(h *RatesHandler) {
+		h.encoder = encoder
+	}
+}
+
+// WithMarketCalendar adds the fiat market state to responses that only
+// contain fiat currencies.
+func WithMarketCalendar(calendar services.MarketCalendar) RatesHandlerOption {
+	return func(h *RatesHandler) {
+		h.marketCalendar = calendar
+	}
+}
+
+func NewRatesHandler(queryHandler *queries.GetRatesQueryHandler, logger logger.Logger, opts ...RatesHandlerOption) *RatesHandler {
+	h := &RatesHandler{
 		queryHandler: queryHandler,
 		logger:       logger,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // @Summary		Get exchange rates
-// @Description	Get exchange rates for a list of currencies (minimum 2 required)
+// @Description	Get exchange rates for a list of currencies (minimum 2 required). Fiat-only requests include the market state and when rates are next expected to change. Problems with the request answer 400; failures of the rates provider answer 502 or 503 with a retryable flag. Rates served from the cache while the provider is failing carry stale metadata.
 // @Tags			Rates
 // @Accept			json
 // @Produce		json
 // @Param			currencies	query		string	true	"Comma-separated list of currency codes (e.g., USD,EUR,GBP)"
 // @Success		200			{object}	RatesResponse
 // @Failure		400			{object}	RatesErrorResponse
+// @Failure		500			{object}	RatesErrorResponse
+// @Failure		502			{object}	RatesErrorResponse
+// @Failure		503			{object}	RatesErrorResponse
 // @Router			/api/v1/rates [get]
 func (h *RatesHandler) GetRates(c *gin.Context) {
 	currenciesParam := c.Query("currencies")
@@ -36,6 +71,7 @@ func (h *RatesHandler) GetRates(c *gin.Context) {
 	if currenciesParam == "" {
 		c.JSON(http.StatusBadRequest, RatesErrorResponse{
 			Error:   "currencies parameter is required",
+			Code:    queries.ErrCodeMissingParameters,
 			Example: "GET /rates?currencies=USD,EUR,GBP",
 		})
 		return
@@ -49,17 +85,118 @@ func (h *RatesHandler) GetRates(c *gin.Context) {
 
 	rates, info, err := h.queryHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		h.logger.Error("Failed to get rates", err)
-		c.JSON(http.StatusBadRequest, RatesErrorResponse{
-			Error: "Failed to retrieve exchange rates. Ensure currency codes are valid.",
-		})
+		h.respondError(c, err)
 		return
 	}
 
 	response := RatesResponse{
-		SourceInfo: info,
+		SourceInfo: info.Source,
 		Rates:      rates,
 	}
 
-	c.JSON(http.StatusOK, response)
+	normalized := make([]string, len(currencies))
+	for i, currency := range currencies {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(currency))
+	}
+
+	if lag, ok := info.ReplicationLag(h.now()); ok {
+		lagSeconds := lag.Seconds()
+		response.ReplicationLagSeconds = &lagSeconds
+	}
+
+	if !info.StaleFetchedAt.IsZero() {
+		response.Stale = &StaleRatesResponse{
+			FetchedAt:  timefmt.UTC(info.StaleFetchedAt),
+			AgeSeconds: int(h.now().Sub(info.StaleFetchedAt).Seconds()),
+			Reason:     "upstream_unavailable",
+		}
+	}
+
+	if h.marketCalendar != nil && entities.IsFiatOnly(normalized) {
+		response.Market = newMarketStateResponse(h.marketCalendar.State(h.now()))
+	}
+
+	writeJSON(c, h.logger, h.encoder, http.StatusOK, response)
+}
+
+// respondError answers problems with the request with 400 and failures of
+// the rates provider with 502, or 503 while it is not being called at all, so
+// clients do not try to fix a request that was fine.
+func (h *RatesHandler) respondError(c *gin.Context, err error) {
+	var budgetErr *queries.ResponseBudgetError
+	if errors.As(err, &budgetErr) {
+		h.logger.Warn("Rates response over budget", "estimated_bytes", budgetErr.EstimatedBytes, "budget_bytes", budgetErr.BudgetBytes)
+		c.JSON(http.StatusBadRequest, RatesErrorResponse{
+			Error:          budgetErr.Message,
+			Code:           budgetErr.Code,
+			Suggestion:     budgetErr.Suggestion,
+			EstimatedBytes: budgetErr.EstimatedBytes,
+			BudgetBytes:    budgetErr.BudgetBytes,
+			MaxCurrencies:  budgetErr.MaxCurrencies,
+		})
+		return
+	}
+
+	var unsupportedErr *repositories.UnsupportedCurrencyError
+	if errors.As(err, &unsupportedErr) {
+		response := RatesErrorResponse{
+			Error:    unsupportedErr.Error(),
+			Code:     queries.ErrCodeUnsupportedCurrency,
+			Currency: unsupportedErr.Currency,
+		}
+		if !unsupportedErr.CachedUntil.IsZero() {
+			response.Cache = &UnsupportedCurrencyCacheResponse{Hit: unsupportedErr.Cached, ExpiresAt: timefmt.UTC(unsupportedErr.CachedUntil)}
+		}
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var validationErr *queries.RatesValidationError
+	if errors.As(err, &validationErr) {
+		response := RatesErrorResponse{
+			Error:    validationErr.Message,
+			Code:     validationErr.Code,
+			Currency: validationErr.Currency,
+		}
+		if validationErr.Code == queries.ErrCodeTooFewCurrencies {
+			response.Example = "GET /rates?currencies=USD,EUR,GBP"
+		}
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var upstreamErr *repositories.UpstreamError
+	if errors.As(err, &upstreamErr) {
+		h.logger.Error("Rates provider failed", err, "source", upstreamErr.Source, "retryable", upstreamErr.Retryable)
+		status, response := http.StatusBadGateway, RatesErrorResponse{
+			Error:     "The exchange rates provider failed to answer. This is not a problem with your request.",
+			Code:      queries.ErrCodeUpstreamError,
+			Retryable: &upstreamErr.Retryable,
+		}
+		if upstreamErr.Unavailable {
+			status = http.StatusServiceUnavailable
+			response.Error = "The exchange rates provider is temporarily unavailable. This is not a problem with your request."
+			response.Code = queries.ErrCodeRatesUnavailable
+		}
+		if seconds := int(upstreamErr.RetryAfter.Seconds()); seconds > 0 {
+			response.RetryAfterSeconds = seconds
+			c.Header("Retry-After", strconv.Itoa(seconds))
+		}
+		c.JSON(status, response)
+		return
+	}
+
+	h.logger.Error("Failed to get rates", err)
+	c.JSON(http.StatusInternalServerError, RatesErrorResponse{
+		Error: "Failed to retrieve exchange rates.",
+		Code:  queries.ErrCodeInternal,
+	})
+}
+
+func newMarketStateResponse(state entities.MarketState) *MarketStateResponse {
+	return &MarketStateResponse{
+		Status:       string(state.Status),
+		NextUpdateAt: timefmt.Optional(state.NextUpdateAt, nil),
+		NextOpenAt:   timefmt.Optional(state.NextOpen, nil),
+	}
 }
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/streaming"
+	"github.com/ajs/go-common/logger"
+	"github.com/ajs/go-common/timefmt"
+	"github.com/gin-gonic/gin"
+)
+
+const ratesStreamHeartbeat = 15 * time.Second
+
+// RateStreamer streams rate updates between a set of currencies until ctx
+// is done.
+type RateStreamer interface {
+	Subscribe(ctx context.Context, currencies []string) (<-chan streaming.Update, error)
+}
+
+// StreamGate decides whether a new stream may open.
+type StreamGate interface {
+	Admit(apiKey, remoteAddr string, pairs int) (*streaming.Connection, error)
+}
+
+type RatesStreamHandler struct {
+	streamer RateStreamer
+	gate     StreamGate
+	logger   logger.Logger
+}
+
+func NewRatesStreamHandler(streamer RateStreamer, gate StreamGate, logger logger.Logger) *RatesStreamHandler {
+	return &RatesStreamHandler{
+		streamer: streamer,
+		gate:     gate,
+		logger:   logger,
+	}
+}
+
+// @Summary Stream exchange rates
+// @Description Server-sent events with the rates between the given currencies. The first event is a "snapshot" with every pair; "rates" events then carry only the pairs that changed, at most one per pair per coalescing interval (STREAM_COALESCE_INTERVAL, default 500ms). Clients that read too slowly skip the deltas they missed and receive a new "snapshot" once they catch up. When the server ends the stream it sends a final "close" event with the reason. With STREAM_API_KEYS configured an API key is required; connections are limited in pairs and per key.
+// @Tags Rates
+// @Produce text/event-stream
+// @Param currencies query string true "Comma-separated list of currency codes (e.g., USD,EUR,GBP)"
+// @Param X-API-Key header string false "Streaming API key"
+// @Param api_key query string false "Streaming API key, for clients such as EventSource that cannot set headers"
+// @Success 200 {object} RatesStreamEvent "Event stream"
+// @Failure 400 {object} RatesErrorResponse
+// @Failure 401 {object} RatesErrorResponse
+// @Failure 429 {object} RatesErrorResponse
+// @Router /api/v1/rates/stream [get]
+func (h *RatesStreamHandler) Stream(c *gin.Context) {
+	currenciesParam := c.Query("currencies")
+	if currenciesParam == "" {
+		c.JSON(http.StatusBadRequest, RatesErrorResponse{
+			Error:   "currencies parameter is required",
+			Example: "GET /api/v1/rates/stream?currencies=USD,EUR,GBP",
+		})
+		return
+	}
+
+	currencies := strings.Split(currenciesParam, ",")
+	for i, currency := range currencies {
+		currencies[i] = strings.ToUpper(strings.TrimSpace(currency))
+	}
+
+	apiKey := c.GetHeader("X-API-Key")
+	if apiKey == "" {
+		apiKey = c.Query("api_key")
+	}
+
+	conn, err := h.gate.Admit(apiKey, c.ClientIP(), len(currencies)*(len(currencies)-1))
+	if err != nil {
+		h.respondRejected(c, err)
+		return
+	}
+	reason := streaming.CloseClientDisconnected
+	defer func() { conn.Close(reason) }()
+
+	ctx := c.Request.Context()
+	updates, err := h.streamer.Subscribe(ctx, currencies)
+	if err != nil {
+		reason = streaming.CloseSubscribeFailed
+		h.logger.Error("Failed to subscribe to rates", err, "client", conn.Client)
+		c.JSON(http.StatusBadRequest, RatesErrorResponse{
+			Error: "Failed to retrieve exchange rates. Ensure currency codes are valid.",
+		})
+		return
+	}
+
+	// Streams outlive the server's write timeout; heartbeats detect dead clients.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(ratesStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				reason = streaming.CloseServerShutdown
+				c.SSEvent("close", RatesStreamCloseEvent{Reason: reason})
+				c.Writer.Flush()
+				return
+			}
+			event := "rates"
+			if update.Snapshot {
+				event = "snapshot"
+			}
+			c.SSEvent(event, RatesStreamEvent{At: timefmt.UTC(update.At), Rates: update.Rates})
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func (h *RatesStreamHandler) respondRejected(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, streaming.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, RatesErrorResponse{
+			Error:   err.Error(),
+			Code:    "UNAUTHORIZED",
+			Example: "GET /api/v1/rates/stream?currencies=USD,EUR&api_key=<key>",
+		})
+	case errors.Is(err, streaming.ErrTooManyPairs):
+		c.JSON(http.StatusBadRequest, RatesErrorResponse{
+			Error:      err.Error(),
+			Code:       "TOO_MANY_PAIRS",
+			Suggestion: "Subscribe to fewer currencies per connection",
+		})
+	case errors.Is(err, streaming.ErrTooManyConnections):
+		c.JSON(http.StatusTooManyRequests, RatesErrorResponse{
+			Error:      err.Error(),
+			Code:       "TOO_MANY_CONNECTIONS",
+			Suggestion: "Close an existing stream or combine subscriptions into one connection",
+		})
+	default:
+		h.logger.Error("Failed to admit rate stream", err)
+		c.JSON(http.StatusInternalServerError, RatesErrorResponse{Error: "failed to open stream"})
+	}
+}
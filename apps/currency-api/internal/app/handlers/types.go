@@ -1,6 +1,11 @@
 package handlers
 
-import "github.com/ajs/currency-api/internal/domain/entities"
+import (
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/shopspring/decimal"
+)
 
 type HTTPError struct {
 	Code    int    `json:"code" example:"400"`
@@ -11,13 +16,49 @@ type HealthResponse struct {
 	Status      string          `json:"status" example:"healthy"`
 	Service     string          `json:"service" example:"currency-exchange-api"`
 	Version     string          `json:"version" example:"2.0.0"`
-	Timestamp   int64           `json:"timestamp"`
+	Timestamp   time.Time       `json:"timestamp" example:"2026-10-16T09:15:00Z"`
 	Environment EnvironmentInfo `json:"environment"`
 	Framework   string          `json:"framework" example:"gin-gonic"`
 	NxPlugin    string          `json:"nx_plugin" example:"@naxodev/gonx"`
 	GoVersion   string          `json:"go_version" example:"1.24"`
 	Features    []string        `json:"features"`
 	Endpoints   EndpointsInfo   `json:"endpoints"`
+	Leadership  *LeadershipInfo `json:"leadership,omitempty"`
+}
+
+type LeadershipInfo struct {
+	InstanceID  string     `json:"instance_id" example:"currency-api-7d9f-1"`
+	Leader      string     `json:"leader" example:"currency-api-7d9f-1"`
+	IsLeader    bool       `json:"is_leader"`
+	LeaderSince *time.Time `json:"leader_since,omitempty"`
+	// Jobs only run on the leader; every other background worker runs on
+	// each instance.
+	Jobs []string `json:"jobs" example:"precision_audit,provider_poller"`
+}
+
+type ReadinessResponse struct {
+	Status     string            `json:"status" example:"ready"`
+	Timestamp  time.Time         `json:"timestamp" example:"2026-10-16T09:15:00Z"`
+	Freshness  []FreshnessStatus `json:"freshness"`
+	Workers    []WorkerStatus    `json:"workers,omitempty"`
+	Violations []string          `json:"violations,omitempty"`
+}
+
+type FreshnessStatus struct {
+	AssetClass       string     `json:"asset_class" example:"fiat"`
+	ObjectiveSeconds float64    `json:"objective_seconds" example:"3600"`
+	LastUpdated      *time.Time `json:"last_updated,omitempty"`
+	AgeSeconds       *float64   `json:"age_seconds,omitempty" example:"42.5"`
+	Violation        bool       `json:"violation"`
+	BurnRate         float64    `json:"burn_rate" example:"0"`
+}
+
+type WorkerStatus struct {
+	Name        string     `json:"name" example:"rates_stream_refresher"`
+	State       string     `json:"state" example:"running"`
+	Restarts    int        `json:"restarts" example:"0"`
+	LastError   string     `json:"last_error,omitempty" example:"panic: assignment to entry in nil map"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
 }
 
 type EnvironmentInfo struct {
@@ -33,11 +74,216 @@ type EndpointsInfo struct {
 }
 
 type RatesResponse struct {
-	SourceInfo string                  `json:"source_info" example:"🔑 API key provided: Using live rates"`
-	Rates      []entities.ExchangeRate `json:"rates"`
+	SourceInfo            string                  `json:"source_info" example:"🔑 API key provided: Using live rates"`
+	Rates                 []entities.ExchangeRate `json:"rates"`
+	ReplicationLagSeconds *float64                `json:"replication_lag_seconds,omitempty" example:"12.5"`
+	Market                *MarketStateResponse    `json:"market,omitempty"`
+	Stale                 *StaleRatesResponse     `json:"stale,omitempty"`
+}
+
+type StaleRatesResponse struct {
+	FetchedAt  time.Time `json:"fetched_at" example:"2026-10-16T08:30:00Z"`
+	AgeSeconds int       `json:"age_seconds" example:"540"`
+	Reason     string    `json:"reason" example:"upstream_unavailable"`
+}
+
+type RatesStreamEvent struct {
+	At    time.Time               `json:"at"`
+	Rates []entities.ExchangeRate `json:"rates"`
+}
+
+type RatesStreamCloseEvent struct {
+	Reason string `json:"reason" example:"server_shutdown"`
+}
+
+type MarketStateResponse struct {
+	Status       string     `json:"status" example:"closed"`
+	NextUpdateAt *time.Time `json:"next_update_at,omitempty" example:"2026-10-18T21:00:00Z"`
+	NextOpenAt   *time.Time `json:"next_open_at,omitempty" example:"2026-10-18T21:00:00Z"`
 }
 
 type RatesErrorResponse struct {
-	Error   string `json:"error" example:"currencies parameter is required"`
-	Example string `json:"example,omitempty" example:"GET /rates?currencies=USD,EUR,GBP"`
+	Error             string                            `json:"error" example:"currencies parameter is required"`
+	Example           string                            `json:"example,omitempty" example:"GET /rates?currencies=USD,EUR,GBP"`
+	Code              string                            `json:"code,omitempty" example:"RESPONSE_TOO_LARGE"`
+	Suggestion        string                            `json:"suggestion,omitempty" example:"Request at most 132 currencies per call and split the rest across several requests"`
+	EstimatedBytes    int                               `json:"estimated_bytes,omitempty" example:"1724312"`
+	BudgetBytes       int                               `json:"budget_bytes,omitempty" example:"1048576"`
+	MaxCurrencies     int                               `json:"max_currencies,omitempty" example:"132"`
+	Currency          string                            `json:"currency,omitempty" example:"XYZ"`
+	Cache             *UnsupportedCurrencyCacheResponse `json:"cache,omitempty"`
+	Retryable         *bool                             `json:"retryable,omitempty" example:"true"`
+	RetryAfterSeconds int                               `json:"retry_after_seconds,omitempty" example:"30"`
+}
+
+type UnsupportedCurrencyCacheResponse struct {
+	Hit       bool      `json:"hit" example:"true"`
+	ExpiresAt time.Time `json:"expires_at" example:"2026-10-16T12:05:00Z"`
+}
+
+type ExchangeErrorResponse struct {
+	Error      string           `json:"error" example:"amount must be positive"`
+	Code       string           `json:"code" example:"NON_POSITIVE_AMOUNT"`
+	MinAmount  *decimal.Decimal `json:"min_amount,omitempty" swaggertype:"string" example:"0.00000001"`
+	Suggestion string           `json:"suggestion,omitempty" example:"Use an amount of at least 0.00000001"`
+	Example    string           `json:"example,omitempty" example:"GET /api/v1/exchange?from=WBTC&to=USDT&amount=1.0"`
+}
+
+type ChangelogResponse struct {
+	Count   int                       `json:"count" example:"1"`
+	Entries []entities.ChangelogEntry `json:"entries"`
+}
+
+type ChangelogErrorResponse struct {
+	Error   string `json:"error" example:"since must be a date in YYYY-MM-DD format"`
+	Example string `json:"example,omitempty" example:"GET /api/v1/changelog?since_version=2.0.0"`
+}
+
+type JobResponse struct {
+	ID                 string      `json:"id" example:"9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b"`
+	Type               string      `json:"type" example:"bulk_conversion"`
+	Status             string      `json:"status" example:"running"`
+	Progress           JobProgress `json:"progress"`
+	Error              string      `json:"error,omitempty" example:"interrupted by instance shutdown"`
+	CancelRequested    bool        `json:"cancel_requested,omitempty" example:"false"`
+	CreatedAt          time.Time   `json:"created_at"`
+	StartedAt          *time.Time  `json:"started_at,omitempty"`
+	CompletedAt        *time.Time  `json:"completed_at,omitempty"`
+	StatusURL          string      `json:"status_url" example:"/api/v1/jobs/9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b"`
+	ResultURL          string      `json:"result_url,omitempty" example:"/api/v1/jobs/9f1c2e7a4b5d6e8f9a0b1c2d3e4f5a6b/result"`
+	ResultURLExpiresAt *time.Time  `json:"result_url_expires_at,omitempty"`
+}
+
+type JobProgress struct {
+	Total     int     `json:"total" example:"1200"`
+	Processed int     `json:"processed" example:"400"`
+	Failed    int     `json:"failed" example:"3"`
+	Percent   float64 `json:"percent" example:"33.3"`
+}
+
+type JobErrorResponse struct {
+	Error string `json:"error" example:"job not found"`
+}
+
+type BulkConversionErrorResponse struct {
+	Error   string `json:"error" example:"expected pair,amount[,date]"`
+	Line    int    `json:"line,omitempty" example:"3"`
+	Example string `json:"example,omitempty" example:"pair,amount,date"`
+}
+
+type NotificationTemplateValidationRequest struct {
+	Kind     string `json:"kind" example:"rate_alert"`
+	Format   string `json:"format,omitempty" example:"text"`
+	Template string `json:"template" example:"{{.Pair}} is {{.Direction}} {{.Threshold}} (now {{round .Rate 2}})"`
+}
+
+type NotificationTemplateValidationResponse struct {
+	Valid   bool   `json:"valid" example:"true"`
+	Preview string `json:"preview,omitempty" example:"WBTC/USDT is above 57000 (now 57094.31)"`
+	Error   string `json:"error,omitempty" example:"function \"call\" is not allowed"`
+	Line    int    `json:"line,omitempty" example:"1"`
+}
+
+type DeliveriesResponse struct {
+	SubscriptionID string             `json:"subscription_id" example:"sub-42"`
+	Count          int                `json:"count" example:"1"`
+	Deliveries     []DeliveryResponse `json:"deliveries"`
+}
+
+type DeliveryResponse struct {
+	ID           string                    `json:"id" example:"5f0c9a1e2b3d4c5e6f708192"`
+	Kind         string                    `json:"kind" example:"rate_alert"`
+	Channel      string                    `json:"channel" example:"slack"`
+	Status       string                    `json:"status" example:"failed"`
+	Error        string                    `json:"error,omitempty" example:"webhook 1: failed to send slack notification after 3 attempt(s): unexpected status 503: "`
+	RedeliveryOf string                    `json:"redelivery_of,omitempty" example:"2a7d4e0f9b8c1d2e3f4a5b6c"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	Attempts     []DeliveryAttemptResponse `json:"attempts"`
+}
+
+type DeliveryAttemptResponse struct {
+	Attempt      int       `json:"attempt" example:"1"`
+	At           time.Time `json:"at"`
+	LatencyMS    int64     `json:"latency_ms" example:"184"`
+	ResponseCode int       `json:"response_code,omitempty" example:"503"`
+	Error        string    `json:"error,omitempty" example:"unexpected status 503: "`
+}
+
+type WebhookErrorResponse struct {
+	Error string `json:"error" example:"delivery not found"`
+}
+
+type ConfigResponse struct {
+	Environment string                  `json:"environment" example:"production"`
+	Region      string                  `json:"region" example:"eu-west-1"`
+	InstanceID  string                  `json:"instance_id,omitempty" example:"currency-api-7d9f8b6c5-x2k4q"`
+	Settings    []ConfigSettingResponse `json:"settings"`
+}
+
+type ConfigSettingResponse struct {
+	Key      string `json:"key" example:"OPEN_EXCHANGE_API_KEY"`
+	Value    string `json:"value" example:"[REDACTED]"`
+	Source   string `json:"source" example:"ssm:/currency-api/prod"`
+	Redacted bool   `json:"redacted,omitempty" example:"true"`
+}
+
+type ProviderReportResponse struct {
+	Date      string                  `json:"date" example:"2026-10-16"`
+	Providers []ProviderStatsResponse `json:"providers"`
+}
+
+type ProviderStatsResponse struct {
+	Provider         string  `json:"provider" example:"openexchangerates"`
+	Primary          bool    `json:"primary" example:"true"`
+	Requests         int     `json:"requests" example:"1440"`
+	Failures         int     `json:"failures" example:"3"`
+	Availability     float64 `json:"availability" example:"0.9979"`
+	LatencyP50Ms     float64 `json:"latency_p50_ms" example:"182.4"`
+	LatencyP95Ms     float64 `json:"latency_p95_ms" example:"611.9"`
+	MeanDeviation    float64 `json:"mean_deviation" example:"0.00042"`
+	DeviationSamples int     `json:"deviation_samples" example:"96"`
+	QuotaUsed        int     `json:"quota_used" example:"23011"`
+}
+
+// PrecisionAuditResponse is one day's precision audit. ULPs count units in
+// the last place of the result.
+type PrecisionAuditResponse struct {
+	Date          string                          `json:"date" example:"2026-10-15"`
+	RanAt         time.Time                       `json:"ran_at"`
+	Sampled       int                             `json:"sampled" example:"1240"`
+	Checked       int                             `json:"checked" example:"1228"`
+	Skipped       int                             `json:"skipped" example:"12"`
+	Priced        int                             `json:"priced" example:"40"`
+	Discrepancies int                             `json:"discrepancies" example:"0"`
+	MaxULPs       decimal.Decimal                 `json:"max_ulps" swaggertype:"string" example:"0.5"`
+	Examples      []entities.PrecisionDiscrepancy `json:"examples"`
+}
+
+type AdminErrorResponse struct {
+	Error string `json:"error" example:"a valid bearer token is required"`
+}
+
+type FeeScheduleRequest struct {
+	Fees          map[string]entities.Fee         `json:"fees"`
+	Limits        map[string]entities.AmountLimit `json:"limits"`
+	EffectiveFrom *time.Time                      `json:"effective_from,omitempty" example:"2026-10-16T18:00:00Z"`
+	// Actor is recorded as reported_actor; the audit trail attributes the
+	// publish to the holder of the bearer token.
+	Actor  string `json:"actor,omitempty" example:"pricing-ops@example.com"`
+	Reason string `json:"reason" example:"Widen WBTC spreads during volatility"`
+}
+
+type FeeScheduleResponse struct {
+	Current *entities.FeeSchedule  `json:"current"`
+	Pending []entities.FeeSchedule `json:"pending"`
+}
+
+type FeeScheduleErrorResponse struct {
+	Error    string   `json:"error" example:"invalid fee schedule"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+type FeeAuditResponse struct {
+	Count   int                      `json:"count" example:"1"`
+	Entries []entities.FeeAuditEntry `json:"entries"`
 }
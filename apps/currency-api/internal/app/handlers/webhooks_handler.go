@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/commands"
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/ajs/go-common/timefmt"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhooksHandler struct {
+	queryHandler     *queries.ListDeliveriesQueryHandler
+	redeliverHandler *commands.RedeliverCommandHandler
+	logger           logger.Logger
+}
+
+func NewWebhooksHandler(queryHandler *queries.ListDeliveriesQueryHandler, redeliverHandler *commands.RedeliverCommandHandler, logger logger.Logger) *WebhooksHandler {
+	return &WebhooksHandler{
+		queryHandler:     queryHandler,
+		redeliverHandler: redeliverHandler,
+		logger:           logger,
+	}
+}
+
+// @Summary List subscription deliveries
+// @Description Latest notification deliveries of a webhook or notification subscription, newest first, with every attempt's latency, response code and error. Answers 501 until this service produces notifications. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param limit query int false "Maximum deliveries to return (1-100, default 20)"
+// @Param tz query string false "IANA time zone to display timestamps in, e.g. Europe/Warsaw (default UTC)"
+// @Success 200 {object} DeliveriesResponse
+// @Failure 400 {object} WebhookErrorResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 403 {object} AdminErrorResponse
+// @Failure 501 {object} WebhookErrorResponse
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhooksHandler) ListDeliveries(c *gin.Context) {
+	loc, err := timefmt.ParseZone(c.Query("tz"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, WebhookErrorResponse{Error: err.Error()})
+		return
+	}
+
+	subscriptionID := c.Param("id")
+	deliveries, err := h.queryHandler.Handle(c.Request.Context(), queries.ListDeliveriesQuery{
+		SubscriptionID: subscriptionID,
+		Limit:          c.Query("limit"),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, WebhookErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := DeliveriesResponse{
+		SubscriptionID: subscriptionID,
+		Count:          len(deliveries),
+		Deliveries:     make([]DeliveryResponse, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, newDeliveryResponse(delivery, loc))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Redeliver a notification
+// @Description Send a recorded delivery's notification again. The result is recorded as a new delivery that references the original; a failed send is reported in its status, not as an error response. Answers 501 until this service produces notifications. Requires a bearer token from ADMIN_TOKEN or ADMIN_TOKENS, and answers 403 when neither is set.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} DeliveryResponse
+// @Failure 401 {object} AdminErrorResponse
+// @Failure 403 {object} AdminErrorResponse
+// @Failure 404 {object} WebhookErrorResponse
+// @Failure 501 {object} WebhookErrorResponse
+// @Router /api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhooksHandler) Redeliver(c *gin.Context) {
+	delivery, err := h.redeliverHandler.Handle(c.Request.Context(), commands.RedeliverCommand{
+		SubscriptionID: c.Param("id"),
+		DeliveryID:     c.Param("delivery_id"),
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDeliveryNotFound) {
+			c.JSON(http.StatusNotFound, WebhookErrorResponse{Error: err.Error()})
+			return
+		}
+		h.logger.Error("Failed to redeliver notification", err, "subscription", c.Param("id"))
+		c.JSON(http.StatusInternalServerError, WebhookErrorResponse{Error: "failed to redeliver notification"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDeliveryResponse(delivery, nil))
+}
+
+// newDeliveryResponse displays the delivery's timestamps in loc, or in UTC
+// when loc is nil.
+func newDeliveryResponse(delivery entities.Delivery, loc *time.Location) DeliveryResponse {
+	response := DeliveryResponse{
+		ID:           delivery.ID,
+		Kind:         string(delivery.Notification.Kind),
+		Channel:      string(delivery.Notification.Channel),
+		Status:       string(delivery.Status),
+		Error:        delivery.Error,
+		RedeliveryOf: delivery.RedeliveryOf,
+		CreatedAt:    timefmt.In(delivery.CreatedAt, loc),
+		Attempts:     make([]DeliveryAttemptResponse, 0, len(delivery.Attempts)),
+	}
+	for _, attempt := range delivery.Attempts {
+		response.Attempts = append(response.Attempts, DeliveryAttemptResponse{
+			Attempt:      attempt.Attempt,
+			At:           timefmt.In(attempt.At, loc),
+			LatencyMS:    attempt.Latency.Milliseconds(),
+			ResponseCode: attempt.ResponseCode,
+			Error:        attempt.Error,
+		})
+	}
+	return response
+}
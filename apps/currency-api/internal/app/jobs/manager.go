@@ -0,0 +1,344 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+)
+
+const progressFlushInterval = 500 * time.Millisecond
+
+var (
+	ErrQueueFull   = errors.New("job queue is full, try again later")
+	ErrJobFinished = errors.New("job has already finished")
+)
+
+// Progress lets a running job report how far it got.
+type Progress interface {
+	// Advance records processed more items, failed of which could not be handled.
+	Advance(processed, failed int)
+}
+
+// Runner does the work of a job. It must return promptly with ctx.Err() once
+// ctx is cancelled. A nil result means the job has nothing to download.
+type Runner func(ctx context.Context, progress Progress) (*entities.JobResult, error)
+
+type queuedJob struct {
+	job entities.Job
+	run Runner
+}
+
+// Manager runs jobs on a fixed pool of workers and records their state in
+// the job repository, so status, progress and results can be served by any
+// instance sharing the repository.
+type Manager struct {
+	repo    repositories.JobRepository
+	workers int
+	logger  logger.Logger
+	now     func() time.Time
+
+	queue  chan queuedJob
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	running  map[string]context.CancelFunc
+	watchers map[string]map[chan entities.Job]struct{}
+}
+
+func NewManager(repo repositories.JobRepository, workers, queueSize int, log logger.Logger) *Manager {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		repo:     repo,
+		workers:  workers,
+		logger:   log,
+		now:      time.Now,
+		queue:    make(chan queuedJob, queueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+		running:  make(map[string]context.CancelFunc),
+		watchers: make(map[string]map[chan entities.Job]struct{}),
+	}
+}
+
+// Start runs the worker pool until Close is called.
+func (m *Manager) Start() {
+	for i := 0; i < m.workers; i++ {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			_ = m.Work(m.ctx)
+		}()
+	}
+}
+
+// Work is one worker of the pool: it runs queued jobs one at a time until ctx
+// is done. Callers that supervise the workers themselves run Workers of them
+// instead of calling Start, and stop them before Close.
+func (m *Manager) Work(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case queued := <-m.queue:
+			m.run(queued)
+		}
+	}
+}
+
+// Workers is the size of the worker pool.
+func (m *Manager) Workers() int {
+	return m.workers
+}
+
+// Close interrupts running jobs and fails the ones still queued, since
+// queued work is not handed over to other instances.
+func (m *Manager) Close() error {
+	m.cancel()
+	m.wg.Wait()
+
+	for {
+		select {
+		case queued := <-m.queue:
+			job := queued.job
+			job.Status = entities.JobFailed
+			job.Error = "instance shut down before the job started"
+			job.CompletedAt = m.now().UTC()
+			m.save(context.Background(), job)
+		default:
+			return nil
+		}
+	}
+}
+
+// Submit stores a pending job and queues it for the worker pool.
+func (m *Manager) Submit(ctx context.Context, jobType, tenant string, total int, run Runner) (entities.Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	job := entities.Job{
+		ID:        id,
+		Type:      jobType,
+		Tenant:    tenant,
+		Status:    entities.JobPending,
+		Total:     total,
+		CreatedAt: m.now().UTC(),
+	}
+	if err := m.repo.Save(ctx, job); err != nil {
+		return entities.Job{}, fmt.Errorf("failed to store job: %w", err)
+	}
+
+	select {
+	case m.queue <- queuedJob{job: job, run: run}:
+		return job, nil
+	default:
+		job.Status = entities.JobFailed
+		job.Error = ErrQueueFull.Error()
+		job.CompletedAt = m.now().UTC()
+		m.save(ctx, job)
+		return entities.Job{}, ErrQueueFull
+	}
+}
+
+// Cancel stops a job submitted for tenant; other tenants' jobs are reported
+// as not found. Pending jobs are cancelled immediately; running jobs stop at
+// their next progress report, on whichever instance runs them.
+func (m *Manager) Cancel(ctx context.Context, id, tenant string) (entities.Job, error) {
+	job, err := m.repo.Get(ctx, id)
+	if err != nil {
+		return entities.Job{}, err
+	}
+	if job.Tenant != tenant {
+		return entities.Job{}, repositories.ErrJobNotFound
+	}
+	if job.Status.Finished() {
+		return job, ErrJobFinished
+	}
+
+	job.CancelRequested = true
+	if job.Status == entities.JobPending {
+		job.Status = entities.JobCancelled
+		job.CompletedAt = m.now().UTC()
+	}
+	if err := m.repo.Save(ctx, job); err != nil {
+		return entities.Job{}, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	m.publish(job)
+
+	m.mu.Lock()
+	if cancel, running := m.running[id]; running {
+		cancel()
+	}
+	m.mu.Unlock()
+
+	return job, nil
+}
+
+func (m *Manager) run(queued queuedJob) {
+	job := queued.job
+
+	if stored, err := m.repo.Get(m.ctx, job.ID); err == nil && stored.Status == entities.JobCancelled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	m.running[job.ID] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, job.ID)
+		m.mu.Unlock()
+	}()
+
+	job.Status = entities.JobRunning
+	job.StartedAt = m.now().UTC()
+	m.save(ctx, job)
+
+	tracker := &progressTracker{manager: m, job: job, cancel: cancel, lastFlush: m.now()}
+	result, err := queued.run(ctx, tracker)
+	job = tracker.snapshot()
+
+	switch {
+	case err == nil:
+		job.Status = entities.JobCompleted
+		if result != nil {
+			if saveErr := m.repo.SaveResult(context.Background(), job.ID, *result); saveErr != nil {
+				m.logger.Error("Failed to store job result", saveErr, "job", job.ID)
+				job.Status = entities.JobFailed
+				job.Error = "failed to store the job result"
+			} else {
+				job.HasResult = true
+			}
+		}
+	case m.ctx.Err() != nil:
+		job.Status = entities.JobFailed
+		job.Error = "interrupted by instance shutdown"
+	case ctx.Err() != nil:
+		job.Status = entities.JobCancelled
+	default:
+		job.Status = entities.JobFailed
+		job.Error = err.Error()
+	}
+
+	job.CompletedAt = m.now().UTC()
+	m.save(context.Background(), job)
+
+	m.logger.Info("Job finished", "job", job.ID, "type", job.Type, "status", job.Status, "processed", job.Processed, "failed", job.Failed)
+}
+
+// save stores job without losing a cancellation recorded by another instance
+// since the job was last read.
+func (m *Manager) save(ctx context.Context, job entities.Job) {
+	if stored, err := m.repo.Get(ctx, job.ID); err == nil && stored.CancelRequested {
+		job.CancelRequested = true
+	}
+	if err := m.repo.Save(ctx, job); err != nil {
+		m.logger.Error("Failed to store job state", err, "job", job.ID)
+		return
+	}
+	m.publish(job)
+}
+
+// Subscribe streams the states a job goes through on this instance. The
+// channel is closed once ctx is done or the manager closes; a slow reader
+// only receives the latest state.
+func (m *Manager) Subscribe(ctx context.Context, id string) <-chan entities.Job {
+	ch := make(chan entities.Job, 1)
+
+	m.mu.Lock()
+	if m.watchers[id] == nil {
+		m.watchers[id] = make(map[chan entities.Job]struct{})
+	}
+	m.watchers[id][ch] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-m.ctx.Done():
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.watchers[id], ch)
+		if len(m.watchers[id]) == 0 {
+			delete(m.watchers, id)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (m *Manager) publish(job entities.Job) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for ch := range m.watchers[job.ID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- job
+	}
+}
+
+type progressTracker struct {
+	manager   *Manager
+	cancel    context.CancelFunc
+	mu        sync.Mutex
+	job       entities.Job
+	lastFlush time.Time
+}
+
+func (t *progressTracker) Advance(processed, failed int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.job.Processed += processed
+	t.job.Failed += failed
+
+	now := t.manager.now()
+	if now.Sub(t.lastFlush) < progressFlushInterval {
+		return
+	}
+	t.lastFlush = now
+
+	if stored, err := t.manager.repo.Get(context.Background(), t.job.ID); err == nil && stored.CancelRequested {
+		t.job.CancelRequested = true
+		t.cancel()
+	}
+	t.manager.save(context.Background(), t.job)
+}
+
+func (t *progressTracker) snapshot() entities.Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.job
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,148 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	domainrepositories "github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForStatus(t *testing.T, manager *Manager, id string, status entities.JobStatus) entities.Job {
+	t.Helper()
+	var job entities.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = manager.repo.Get(context.Background(), id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestManager_Submit(t *testing.T) {
+	manager := NewManager(repositories.NewMemoryJobRepository(), 2, 10, logger.New("error"))
+	manager.Start()
+	t.Cleanup(func() { manager.Close() })
+
+	job, err := manager.Submit(context.Background(), "test", "acme", 3, func(ctx context.Context, progress Progress) (*entities.JobResult, error) {
+		progress.Advance(2, 0)
+		progress.Advance(1, 1)
+		return &entities.JobResult{ContentType: "text/plain", Filename: "out.txt", Data: []byte("done")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobPending, job.Status)
+
+	job = waitForStatus(t, manager, job.ID, entities.JobCompleted)
+	assert.Equal(t, 3, job.Processed)
+	assert.Equal(t, 1, job.Failed)
+	assert.True(t, job.HasResult)
+	assert.False(t, job.StartedAt.IsZero())
+
+	result, err := manager.repo.GetResult(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(result.Data))
+
+	failing, err := manager.Submit(context.Background(), "test", "", 1, func(ctx context.Context, progress Progress) (*entities.JobResult, error) {
+		return nil, errors.New("upstream unavailable")
+	})
+	require.NoError(t, err)
+	failing = waitForStatus(t, manager, failing.ID, entities.JobFailed)
+	assert.Equal(t, "upstream unavailable", failing.Error)
+}
+
+func TestManager_Cancel(t *testing.T) {
+	manager := NewManager(repositories.NewMemoryJobRepository(), 1, 10, logger.New("error"))
+	manager.Start()
+	t.Cleanup(func() { manager.Close() })
+
+	started := make(chan struct{})
+	running, err := manager.Submit(context.Background(), "test", "acme", 1, func(ctx context.Context, progress Progress) (*entities.JobResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+	pending, err := manager.Submit(context.Background(), "test", "acme", 1, func(ctx context.Context, progress Progress) (*entities.JobResult, error) {
+		t.Error("cancelled job must not run")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	<-started
+
+	_, err = manager.Cancel(context.Background(), pending.ID, "globex")
+	assert.ErrorIs(t, err, domainrepositories.ErrJobNotFound)
+	_, err = manager.Cancel(context.Background(), pending.ID, "")
+	assert.ErrorIs(t, err, domainrepositories.ErrJobNotFound)
+
+	cancelled, err := manager.Cancel(context.Background(), pending.ID, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobCancelled, cancelled.Status)
+
+	cancelled, err = manager.Cancel(context.Background(), running.ID, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobRunning, cancelled.Status)
+	assert.True(t, cancelled.CancelRequested)
+
+	waitForStatus(t, manager, running.ID, entities.JobCancelled)
+
+	_, err = manager.Cancel(context.Background(), running.ID, "acme")
+	assert.ErrorIs(t, err, ErrJobFinished)
+}
+
+func TestManager_Submit_QueueFull(t *testing.T) {
+	manager := NewManager(repositories.NewMemoryJobRepository(), 1, 1, logger.New("error"))
+	noop := func(ctx context.Context, progress Progress) (*entities.JobResult, error) { return nil, nil }
+
+	queued, err := manager.Submit(context.Background(), "test", "", 1, noop)
+	require.NoError(t, err)
+
+	_, err = manager.Submit(context.Background(), "test", "", 1, noop)
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	require.NoError(t, manager.Close())
+	job, err := manager.repo.Get(context.Background(), queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobFailed, job.Status)
+	assert.Equal(t, "instance shut down before the job started", job.Error)
+}
+
+func TestManager_Subscribe(t *testing.T) {
+	manager := NewManager(repositories.NewMemoryJobRepository(), 1, 10, logger.New("error"))
+	manager.Start()
+	t.Cleanup(func() { manager.Close() })
+
+	release := make(chan struct{})
+	job, err := manager.Submit(context.Background(), "test", "", 2, func(ctx context.Context, progress Progress) (*entities.JobResult, error) {
+		<-release
+		progress.Advance(2, 0)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := manager.Subscribe(ctx, job.ID)
+	close(release)
+
+	var last entities.Job
+	require.Eventually(t, func() bool {
+		select {
+		case last = <-updates:
+		default:
+		}
+		return last.Status == entities.JobCompleted
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, last.Processed)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-updates
+		return !open
+	}, time.Second, 10*time.Millisecond)
+}
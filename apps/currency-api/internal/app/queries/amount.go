@@ -0,0 +1,81 @@
+package queries
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// maxAmountLength bounds the amount string so a request cannot make
+	// parsing, or the arithmetic after it, arbitrarily expensive.
+	maxAmountLength = 64
+	// maxAmountExponent bounds scientific notation for the same reason:
+	// "1e-999999999" is short but would be rescaled to a billion digits.
+	maxAmountExponent = 64
+	// maxFastAmountDigits is the most digits that always fit in an int64.
+	maxFastAmountDigits = 18
+)
+
+// parseAmount parses a user supplied amount. Plain decimals of up to 18
+// digits, which is nearly every request, are scanned by hand into an int64;
+// anything else, including scientific notation, falls back to
+// decimal.NewFromString, so both accept exactly the same input.
+func parseAmount(raw string) (decimal.Decimal, error) {
+	if len(raw) > maxAmountLength {
+		return decimal.Zero, fmt.Errorf("longer than %d characters", maxAmountLength)
+	}
+
+	if amount, ok := parsePlainAmount(raw); ok {
+		return amount, nil
+	}
+
+	amount, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if exp := amount.Exponent(); exp < -maxAmountExponent || exp > maxAmountExponent {
+		return decimal.Zero, fmt.Errorf("exponent must be between -%d and %d", maxAmountExponent, maxAmountExponent)
+	}
+	return amount, nil
+}
+
+// parsePlainAmount handles [+-]digits[.digits] with at most
+// maxFastAmountDigits digits. ok is false for anything else.
+func parsePlainAmount(raw string) (decimal.Decimal, bool) {
+	i := 0
+	negative := false
+	if len(raw) > 0 && (raw[0] == '-' || raw[0] == '+') {
+		negative = raw[0] == '-'
+		i++
+	}
+
+	var value int64
+	digits, fractionDigits := 0, 0
+	seenPoint := false
+	for ; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c >= '0' && c <= '9':
+			if digits == maxFastAmountDigits {
+				return decimal.Decimal{}, false
+			}
+			value = value*10 + int64(c-'0')
+			digits++
+			if seenPoint {
+				fractionDigits++
+			}
+		case c == '.' && !seenPoint:
+			seenPoint = true
+		default:
+			return decimal.Decimal{}, false
+		}
+	}
+	if digits == 0 {
+		return decimal.Decimal{}, false
+	}
+
+	if negative {
+		value = -value
+	}
+	return decimal.New(value, int32(-fractionDigits)), true
+}
@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount_MatchesNewFromString(t *testing.T) {
+	inputs := []string{
+		"1", "1.0", "1.50", "0.00012345", "-2.5", "+3", "-0", "0", ".5", "5.",
+		"000123.4500", "123456789012345678", "1234567890123456789", "0.123456789012345678",
+		"99999999999999999999.99999999", "1e3", "1.5E-2", "-4e+2",
+		"", "-", "+", ".", "1,000", "1.2.3", "$5", " 1", "1 ", "--1", "1e", "abc", "1_000", "0x10",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			expected, expectedErr := decimal.NewFromString(input)
+			actual, err := parseAmount(input)
+
+			if expectedErr != nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected.String(), actual.String())
+			assert.Equal(t, expected.Exponent(), actual.Exponent())
+		})
+	}
+}
+
+func TestParseAmount_Bounds(t *testing.T) {
+	_, err := parseAmount("1" + strings.Repeat("0", maxAmountLength))
+	assert.EqualError(t, err, "longer than 64 characters")
+
+	_, err = parseAmount("1e-999999999")
+	assert.EqualError(t, err, "exponent must be between -64 and 64")
+
+	_, err = parseAmount("1e65")
+	assert.Error(t, err)
+
+	amount, err := parseAmount("1e-64")
+	require.NoError(t, err)
+	assert.Equal(t, int32(-64), amount.Exponent())
+}
+
+func BenchmarkParseAmount(b *testing.B) {
+	amounts := []string{"1", "1.5", "0.00012345", "250000", "12345.678901", "99.99"}
+
+	for name, parse := range map[string]func(string) (decimal.Decimal, error){
+		"NewFromString": decimal.NewFromString,
+		"parseAmount":   parseAmount,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := parse(amounts[i%len(amounts)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+const changelogDateLayout = "2006-01-02"
+
+type GetChangelogQuery struct {
+	Version      string
+	SinceVersion string
+	Since        string
+	Until        string
+}
+
+type GetChangelogQueryHandler struct {
+	changelogRepo repositories.ChangelogRepository
+}
+
+func NewGetChangelogQueryHandler(changelogRepo repositories.ChangelogRepository) *GetChangelogQueryHandler {
+	return &GetChangelogQueryHandler{changelogRepo: changelogRepo}
+}
+
+// Handle returns matching entries newest first. SinceVersion is exclusive so
+// clients can pass the version they last deployed against.
+func (h *GetChangelogQueryHandler) Handle(ctx context.Context, query GetChangelogQuery) ([]entities.ChangelogEntry, error) {
+	since, err := parseChangelogDate("since", query.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := parseChangelogDate("until", query.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	if !since.IsZero() && !until.IsZero() && since.After(until) {
+		return nil, fmt.Errorf("since must not be after until")
+	}
+
+	var sinceVersion []int
+	if query.SinceVersion != "" {
+		sinceVersion, err = parseVersion(query.SinceVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since_version: %w", err)
+		}
+	}
+
+	entries, err := h.changelogRepo.GetEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changelog: %w", err)
+	}
+
+	result := make([]entities.ChangelogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if query.Version != "" && entry.Version != query.Version {
+			continue
+		}
+
+		if sinceVersion != nil {
+			version, err := parseVersion(entry.Version)
+			if err != nil || compareVersions(version, sinceVersion) <= 0 {
+				continue
+			}
+		}
+
+		date, err := time.Parse(changelogDateLayout, entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid changelog entry date %q: %w", entry.Date, err)
+		}
+		if !since.IsZero() && date.Before(since) {
+			continue
+		}
+		if !until.IsZero() && date.After(until) {
+			continue
+		}
+
+		result = append(result, entry)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date > result[j].Date
+	})
+
+	return result, nil
+}
+
+func parseChangelogDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(changelogDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+
+	return date, nil
+}
+
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("version %q must be in MAJOR.MINOR.PATCH format", version)
+	}
+
+	result := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("version %q must be in MAJOR.MINOR.PATCH format", version)
+		}
+		result[i] = number
+	}
+
+	return result, nil
+}
+
+func compareVersions(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,109 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestChangelogRepository struct {
+	entries []entities.ChangelogEntry
+	err     error
+}
+
+func (r *TestChangelogRepository) GetEntries(ctx context.Context) ([]entities.ChangelogEntry, error) {
+	return r.entries, r.err
+}
+
+func testChangelogEntries() []entities.ChangelogEntry {
+	return []entities.ChangelogEntry{
+		{Version: "2.0.0", Date: "2025-08-02", Type: "added", Endpoint: "GET /api/v1/rates"},
+		{Version: "2.0.0", Date: "2025-08-02", Type: "added", Endpoint: "GET /api/v1/exchange"},
+		{Version: "2.1.0", Date: "2026-10-16", Type: "added", Endpoint: "GET /api/v1/changelog"},
+		{Version: "2.10.0", Date: "2026-12-01", Type: "changed", Endpoint: "GET /api/v1/rates", Breaking: true},
+	}
+}
+
+func TestGetChangelogQueryHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             GetChangelogQuery
+		expectedEndpoints []string
+		expectedError     string
+	}{
+		{
+			name:              "no filters returns newest first",
+			query:             GetChangelogQuery{},
+			expectedEndpoints: []string{"GET /api/v1/rates", "GET /api/v1/changelog", "GET /api/v1/rates", "GET /api/v1/exchange"},
+		},
+		{
+			name:              "exact version",
+			query:             GetChangelogQuery{Version: "2.1.0"},
+			expectedEndpoints: []string{"GET /api/v1/changelog"},
+		},
+		{
+			name:              "since version is exclusive and numeric",
+			query:             GetChangelogQuery{SinceVersion: "2.1.0"},
+			expectedEndpoints: []string{"GET /api/v1/rates"},
+		},
+		{
+			name:              "date range",
+			query:             GetChangelogQuery{Since: "2026-01-01", Until: "2026-10-16"},
+			expectedEndpoints: []string{"GET /api/v1/changelog"},
+		},
+		{
+			name:              "no matches",
+			query:             GetChangelogQuery{Version: "9.9.9"},
+			expectedEndpoints: []string{},
+		},
+		{
+			name:          "invalid since date",
+			query:         GetChangelogQuery{Since: "16/10/2026"},
+			expectedError: "since must be a date in YYYY-MM-DD format",
+		},
+		{
+			name:          "since after until",
+			query:         GetChangelogQuery{Since: "2026-10-16", Until: "2026-01-01"},
+			expectedError: "since must not be after until",
+		},
+		{
+			name:          "invalid since version",
+			query:         GetChangelogQuery{SinceVersion: "latest"},
+			expectedError: "invalid since_version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewGetChangelogQueryHandler(&TestChangelogRepository{entries: testChangelogEntries()})
+
+			entries, err := handler.Handle(context.Background(), tt.query)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			endpoints := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				endpoints = append(endpoints, entry.Endpoint)
+			}
+			assert.Equal(t, tt.expectedEndpoints, endpoints)
+		})
+	}
+}
+
+func TestGetChangelogQueryHandler_RepositoryError(t *testing.T) {
+	handler := NewGetChangelogQueryHandler(&TestChangelogRepository{err: fmt.Errorf("boom")})
+
+	_, err := handler.Handle(context.Background(), GetChangelogQuery{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get changelog")
+}
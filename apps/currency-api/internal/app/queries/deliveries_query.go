@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+const (
+	defaultDeliveriesLimit = 20
+	maxDeliveriesLimit     = 100
+)
+
+// ListDeliveriesQuery lists the latest deliveries of a subscription. Limit
+// is the raw query parameter; empty means the default.
+type ListDeliveriesQuery struct {
+	SubscriptionID string
+	Limit          string
+}
+
+type ListDeliveriesQueryHandler struct {
+	repo repositories.DeliveryRepository
+}
+
+func NewListDeliveriesQueryHandler(repo repositories.DeliveryRepository) *ListDeliveriesQueryHandler {
+	return &ListDeliveriesQueryHandler{repo: repo}
+}
+
+func (h *ListDeliveriesQueryHandler) Handle(ctx context.Context, query ListDeliveriesQuery) ([]entities.Delivery, error) {
+	limit := defaultDeliveriesLimit
+	if query.Limit != "" {
+		parsed, err := strconv.Atoi(query.Limit)
+		if err != nil || parsed < 1 || parsed > maxDeliveriesLimit {
+			return nil, fmt.Errorf("limit must be a number between 1 and %d", maxDeliveriesLimit)
+		}
+		limit = parsed
+	}
+
+	return h.repo.List(ctx, query.SubscriptionID, limit)
+}
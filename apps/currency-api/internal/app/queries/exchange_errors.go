@@ -0,0 +1,175 @@
+package queries
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ErrCodeMissingParameters   = "MISSING_PARAMETERS"
+	ErrCodeInvalidAmount       = "INVALID_AMOUNT"
+	ErrCodeNonPositiveAmount   = "NON_POSITIVE_AMOUNT"
+	ErrCodeUnsupportedCurrency = "UNSUPPORTED_CURRENCY"
+	ErrCodeUnsupportedNetwork  = "UNSUPPORTED_NETWORK"
+	ErrCodeAmountBelowMinimum  = "AMOUNT_BELOW_MINIMUM"
+	ErrCodeAmountAboveMaximum  = "AMOUNT_ABOVE_MAXIMUM"
+
+	// ErrCodePricingRuleFailed and ErrCodeInvalidPricedAmount report a
+	// tenant pricing rule that failed rather than a problem with the request.
+	ErrCodePricingRuleFailed   = "PRICING_RULE_FAILED"
+	ErrCodeInvalidPricedAmount = "INVALID_PRICED_AMOUNT"
+)
+
+// ExchangeValidationError is a client error with a stable code and hints
+// integrators can act on. MinAmount is zero when the pair is unknown.
+type ExchangeValidationError struct {
+	Code       string
+	Message    string
+	MinAmount  decimal.Decimal
+	Suggestion string
+	Example    string
+}
+
+func (e *ExchangeValidationError) Error() string {
+	return e.Message
+}
+
+func newExchangeValidationError(code, message, from, to string) *ExchangeValidationError {
+	err := &ExchangeValidationError{
+		Code:    code,
+		Message: message,
+		Example: exampleExchangeRequest(from, to, "1.0"),
+	}
+
+	if minAmount, ok := minExchangeAmount(from, to); ok {
+		err.MinAmount = minAmount
+	}
+
+	return err
+}
+
+func nonPositiveAmountError(amount decimal.Decimal, from, to string) *ExchangeValidationError {
+	err := newExchangeValidationError(ErrCodeNonPositiveAmount, "amount must be positive", from, to)
+
+	switch {
+	case amount.IsNegative():
+		err.Suggestion = fmt.Sprintf("Amounts are always positive; to convert %s use amount=%s", amount.Abs().String(), amount.Abs().String())
+		err.Example = exampleExchangeRequest(from, to, amount.Abs().String())
+	case !err.MinAmount.IsZero():
+		err.Suggestion = fmt.Sprintf("Use an amount of at least %s", err.MinAmount.String())
+	default:
+		err.Suggestion = "Use an amount greater than zero"
+	}
+
+	return err
+}
+
+func unsupportedCurrencyError(code, from, to string) *ExchangeValidationError {
+	supported := make([]string, 0, len(entities.CryptoCurrencies))
+	for supportedCode := range entities.CryptoCurrencies {
+		supported = append(supported, supportedCode)
+	}
+	sort.Strings(supported)
+
+	err := newExchangeValidationError(ErrCodeUnsupportedCurrency, fmt.Sprintf("unsupported currency %s", code), from, to)
+	err.Suggestion = fmt.Sprintf("Supported currencies: %s", strings.Join(supported, ", "))
+	return err
+}
+
+func unsupportedNetworkError(network string, available []string, from, to string) *ExchangeValidationError {
+	err := newExchangeValidationError(ErrCodeUnsupportedNetwork, fmt.Sprintf("%s is not available on network %s", to, network), from, to)
+	if len(available) > 0 {
+		err.Suggestion = fmt.Sprintf("%s is available on: %s", to, strings.Join(available, ", "))
+	} else {
+		err.Suggestion = "Omit the network parameter to convert without chain constraints"
+	}
+	return err
+}
+
+// checkAmountLimit enforces the fee schedule's limit on the source amount.
+func checkAmountLimit(amount decimal.Decimal, limit entities.AmountLimit, from, to string) error {
+	switch {
+	case amount.LessThan(limit.Min):
+		err := newExchangeValidationError(ErrCodeAmountBelowMinimum, fmt.Sprintf("amount must be at least %s %s", limit.Min.String(), from), from, to)
+		err.MinAmount = limit.Min
+		err.Suggestion = fmt.Sprintf("Use an amount of at least %s", limit.Min.String())
+		err.Example = exampleExchangeRequest(from, to, limit.Min.String())
+		return err
+	case limit.Max.IsPositive() && amount.GreaterThan(limit.Max):
+		err := newExchangeValidationError(ErrCodeAmountAboveMaximum, fmt.Sprintf("amount must be at most %s %s", limit.Max.String(), from), from, to)
+		err.Suggestion = fmt.Sprintf("Split the conversion into amounts of at most %s", limit.Max.String())
+		err.Example = exampleExchangeRequest(from, to, limit.Max.String())
+		return err
+	}
+	return nil
+}
+
+// feeExceedsAmountError rejects a conversion that would not cover its fee.
+// The minimum is the smallest source amount still worth something after it.
+func feeExceedsAmountError(fee entities.Fee, fromCurrency, toCurrency entities.Currency) *ExchangeValidationError {
+	from, to := fromCurrency.Code, toCurrency.Code
+	err := newExchangeValidationError(ErrCodeAmountBelowMinimum, fmt.Sprintf("amount does not cover the fee of %s %s", fee.Flat.String(), to), from, to)
+
+	// result - (result * spread + flat) keeps at least one unit of the target
+	// precision once result >= (flat + unit) / (1 - spread).
+	kept := decimal.NewFromInt(1).Sub(fee.SpreadBps.Div(decimal.NewFromInt(10000)))
+	minResult := fee.Flat.Add(decimal.New(1, -toCurrency.DecimalPlaces)).DivRound(kept, 2*int32(decimal.DivisionPrecision))
+	minAmount := minResult.Mul(toCurrency.RateToUSD).
+		DivRound(fromCurrency.RateToUSD, 2*int32(decimal.DivisionPrecision)).
+		RoundCeil(fromCurrency.DecimalPlaces)
+
+	err.MinAmount = decimal.Max(err.MinAmount, minAmount)
+	err.Suggestion = fmt.Sprintf("Use an amount of at least %s", err.MinAmount.String())
+	err.Example = exampleExchangeRequest(from, to, err.MinAmount.String())
+	return err
+}
+
+// minExchangeAmount is the smallest amount, in the source currency's precision,
+// that converts to at least one unit of the target currency's precision.
+func minExchangeAmount(from, to string) (decimal.Decimal, bool) {
+	fromCurrency, err := entities.GetCurrency(from)
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	toCurrency, err := entities.GetCurrency(to)
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	return constantsFor(fromCurrency, toCurrency).minAmount, true
+}
+
+// minAmountForPlaces is minExchangeAmount for a result kept to targetPlaces,
+// which is below the currency's own precision on some chains.
+func minAmountForPlaces(fromCurrency, toCurrency entities.Currency, targetPlaces int32) decimal.Decimal {
+	// Conversion divides with decimal.DivisionPrecision places, which caps
+	// the smallest result below the precision of 18-decimal tokens.
+	targetPlaces = min(targetPlaces, int32(decimal.DivisionPrecision))
+
+	sourceUnit := decimal.New(1, -fromCurrency.DecimalPlaces)
+	targetUnit := decimal.New(1, -targetPlaces)
+
+	required := targetUnit.Mul(toCurrency.RateToUSD).
+		DivRound(fromCurrency.RateToUSD, 2*int32(decimal.DivisionPrecision)).
+		RoundCeil(fromCurrency.DecimalPlaces)
+
+	return decimal.Max(required, sourceUnit)
+}
+
+func exampleExchangeRequest(from, to, amount string) string {
+	if _, err := entities.GetCurrency(from); err != nil {
+		from = "WBTC"
+	}
+	if _, err := entities.GetCurrency(to); err != nil || to == from {
+		to = "USDT"
+		if from == "USDT" {
+			to = "WBTC"
+		}
+	}
+	return fmt.Sprintf("GET /api/v1/exchange?from=%s&to=%s&amount=%s", from, to, amount)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
 	"github.com/shopspring/decimal"
 )
 
@@ -13,12 +15,57 @@ type ExchangeQuery struct {
 	From   string
 	To     string
 	Amount string
+	Tenant string
+	// Network optionally names the chain the target asset is delivered on;
+	// the result then follows that chain's decimals and dust limit.
+	Network string
 }
 
-type ExchangeQueryHandler struct{}
+type ExchangeQueryHandler struct {
+	pricingRules services.PricingRules
+	chainRules   services.ChainRules
+	feeSchedules services.FeeSchedules
+	recorder     services.ConversionRecorder
+	now          func() time.Time
+}
+
+type ExchangeQueryOption func(*ExchangeQueryHandler)
+
+func WithPricingRules(rules services.PricingRules) ExchangeQueryOption {
+	return func(h *ExchangeQueryHandler) {
+		h.pricingRules = rules
+	}
+}
 
-func NewExchangeQueryHandler() *ExchangeQueryHandler {
-	return &ExchangeQueryHandler{}
+// WithChainRules enables the network parameter for chain-specific previews.
+func WithChainRules(rules services.ChainRules) ExchangeQueryOption {
+	return func(h *ExchangeQueryHandler) {
+		h.chainRules = rules
+	}
+}
+
+// WithFeeSchedules charges the fees and enforces the amount limits of the
+// fee schedule in effect.
+func WithFeeSchedules(schedules services.FeeSchedules) ExchangeQueryOption {
+	return func(h *ExchangeQueryHandler) {
+		h.feeSchedules = schedules
+	}
+}
+
+// WithConversionRecorder hands a sample of the served conversions to the
+// precision audit.
+func WithConversionRecorder(recorder services.ConversionRecorder) ExchangeQueryOption {
+	return func(h *ExchangeQueryHandler) {
+		h.recorder = recorder
+	}
+}
+
+func NewExchangeQueryHandler(opts ...ExchangeQueryOption) *ExchangeQueryHandler {
+	h := &ExchangeQueryHandler{now: time.Now}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *ExchangeQueryHandler) Handle(ctx context.Context, query ExchangeQuery) (*entities.ExchangeResult, error) {
@@ -26,36 +73,181 @@ func (h *ExchangeQueryHandler) Handle(ctx context.Context, query ExchangeQuery)
 	to := strings.ToUpper(strings.TrimSpace(query.To))
 
 	if from == "" || to == "" || query.Amount == "" {
-		return nil, fmt.Errorf("from, to, and amount parameters are required")
+		return nil, newExchangeValidationError(ErrCodeMissingParameters, "from, to, and amount parameters are required", from, to)
 	}
 
-	amount, err := decimal.NewFromString(query.Amount)
+	amount, err := parseAmount(query.Amount)
 	if err != nil {
-		return nil, fmt.Errorf("invalid amount: %w", err)
+		validationErr := newExchangeValidationError(ErrCodeInvalidAmount, fmt.Sprintf("invalid amount: %s", err), from, to)
+		validationErr.Suggestion = "Use a plain decimal number such as 1.5 (no thousands separators or currency symbols)"
+		return nil, validationErr
 	}
 
-	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, fmt.Errorf("amount must be positive")
+	// Sign avoids the rescaling a comparison with decimal.Zero does.
+	if amount.Sign() <= 0 {
+		return nil, nonPositiveAmountError(amount, from, to)
 	}
 
 	fromCurrency, err := entities.GetCurrency(from)
 	if err != nil {
-		return nil, fmt.Errorf("unsupported currency %s", from)
+		return nil, unsupportedCurrencyError(from, from, to)
 	}
 
 	toCurrency, err := entities.GetCurrency(to)
 	if err != nil {
-		return nil, fmt.Errorf("unsupported currency %s", to)
+		return nil, unsupportedCurrencyError(to, from, to)
+	}
+
+	var chain *entities.ChainConstraints
+	if network := strings.TrimSpace(query.Network); network != "" {
+		constraints, err := h.chainConstraints(network, from, to)
+		if err != nil {
+			return nil, err
+		}
+		chain = &constraints
+	}
+
+	// Without a schedule in effect the zero value has no fees and no limits.
+	var schedule entities.FeeSchedule
+	if h.feeSchedules != nil {
+		schedule, _ = h.feeSchedules.Current()
+	}
+	if limit, ok := schedule.LimitFor(from); ok {
+		if err := checkAmountLimit(amount, limit, from, to); err != nil {
+			return nil, err
+		}
 	}
 
 	usdAmount := amount.Mul(fromCurrency.RateToUSD)
 	resultAmount := usdAmount.Div(toCurrency.RateToUSD)
 
+	var charged decimal.Decimal
+	var appliedFee *entities.AppliedFee
+	fee, hasFee := schedule.FeeFor(from, to)
+	if hasFee {
+		charged = fee.Charge(resultAmount)
+		if charged.GreaterThanOrEqual(resultAmount) {
+			return nil, feeExceedsAmountError(fee, fromCurrency, toCurrency)
+		}
+		resultAmount = resultAmount.Sub(charged)
+		appliedFee = &entities.AppliedFee{
+			ScheduleVersion: schedule.Version,
+			SpreadBps:       fee.SpreadBps,
+			Amount:          toCurrency.RoundToDecimalPlaces(charged),
+		}
+	}
+
+	var pricingRule string
+	var priced bool
+	unpriced := resultAmount
+	if h.pricingRules != nil {
+		adjusted, rule, err := h.pricingRules.Apply(ctx, services.PricingInput{
+			Tenant:        query.Tenant,
+			From:          from,
+			To:            to,
+			Amount:        resultAmount,
+			Rate:          constantsFor(fromCurrency, toCurrency).rate,
+			DecimalPlaces: toCurrency.DecimalPlaces,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply pricing rule: %w", err)
+		}
+		if rule != "" {
+			resultAmount = adjusted
+			priced = true
+			pricingRule = rule
+		}
+	}
+
 	finalAmount := toCurrency.RoundToDecimalPlaces(resultAmount)
 
-	return &entities.ExchangeResult{
-		From:   from,
-		To:     to,
-		Amount: finalAmount,
-	}, nil
+	result := &entities.ExchangeResult{
+		From:        from,
+		To:          to,
+		Amount:      finalAmount,
+		PricingRule: pricingRule,
+		Fee:         appliedFee,
+	}
+
+	decimalPlaces := toCurrency.DecimalPlaces
+	if chain != nil {
+		// Amounts beyond the token's decimals on the chain cannot be
+		// transferred, so they are cut rather than rounded up.
+		decimalPlaces = min(chain.DecimalPlaces, toCurrency.DecimalPlaces)
+		result.Amount = finalAmount.Truncate(decimalPlaces)
+		result.Chain = &entities.ChainPreview{
+			Network:        chain.Network,
+			DecimalPlaces:  chain.DecimalPlaces,
+			DustLimit:      chain.DustLimit,
+			BelowDustLimit: result.Amount.LessThan(chain.DustLimit),
+		}
+	}
+
+	if result.Amount.IsZero() {
+		// Div keeps decimal.DivisionPrecision places, which is not enough to
+		// show how small an underflowing result actually is. The wider
+		// division is the costliest step of a conversion, so only results
+		// that rounded to zero pay for it.
+		unroundedAmount := resultAmount
+		if !priced {
+			unroundedAmount = usdAmount.DivRound(toCurrency.RateToUSD, 2*int32(decimal.DivisionPrecision))
+			if appliedFee != nil {
+				unroundedAmount = unroundedAmount.Sub(charged)
+			}
+		}
+		if unroundedAmount.IsPositive() {
+			result.Warning = underflowWarning(fromCurrency, toCurrency, unroundedAmount, decimalPlaces)
+		}
+	}
+
+	if h.recorder != nil && h.recorder.Sample() {
+		record := entities.ConversionRecord{
+			At:            h.now().UTC(),
+			From:          fromCurrency,
+			To:            toCurrency,
+			Amount:        amount,
+			PricingRule:   pricingRule,
+			DecimalPlaces: decimalPlaces,
+			Result:        result.Amount,
+		}
+		if priced {
+			record.PricingInput = &unpriced
+		}
+		if hasFee {
+			record.Fee = &fee
+			record.FeeScheduleVersion = schedule.Version
+		}
+		h.recorder.Record(record)
+	}
+
+	return result, nil
+}
+
+// underflowWarning explains a positive conversion that rounds to zero, so
+// callers do not mistake it for a real zero amount.
+func underflowWarning(fromCurrency, toCurrency entities.Currency, unrounded decimal.Decimal, decimalPlaces int32) *entities.PrecisionWarning {
+	minAmount := constantsFor(fromCurrency, toCurrency).minAmount
+	if decimalPlaces != toCurrency.DecimalPlaces {
+		minAmount = minAmountForPlaces(fromCurrency, toCurrency, decimalPlaces)
+	}
+	return &entities.PrecisionWarning{
+		Code: entities.WarningAmountUnderflow,
+		Message: fmt.Sprintf("result is smaller than the %d decimal places of %s and was rounded to zero; convert at least %s %s",
+			decimalPlaces, toCurrency.Code, minAmount.String(), fromCurrency.Code),
+		UnroundedAmount:     unrounded,
+		DecimalPlaces:       decimalPlaces,
+		MinMeaningfulAmount: minAmount,
+	}
+}
+
+func (h *ExchangeQueryHandler) chainConstraints(network, from, to string) (entities.ChainConstraints, error) {
+	if h.chainRules == nil {
+		return entities.ChainConstraints{}, unsupportedNetworkError(network, nil, from, to)
+	}
+
+	constraints, ok := h.chainRules.Constraints(network, to)
+	if !ok {
+		return entities.ChainConstraints{}, unsupportedNetworkError(network, h.chainRules.Networks(to), from, to)
+	}
+	return constraints, nil
 }
@@ -2,8 +2,12 @@ package queries
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -171,3 +175,458 @@ func TestExchangeQueryHandler_AllCryptoPairs_WithDecimal(t *testing.T) {
 		}
 	}
 }
+
+type TestPricingRules struct {
+	rule   string
+	factor decimal.Decimal
+	err    error
+	input  services.PricingInput
+}
+
+func (r *TestPricingRules) Apply(ctx context.Context, input services.PricingInput) (decimal.Decimal, string, error) {
+	r.input = input
+	if r.err != nil {
+		return decimal.Zero, "", r.err
+	}
+	if r.rule == "" {
+		return input.Amount, "", nil
+	}
+	return input.Amount.Mul(r.factor), r.rule, nil
+}
+
+func TestExchangeQueryHandler_Handle_WithPricingRules(t *testing.T) {
+	ctx := context.Background()
+	query := ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1.0", Tenant: "acme"}
+
+	t.Run("rule applied and rounded to target precision", func(t *testing.T) {
+		rules := &TestPricingRules{rule: "tenant:acme", factor: decimal.RequireFromString("0.99")}
+		handler := NewExchangeQueryHandler(WithPricingRules(rules))
+
+		result, err := handler.Handle(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, "tenant:acme", result.PricingRule)
+		assert.True(t, decimal.RequireFromString("56523.371171").Equal(result.Amount), "got %s", result.Amount)
+		assert.Equal(t, "acme", rules.input.Tenant)
+		assert.Equal(t, int32(6), rules.input.DecimalPlaces)
+	})
+
+	t.Run("no matching rule keeps computed amount", func(t *testing.T) {
+		handler := NewExchangeQueryHandler(WithPricingRules(&TestPricingRules{}))
+
+		result, err := handler.Handle(ctx, query)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.PricingRule)
+		assert.True(t, decimal.RequireFromString("57094.314314").Equal(result.Amount))
+	})
+
+	t.Run("rule failure", func(t *testing.T) {
+		handler := NewExchangeQueryHandler(WithPricingRules(&TestPricingRules{err: fmt.Errorf("timed out")}))
+
+		_, err := handler.Handle(ctx, query)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to apply pricing rule")
+	})
+}
+
+type TestChainRules map[string]map[string]entities.ChainConstraints
+
+func (r TestChainRules) Constraints(network, asset string) (entities.ChainConstraints, bool) {
+	constraints, ok := r[network][asset]
+	return constraints, ok
+}
+
+func (r TestChainRules) Networks(asset string) []string {
+	var networks []string
+	for network, assets := range r {
+		if _, ok := assets[asset]; ok {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func TestExchangeQueryHandler_Handle_WithChainRules(t *testing.T) {
+	ctx := context.Background()
+	handler := NewExchangeQueryHandler(WithChainRules(TestChainRules{
+		"ethereum": {
+			"FLOKI": {Network: "ethereum", DecimalPlaces: 9, DustLimit: decimal.RequireFromString("150000")},
+			"WBTC":  {Network: "ethereum", DecimalPlaces: 8, DustLimit: decimal.RequireFromString("0.0005")},
+		},
+	}))
+
+	t.Run("result cut to the chain decimals", func(t *testing.T) {
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "FLOKI", Amount: "100", Network: "ethereum"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "699579.831932773", result.Amount.String())
+		require.NotNil(t, result.Chain)
+		assert.Equal(t, "ethereum", result.Chain.Network)
+		assert.Equal(t, int32(9), result.Chain.DecimalPlaces)
+		assert.False(t, result.Chain.BelowDustLimit)
+	})
+
+	t.Run("result below the dust limit", func(t *testing.T) {
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "WBTC", Amount: "10", Network: "ethereum"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "0.00017515", result.Amount.String())
+		assert.True(t, result.Chain.BelowDustLimit)
+	})
+
+	t.Run("no network keeps the plain conversion", func(t *testing.T) {
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "FLOKI", Amount: "100"})
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Chain)
+		assert.Equal(t, "699579.8319327731092437", result.Amount.String())
+	})
+
+	t.Run("asset not on the network", func(t *testing.T) {
+		_, err := handler.Handle(ctx, ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1", Network: "ethereum"})
+
+		var validationErr *ExchangeValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, ErrCodeUnsupportedNetwork, validationErr.Code)
+		assert.Equal(t, "USDT is not available on network ethereum", validationErr.Message)
+	})
+
+	t.Run("suggests the networks of the asset", func(t *testing.T) {
+		_, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "WBTC", Amount: "1", Network: "tron"})
+
+		var validationErr *ExchangeValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "WBTC is available on: ethereum", validationErr.Suggestion)
+	})
+}
+
+func TestExchangeQueryHandler_Handle_ValidationErrors(t *testing.T) {
+	handler := NewExchangeQueryHandler()
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		query              ExchangeQuery
+		expectedCode       string
+		expectedMinAmount  string
+		expectedSuggestion string
+		expectedExample    string
+	}{
+		{
+			name:               "zero amount",
+			query:              ExchangeQuery{From: "USDT", To: "WBTC", Amount: "0"},
+			expectedCode:       ErrCodeNonPositiveAmount,
+			expectedMinAmount:  "0.000571",
+			expectedSuggestion: "Use an amount of at least 0.000571",
+			expectedExample:    "GET /api/v1/exchange?from=USDT&to=WBTC&amount=1.0",
+		},
+		{
+			name:               "negative amount suggests absolute value",
+			query:              ExchangeQuery{From: "wbtc", To: "usdt", Amount: "-2.5"},
+			expectedCode:       ErrCodeNonPositiveAmount,
+			expectedMinAmount:  "0.00000001",
+			expectedSuggestion: "Amounts are always positive; to convert 2.5 use amount=2.5",
+			expectedExample:    "GET /api/v1/exchange?from=WBTC&to=USDT&amount=2.5",
+		},
+		{
+			name:               "zero amount with unknown pair",
+			query:              ExchangeQuery{From: "MATIC", To: "USDT", Amount: "0"},
+			expectedCode:       ErrCodeNonPositiveAmount,
+			expectedSuggestion: "Use an amount greater than zero",
+			expectedExample:    "GET /api/v1/exchange?from=WBTC&to=USDT&amount=1.0",
+		},
+		{
+			name:               "invalid amount",
+			query:              ExchangeQuery{From: "GATE", To: "BEER", Amount: "1,000"},
+			expectedCode:       ErrCodeInvalidAmount,
+			expectedMinAmount:  "0.000000000000000001",
+			expectedSuggestion: "Use a plain decimal number such as 1.5 (no thousands separators or currency symbols)",
+			expectedExample:    "GET /api/v1/exchange?from=GATE&to=BEER&amount=1.0",
+		},
+		{
+			name:            "missing parameters",
+			query:           ExchangeQuery{From: "USDT"},
+			expectedCode:    ErrCodeMissingParameters,
+			expectedExample: "GET /api/v1/exchange?from=USDT&to=WBTC&amount=1.0",
+		},
+		{
+			name:               "unsupported currency",
+			query:              ExchangeQuery{From: "WBTC", To: "MATIC", Amount: "1"},
+			expectedCode:       ErrCodeUnsupportedCurrency,
+			expectedSuggestion: "Supported currencies: BEER, FLOKI, GATE, USDT, WBTC",
+			expectedExample:    "GET /api/v1/exchange?from=WBTC&to=USDT&amount=1.0",
+		},
+		{
+			name:               "network without chain rules",
+			query:              ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1", Network: "ethereum"},
+			expectedCode:       ErrCodeUnsupportedNetwork,
+			expectedMinAmount:  "0.00000001",
+			expectedSuggestion: "Omit the network parameter to convert without chain constraints",
+			expectedExample:    "GET /api/v1/exchange?from=WBTC&to=USDT&amount=1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Handle(ctx, tt.query)
+
+			var validationErr *ExchangeValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.expectedCode, validationErr.Code)
+			assert.Equal(t, tt.expectedSuggestion, validationErr.Suggestion)
+			assert.Equal(t, tt.expectedExample, validationErr.Example)
+
+			if tt.expectedMinAmount == "" {
+				assert.True(t, validationErr.MinAmount.IsZero())
+			} else {
+				assert.True(t, decimal.RequireFromString(tt.expectedMinAmount).Equal(validationErr.MinAmount),
+					"expected min amount %s, got %s", tt.expectedMinAmount, validationErr.MinAmount)
+			}
+		})
+	}
+}
+
+func TestMinExchangeAmount_ProducesNonZeroResult(t *testing.T) {
+	handler := NewExchangeQueryHandler()
+
+	for from := range entities.CryptoCurrencies {
+		for to := range entities.CryptoCurrencies {
+			minAmount, ok := minExchangeAmount(from, to)
+			require.True(t, ok)
+
+			result, err := handler.Handle(context.Background(), ExchangeQuery{From: from, To: to, Amount: minAmount.String()})
+
+			require.NoError(t, err)
+			assert.True(t, result.Amount.IsPositive(), "%s -> %s: min amount %s converts to zero", from, to, minAmount)
+		}
+	}
+}
+
+func TestExchangeQueryHandler_Handle_Underflow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("result below the target precision", func(t *testing.T) {
+		result, err := NewExchangeQueryHandler().Handle(ctx, ExchangeQuery{From: "BEER", To: "WBTC", Amount: "1"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Amount.IsZero())
+		require.NotNil(t, result.Warning)
+		assert.Equal(t, entities.WarningAmountUnderflow, result.Warning.Code)
+		assert.Equal(t, int32(8), result.Warning.DecimalPlaces)
+		assert.Equal(t, "0.00000000043147264189944741346089", result.Warning.UnroundedAmount.String())
+		assert.Equal(t, "23.176440471353108493", result.Warning.MinMeaningfulAmount.String())
+		assert.Equal(t, "result is smaller than the 8 decimal places of WBTC and was rounded to zero; convert at least 23.176440471353108493 BEER", result.Warning.Message)
+
+		minimum, err := NewExchangeQueryHandler().Handle(ctx, ExchangeQuery{From: "BEER", To: "WBTC", Amount: result.Warning.MinMeaningfulAmount.String()})
+		require.NoError(t, err)
+		assert.True(t, minimum.Amount.IsPositive())
+		assert.Nil(t, minimum.Warning)
+	})
+
+	t.Run("result below the chain decimals", func(t *testing.T) {
+		handler := NewExchangeQueryHandler(WithChainRules(TestChainRules{
+			"ethereum": {"FLOKI": {Network: "ethereum", DecimalPlaces: 9, DustLimit: decimal.RequireFromString("150000")}},
+		}))
+
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "BEER", To: "FLOKI", Amount: "0.000000001", Network: "ethereum"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Amount.IsZero())
+		require.NotNil(t, result.Warning)
+		assert.Equal(t, int32(9), result.Warning.DecimalPlaces)
+		assert.Equal(t, "0.000000005802519302", result.Warning.MinMeaningfulAmount.String())
+	})
+
+	t.Run("representable result has no warning", func(t *testing.T) {
+		result, err := NewExchangeQueryHandler().Handle(ctx, ExchangeQuery{From: "GATE", To: "WBTC", Amount: "100"})
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Warning)
+	})
+
+	t.Run("pricing rule rounding to zero on purpose", func(t *testing.T) {
+		handler := NewExchangeQueryHandler(WithPricingRules(&TestPricingRules{rule: "tenant:acme", factor: decimal.Zero}))
+
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "GATE", To: "WBTC", Amount: "100", Tenant: "acme"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Amount.IsZero())
+		assert.Nil(t, result.Warning)
+	})
+}
+
+type TestFeeSchedules entities.FeeSchedule
+
+func (s TestFeeSchedules) Current() (entities.FeeSchedule, bool) {
+	return entities.FeeSchedule(s), s.Version > 0
+}
+
+func TestExchangeQueryHandler_Handle_WithFeeSchedules(t *testing.T) {
+	ctx := context.Background()
+	handler := NewExchangeQueryHandler(WithFeeSchedules(TestFeeSchedules{
+		Version: 3,
+		Fees: map[string]entities.Fee{
+			"*":         {SpreadBps: decimal.RequireFromString("25"), Flat: decimal.Zero},
+			"USDT-WBTC": {SpreadBps: decimal.Zero, Flat: decimal.RequireFromString("0.001")},
+		},
+		Limits: map[string]entities.AmountLimit{
+			"USDT": {Min: decimal.RequireFromString("10")},
+			"WBTC": {Max: decimal.RequireFromString("2")},
+		},
+	}))
+
+	t.Run("fee charged on the converted amount", func(t *testing.T) {
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1.0"})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Fee)
+		assert.Equal(t, int64(3), result.Fee.ScheduleVersion)
+		assert.True(t, decimal.RequireFromString("142.735786").Equal(result.Fee.Amount), "got %s", result.Fee.Amount)
+		assert.True(t, decimal.RequireFromString("56951.578529").Equal(result.Amount), "got %s", result.Amount)
+	})
+
+	t.Run("no schedule in effect", func(t *testing.T) {
+		result, err := NewExchangeQueryHandler(WithFeeSchedules(TestFeeSchedules{})).Handle(ctx, ExchangeQuery{From: "WBTC", To: "USDT", Amount: "3"})
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Fee)
+		assert.True(t, decimal.RequireFromString("171282.942943").Equal(result.Amount), "got %s", result.Amount)
+	})
+
+	t.Run("fee larger than the converted amount", func(t *testing.T) {
+		_, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "WBTC", Amount: "20"})
+
+		var validationErr *ExchangeValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, ErrCodeAmountBelowMinimum, validationErr.Code)
+		assert.Equal(t, "amount does not cover the fee of 0.001 WBTC", validationErr.Message)
+		assert.Equal(t, "57.094886", validationErr.MinAmount.String())
+
+		result, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "WBTC", Amount: "57.094886"})
+		require.NoError(t, err)
+		assert.Equal(t, "0.00000001", result.Amount.String())
+		assert.Equal(t, "0.001", result.Fee.Amount.String())
+	})
+
+	for name, tt := range map[string]struct {
+		query        ExchangeQuery
+		expectedCode string
+		suggestion   string
+	}{
+		"below the minimum": {ExchangeQuery{From: "USDT", To: "WBTC", Amount: "5"}, ErrCodeAmountBelowMinimum, "Use an amount of at least 10"},
+		"above the maximum": {ExchangeQuery{From: "WBTC", To: "USDT", Amount: "2.5"}, ErrCodeAmountAboveMaximum, "Split the conversion into amounts of at most 2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := handler.Handle(ctx, tt.query)
+
+			var validationErr *ExchangeValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.expectedCode, validationErr.Code)
+			assert.Equal(t, tt.suggestion, validationErr.Suggestion)
+		})
+	}
+}
+
+type TestConversionRecorder struct {
+	sample  bool
+	records []entities.ConversionRecord
+}
+
+func (r *TestConversionRecorder) Sample() bool { return r.sample }
+
+func (r *TestConversionRecorder) Record(record entities.ConversionRecord) {
+	r.records = append(r.records, record)
+}
+
+func TestExchangeQueryHandler_Handle_RecordsSamples(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+	recorder := &TestConversionRecorder{sample: true}
+	handler := NewExchangeQueryHandler(
+		WithChainRules(TestChainRules{"ethereum": {"FLOKI": {Network: "ethereum", DecimalPlaces: 9}}}),
+		WithFeeSchedules(TestFeeSchedules{Version: 2, Fees: map[string]entities.Fee{
+			"WBTC-USDT": {SpreadBps: decimal.RequireFromString("25"), Flat: decimal.RequireFromString("0")},
+		}}),
+		WithConversionRecorder(recorder),
+	)
+	handler.now = func() time.Time { return now }
+
+	feeResult, err := handler.Handle(ctx, ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1.0"})
+	require.NoError(t, err)
+	chainResult, err := handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "FLOKI", Amount: "100", Network: "ethereum"})
+	require.NoError(t, err)
+	_, err = handler.Handle(ctx, ExchangeQuery{From: "USDT", To: "DOGE", Amount: "1"})
+	require.Error(t, err)
+
+	require.Len(t, recorder.records, 2)
+	withFee := recorder.records[0]
+	assert.Equal(t, now, withFee.At)
+	assert.Equal(t, entities.CryptoCurrencies["WBTC"], withFee.From)
+	assert.Equal(t, entities.CryptoCurrencies["USDT"], withFee.To)
+	assert.Equal(t, "1", withFee.Amount.String())
+	require.NotNil(t, withFee.Fee)
+	assert.Equal(t, "25", withFee.Fee.SpreadBps.String())
+	assert.Equal(t, int64(2), withFee.FeeScheduleVersion)
+	assert.Equal(t, int32(6), withFee.DecimalPlaces)
+	assert.Equal(t, feeResult.Amount, withFee.Result)
+
+	onChain := recorder.records[1]
+	assert.Nil(t, onChain.Fee)
+	assert.Equal(t, int32(9), onChain.DecimalPlaces)
+	assert.Equal(t, chainResult.Amount, onChain.Result)
+
+	recorder.sample = false
+	_, err = handler.Handle(ctx, ExchangeQuery{From: "WBTC", To: "USDT", Amount: "2"})
+	require.NoError(t, err)
+	assert.Len(t, recorder.records, 2)
+}
+
+func TestExchangeQueryHandler_Handle_RecordsThePricingInput(t *testing.T) {
+	recorder := &TestConversionRecorder{sample: true}
+	rules := &TestPricingRules{rule: "tenant:acme", factor: decimal.RequireFromString("0.99")}
+	handler := NewExchangeQueryHandler(WithPricingRules(rules), WithConversionRecorder(recorder))
+
+	_, err := handler.Handle(context.Background(), ExchangeQuery{From: "WBTC", To: "USDT", Amount: "1.0", Tenant: "acme"})
+	require.NoError(t, err)
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, "tenant:acme", recorder.records[0].PricingRule)
+	require.NotNil(t, recorder.records[0].PricingInput)
+	assert.Equal(t, rules.input.Amount, *recorder.records[0].PricingInput)
+}
+
+// batchConversionQueries is a bulk upload sized workload over every pair, with
+// amounts shaped like the ones clients send.
+func batchConversionQueries(n int) []ExchangeQuery {
+	codes := []string{"BEER", "FLOKI", "GATE", "USDT", "WBTC"}
+	amounts := []string{"1", "1.5", "0.00012345", "250000", "12345.678901", "0.1", "99.99", "1000000000"}
+
+	queries := make([]ExchangeQuery, 0, n)
+	for i := 0; len(queries) < n; i++ {
+		from, to := codes[i%len(codes)], codes[(i/len(codes)+1+i)%len(codes)]
+		if from == to {
+			continue
+		}
+		queries = append(queries, ExchangeQuery{From: from, To: to, Amount: amounts[i%len(amounts)]})
+	}
+	return queries
+}
+
+func BenchmarkExchangeQueryHandler_Batch(b *testing.B) {
+	handler := NewExchangeQueryHandler()
+	ctx := context.Background()
+	queries := batchConversionQueries(1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, query := range queries {
+			if _, err := handler.Handle(ctx, query); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(queries)), "ns/conversion")
+}
@@ -0,0 +1,74 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// ErrInvalidFeeAuditLimit rejects a limit outside 1 to maxFeeAuditLimit.
+var ErrInvalidFeeAuditLimit = fmt.Errorf("limit must be a number between 1 and %d", maxFeeAuditLimit)
+
+const (
+	defaultFeeAuditLimit = 50
+	maxFeeAuditLimit     = 500
+)
+
+// FeeScheduleReader is the in-process view of the fee schedules.
+type FeeScheduleReader interface {
+	Current() (entities.FeeSchedule, bool)
+	Pending() []entities.FeeSchedule
+}
+
+// FeeScheduleView is the fee schedule this instance applies, nil while none
+// is in effect, and the versions staged to take effect later.
+type FeeScheduleView struct {
+	Current *entities.FeeSchedule
+	Pending []entities.FeeSchedule
+}
+
+type GetFeeScheduleQueryHandler struct {
+	schedules FeeScheduleReader
+}
+
+func NewGetFeeScheduleQueryHandler(schedules FeeScheduleReader) *GetFeeScheduleQueryHandler {
+	return &GetFeeScheduleQueryHandler{schedules: schedules}
+}
+
+func (h *GetFeeScheduleQueryHandler) Handle(ctx context.Context) FeeScheduleView {
+	view := FeeScheduleView{Pending: h.schedules.Pending()}
+	if current, ok := h.schedules.Current(); ok {
+		view.Current = &current
+	}
+	return view
+}
+
+// ListFeeAuditQuery lists the latest fee schedule audit entries. Limit is
+// the raw query parameter; empty means the default.
+type ListFeeAuditQuery struct {
+	Limit string
+}
+
+type ListFeeAuditQueryHandler struct {
+	repo repositories.FeeScheduleRepository
+}
+
+func NewListFeeAuditQueryHandler(repo repositories.FeeScheduleRepository) *ListFeeAuditQueryHandler {
+	return &ListFeeAuditQueryHandler{repo: repo}
+}
+
+func (h *ListFeeAuditQueryHandler) Handle(ctx context.Context, query ListFeeAuditQuery) ([]entities.FeeAuditEntry, error) {
+	limit := defaultFeeAuditLimit
+	if query.Limit != "" {
+		parsed, err := strconv.Atoi(query.Limit)
+		if err != nil || parsed < 1 || parsed > maxFeeAuditLimit {
+			return nil, ErrInvalidFeeAuditLimit
+		}
+		limit = parsed
+	}
+
+	return h.repo.Audit(ctx, limit)
+}
@@ -0,0 +1,61 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+var (
+	ErrJobNotCompleted = errors.New("job has not completed yet")
+	ErrJobHasNoResult  = errors.New("job has no downloadable result")
+)
+
+// GetJobQuery looks up a job of Tenant; jobs submitted for another tenant
+// are reported as not found, so their IDs cannot be probed. SignedLink marks
+// a result download authorized by a signed link, which is only handed out
+// with the status of the tenant's own job, so its tenant is not checked.
+type GetJobQuery struct {
+	ID         string
+	Tenant     string
+	SignedLink bool
+}
+
+type GetJobQueryHandler struct {
+	repo repositories.JobRepository
+}
+
+func NewGetJobQueryHandler(repo repositories.JobRepository) *GetJobQueryHandler {
+	return &GetJobQueryHandler{repo: repo}
+}
+
+func (h *GetJobQueryHandler) Handle(ctx context.Context, query GetJobQuery) (entities.Job, error) {
+	job, err := h.repo.Get(ctx, query.ID)
+	if err != nil {
+		return entities.Job{}, err
+	}
+	if job.Tenant != query.Tenant {
+		return entities.Job{}, repositories.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// HandleResult returns the downloadable output once the job has completed.
+func (h *GetJobQueryHandler) HandleResult(ctx context.Context, query GetJobQuery) (entities.JobResult, error) {
+	job, err := h.repo.Get(ctx, query.ID)
+	if err != nil {
+		return entities.JobResult{}, err
+	}
+	if !query.SignedLink && job.Tenant != query.Tenant {
+		return entities.JobResult{}, repositories.ErrJobNotFound
+	}
+	if job.Status != entities.JobCompleted {
+		return entities.JobResult{}, ErrJobNotCompleted
+	}
+	if !job.HasResult {
+		return entities.JobResult{}, ErrJobHasNoResult
+	}
+	return h.repo.GetResult(ctx, query.ID)
+}
@@ -0,0 +1,48 @@
+package queries
+
+import (
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/shopspring/decimal"
+)
+
+type currencyPair struct {
+	from, to string
+}
+
+// pairConstants depend only on the static currency table, so they are worked
+// out once per pair at startup instead of on every conversion.
+type pairConstants struct {
+	// rate is the target amount for one unit of the source, as handed to
+	// pricing rules.
+	rate decimal.Decimal
+	// minAmount is minExchangeAmount for the pair.
+	minAmount decimal.Decimal
+}
+
+var pairConstantsTable = buildPairConstants(entities.CryptoCurrencies)
+
+func buildPairConstants(currencies map[string]entities.Currency) map[currencyPair]pairConstants {
+	table := make(map[currencyPair]pairConstants, len(currencies)*len(currencies))
+	for _, from := range currencies {
+		for _, to := range currencies {
+			table[currencyPair{from.Code, to.Code}] = computePairConstants(from, to)
+		}
+	}
+	return table
+}
+
+func computePairConstants(fromCurrency, toCurrency entities.Currency) pairConstants {
+	return pairConstants{
+		rate:      fromCurrency.RateToUSD.Div(toCurrency.RateToUSD),
+		minAmount: minAmountForPlaces(fromCurrency, toCurrency, toCurrency.DecimalPlaces),
+	}
+}
+
+// constantsFor looks the pair up, computing it for currencies that are not in
+// the table.
+func constantsFor(fromCurrency, toCurrency entities.Currency) pairConstants {
+	if constants, ok := pairConstantsTable[currencyPair{fromCurrency.Code, toCurrency.Code}]; ok {
+		return constants
+	}
+	return computePairConstants(fromCurrency, toCurrency)
+}
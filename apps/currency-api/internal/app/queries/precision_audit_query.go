@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// ErrInvalidAuditDate rejects a date that is not YYYY-MM-DD.
+var ErrInvalidAuditDate = errors.New("date must be formatted as YYYY-MM-DD")
+
+// GetPrecisionAuditQuery asks for the precision audit of one UTC day. Date is
+// the raw query parameter; empty means yesterday, the day the latest nightly
+// run covered.
+type GetPrecisionAuditQuery struct {
+	Date string
+}
+
+type GetPrecisionAuditQueryHandler struct {
+	repo repositories.PrecisionAuditRepository
+	now  func() time.Time
+}
+
+func NewGetPrecisionAuditQueryHandler(repo repositories.PrecisionAuditRepository) *GetPrecisionAuditQueryHandler {
+	return &GetPrecisionAuditQueryHandler{repo: repo, now: time.Now}
+}
+
+// Handle returns the report of the day; ok is false when the day has not
+// been audited.
+func (h *GetPrecisionAuditQueryHandler) Handle(ctx context.Context, query GetPrecisionAuditQuery) (report entities.PrecisionAuditReport, ok bool, err error) {
+	date := h.now().UTC().AddDate(0, 0, -1)
+	if query.Date != "" {
+		date, err = time.Parse(time.DateOnly, query.Date)
+		if err != nil {
+			return entities.PrecisionAuditReport{}, false, ErrInvalidAuditDate
+		}
+	}
+
+	return h.repo.Report(ctx, date)
+}
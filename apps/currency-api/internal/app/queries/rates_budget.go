@@ -0,0 +1,105 @@
+package queries
+
+import (
+	"fmt"
+)
+
+const ErrCodeResponseTooLarge = "RESPONSE_TOO_LARGE"
+
+// Upper bounds used to estimate the size of a rates response before it is
+// built. A rate is rendered with up to 16 decimal places plus its integer
+// part, and the envelope carries source_info and replication metadata.
+const (
+	ratePairOverheadBytes = len(`{"from":"","to":"","rate":""},`)
+	rateValueBytes        = 24
+	ratesEnvelopeBytes    = 512
+)
+
+// ResponseBudgetError rejects a rates request whose response would exceed the
+// configured byte budget. MaxCurrencies is how many currencies of the same
+// code length fit, so clients know how far to narrow the request.
+type ResponseBudgetError struct {
+	Code           string
+	Message        string
+	Suggestion     string
+	EstimatedBytes int
+	BudgetBytes    int
+	MaxCurrencies  int
+}
+
+func (e *ResponseBudgetError) Error() string {
+	return e.Message
+}
+
+// estimateRatesResponseBytes returns the worst-case JSON size of the full rate
+// matrix for currencies: every ordered pair, each code appearing n-1 times on
+// either side.
+func estimateRatesResponseBytes(currencies []string) int {
+	n := len(currencies)
+	codeBytes := 0
+	for _, currency := range currencies {
+		codeBytes += len(currency)
+	}
+	return ratesEnvelopeBytes + n*(n-1)*(ratePairOverheadBytes+rateValueBytes) + 2*(n-1)*codeBytes
+}
+
+func checkResponseBudget(currencies []string, budget int) error {
+	if budget <= 0 {
+		return nil
+	}
+
+	estimated := estimateRatesResponseBytes(currencies)
+	if estimated <= budget {
+		return nil
+	}
+
+	maxCurrencies := maxCurrenciesWithinBudget(currencies, budget)
+	pairs := len(currencies) * (len(currencies) - 1)
+
+	err := &ResponseBudgetError{
+		Code:           ErrCodeResponseTooLarge,
+		Message:        fmt.Sprintf("the response for %d currencies (%d rate pairs) is estimated at %s, above the %s budget", len(currencies), pairs, formatBytes(estimated), formatBytes(budget)),
+		EstimatedBytes: estimated,
+		BudgetBytes:    budget,
+		MaxCurrencies:  maxCurrencies,
+	}
+
+	if maxCurrencies >= 2 {
+		err.Suggestion = fmt.Sprintf("Request at most %d currencies per call and split the rest across several requests", maxCurrencies)
+	} else {
+		err.Suggestion = "The response budget is too small for any currency pair; contact the API operator"
+	}
+
+	return err
+}
+
+// maxCurrenciesWithinBudget finds the largest request that fits the budget,
+// assuming the average code length of the rejected request.
+func maxCurrenciesWithinBudget(currencies []string, budget int) int {
+	codeBytes := 0
+	for _, currency := range currencies {
+		codeBytes += len(currency)
+	}
+	avgCodeBytes := (codeBytes + len(currencies) - 1) / len(currencies)
+
+	n := 1
+	for {
+		next := n + 1
+		estimated := ratesEnvelopeBytes + next*(next-1)*(ratePairOverheadBytes+rateValueBytes+2*avgCodeBytes)
+		if estimated > budget {
+			return n
+		}
+		n = next
+	}
+}
+
+func formatBytes(bytes int) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
@@ -10,21 +10,57 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	ErrCodeTooFewCurrencies = "TOO_FEW_CURRENCIES"
+	// ErrCodeUpstreamError and ErrCodeRatesUnavailable report failures of the
+	// rates provider rather than of the request.
+	ErrCodeUpstreamError    = "UPSTREAM_ERROR"
+	ErrCodeRatesUnavailable = "RATES_UNAVAILABLE"
+	ErrCodeInternal         = "INTERNAL_ERROR"
+)
+
+// RatesValidationError rejects a rates request the caller has to change,
+// unlike a repositories.UpstreamError, which a retry may get past.
+type RatesValidationError struct {
+	Code     string
+	Message  string
+	Currency string
+}
+
+func (e *RatesValidationError) Error() string {
+	return e.Message
+}
+
 type GetRatesQuery struct {
 	Currencies []string
 }
 
 type GetRatesQueryHandler struct {
-	ratesRepo repositories.RatesRepository
+	ratesRepo      repositories.RatesRepository
+	responseBudget int
 }
 
-func NewGetRatesQueryHandler(ratesRepo repositories.RatesRepository) *GetRatesQueryHandler {
-	return &GetRatesQueryHandler{ratesRepo: ratesRepo}
+type GetRatesQueryOption func(*GetRatesQueryHandler)
+
+// WithResponseBudget rejects requests whose estimated response exceeds bytes
+// before any rates are fetched. Zero disables the check.
+func WithResponseBudget(bytes int) GetRatesQueryOption {
+	return func(h *GetRatesQueryHandler) {
+		h.responseBudget = bytes
+	}
+}
+
+func NewGetRatesQueryHandler(ratesRepo repositories.RatesRepository, opts ...GetRatesQueryOption) *GetRatesQueryHandler {
+	h := &GetRatesQueryHandler{ratesRepo: ratesRepo}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
-func (h *GetRatesQueryHandler) Handle(ctx context.Context, query GetRatesQuery) ([]entities.ExchangeRate, string, error) {
+func (h *GetRatesQueryHandler) Handle(ctx context.Context, query GetRatesQuery) ([]entities.ExchangeRate, repositories.RatesInfo, error) {
 	if len(query.Currencies) < 2 {
-		return nil, "", fmt.Errorf("at least two currencies are required")
+		return nil, repositories.RatesInfo{}, &RatesValidationError{Code: ErrCodeTooFewCurrencies, Message: "at least two currencies are required"}
 	}
 
 	currencies := make([]string, len(query.Currencies))
@@ -32,39 +68,67 @@ func (h *GetRatesQueryHandler) Handle(ctx context.Context, query GetRatesQuery)
 		currencies[i] = strings.ToUpper(strings.TrimSpace(currency))
 	}
 
+	if err := checkResponseBudget(currencies, h.responseBudget); err != nil {
+		return nil, repositories.RatesInfo{}, err
+	}
+
 	rates, info, err := h.ratesRepo.GetRates(ctx, currencies)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get rates: %w", err)
+		return nil, repositories.RatesInfo{}, fmt.Errorf("failed to get rates: %w", err)
 	}
 
 	for _, currency := range currencies {
 		if _, exists := rates[currency]; !exists {
-			return nil, "", fmt.Errorf("currency '%s' is not supported or not available", currency)
+			return nil, repositories.RatesInfo{}, &RatesValidationError{
+				Code:     ErrCodeUnsupportedCurrency,
+				Message:  fmt.Sprintf("currency '%s' is not supported or not available", currency),
+				Currency: currency,
+			}
 		}
 	}
 
+	overrides := h.rateOverrides()
 	result := make([]entities.ExchangeRate, 0, len(currencies)*(len(currencies)-1))
 
 	for _, from := range currencies {
 		for _, to := range currencies {
-			if from != to {
-				rate, err := h.calculateRate(rates, from, to)
-				if err != nil {
-					return nil, "", fmt.Errorf("failed to calculate rate from %s to %s: %w", from, to, err)
-				}
+			if from == to {
+				continue
+			}
 
+			if rate, pinned := overrides[from+"-"+to]; pinned {
 				result = append(result, entities.ExchangeRate{
-					From: from,
-					To:   to,
-					Rate: rate,
+					From:   from,
+					To:     to,
+					Rate:   rate,
+					Mocked: true,
 				})
+				continue
+			}
+
+			rate, err := h.calculateRate(rates, from, to)
+			if err != nil {
+				return nil, repositories.RatesInfo{}, fmt.Errorf("failed to calculate rate from %s to %s: %w", from, to, err)
 			}
+
+			result = append(result, entities.ExchangeRate{
+				From: from,
+				To:   to,
+				Rate: rate,
+			})
 		}
 	}
 
 	return result, info, nil
 }
 
+func (h *GetRatesQueryHandler) rateOverrides() map[string]decimal.Decimal {
+	if overrides, ok := h.ratesRepo.(repositories.RateOverrides); ok {
+		return overrides.RateOverrides()
+	}
+	return nil
+}
+
 func (h *GetRatesQueryHandler) calculateRate(rates map[string]float64, from, to string) (decimal.Decimal, error) {
 	fromRate, fromExists := rates[from]
 	toRate, toExists := rates[to]
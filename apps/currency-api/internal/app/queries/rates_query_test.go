@@ -2,9 +2,12 @@ package queries
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/ajs/currency-api/internal/domain/repositories"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,9 +38,9 @@ func (r *TestRatesRepository) SetInfo(info string) {
 	r.info = info
 }
 
-func (r *TestRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, string, error) {
+func (r *TestRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
 	if r.err != nil {
-		return nil, "", r.err
+		return nil, repositories.RatesInfo{}, r.err
 	}
 
 	result := make(map[string]float64)
@@ -47,7 +50,7 @@ func (r *TestRatesRepository) GetRates(ctx context.Context, currencies []string)
 		}
 	}
 
-	return result, r.info, nil
+	return result, repositories.RatesInfo{Source: r.info}, nil
 }
 
 func TestGetRatesQueryHandler_Handle_WithDecimal(t *testing.T) {
@@ -191,7 +194,7 @@ func TestGetRatesQueryHandler_Handle_WithDecimal(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.expectedInfo, info)
+			assert.Equal(t, tt.expectedInfo, info.Source)
 			assert.Len(t, rates, len(tt.expectedRates))
 
 			rateMap := make(map[string]decimal.Decimal)
@@ -322,3 +325,115 @@ func TestGetRatesQueryHandler_CalculateRate_WithDecimal(t *testing.T) {
 		})
 	}
 }
+
+type TestOverridingRatesRepository struct {
+	*TestRatesRepository
+	overrides map[string]decimal.Decimal
+}
+
+func (r *TestOverridingRatesRepository) RateOverrides() map[string]decimal.Decimal {
+	return r.overrides
+}
+
+func TestGetRatesQueryHandler_Handle_WithRateOverrides(t *testing.T) {
+	repo := &TestOverridingRatesRepository{
+		TestRatesRepository: NewTestRatesRepository(),
+		overrides: map[string]decimal.Decimal{
+			"USD-EUR": decimal.RequireFromString("1.10"),
+		},
+	}
+	repo.SetRates(map[string]float64{"USD": 1.0, "EUR": 0.85, "GBP": 0.73})
+
+	handler := NewGetRatesQueryHandler(repo)
+
+	rates, _, err := handler.Handle(context.Background(), GetRatesQuery{Currencies: []string{"USD", "EUR", "GBP"}})
+
+	require.NoError(t, err)
+	require.Len(t, rates, 6)
+
+	for _, rate := range rates {
+		if rate.From == "USD" && rate.To == "EUR" {
+			assert.True(t, rate.Mocked)
+			assert.True(t, decimal.RequireFromString("1.10").Equal(rate.Rate))
+			continue
+		}
+		assert.False(t, rate.Mocked, "%s-%s should not be mocked", rate.From, rate.To)
+	}
+}
+
+func TestGetRatesQueryHandler_Handle_ResponseBudget(t *testing.T) {
+	repo := NewTestRatesRepository()
+	repo.SetRates(map[string]float64{"USD": 1, "EUR": 0.923456, "GBP": 0.791234, "JPY": 149.123456, "PLN": 3.987654})
+	currencies := []string{"USD", "EUR", "GBP", "JPY", "PLN"}
+
+	unlimited := NewGetRatesQueryHandler(repo)
+	rates, _, err := unlimited.Handle(context.Background(), GetRatesQuery{Currencies: currencies})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(rates)
+	require.NoError(t, err)
+	estimated := estimateRatesResponseBytes(currencies)
+	assert.GreaterOrEqual(t, estimated, len(body), "the estimate must be an upper bound")
+
+	t.Run("within budget", func(t *testing.T) {
+		handler := NewGetRatesQueryHandler(repo, WithResponseBudget(estimated))
+
+		rates, _, err := handler.Handle(context.Background(), GetRatesQuery{Currencies: currencies})
+
+		require.NoError(t, err)
+		assert.Len(t, rates, 20)
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		handler := NewGetRatesQueryHandler(repo, WithResponseBudget(1024))
+
+		_, _, err := handler.Handle(context.Background(), GetRatesQuery{Currencies: currencies})
+
+		var budgetErr *ResponseBudgetError
+		require.ErrorAs(t, err, &budgetErr)
+		assert.Equal(t, ErrCodeResponseTooLarge, budgetErr.Code)
+		assert.Equal(t, estimated, budgetErr.EstimatedBytes)
+		assert.Equal(t, 1024, budgetErr.BudgetBytes)
+		assert.Equal(t, 3, budgetErr.MaxCurrencies)
+		assert.Equal(t, "the response for 5 currencies (20 rate pairs) is estimated at 1.7 KiB, above the 1.0 KiB budget", budgetErr.Message)
+		assert.Equal(t, "Request at most 3 currencies per call and split the rest across several requests", budgetErr.Suggestion)
+
+		fitting := NewGetRatesQueryHandler(repo, WithResponseBudget(1024))
+		_, _, err = fitting.Handle(context.Background(), GetRatesQuery{Currencies: currencies[:budgetErr.MaxCurrencies]})
+		assert.NoError(t, err, "the suggested number of currencies must fit the budget")
+	})
+
+	t.Run("budget below a single pair", func(t *testing.T) {
+		handler := NewGetRatesQueryHandler(repo, WithResponseBudget(600))
+
+		_, _, err := handler.Handle(context.Background(), GetRatesQuery{Currencies: currencies[:2]})
+
+		var budgetErr *ResponseBudgetError
+		require.ErrorAs(t, err, &budgetErr)
+		assert.Equal(t, 1, budgetErr.MaxCurrencies)
+		assert.Contains(t, budgetErr.Suggestion, "contact the API operator")
+	})
+}
+
+func TestGetRatesQueryHandler_Handle_ErrorKinds(t *testing.T) {
+	repo := NewTestRatesRepository()
+	repo.SetRates(map[string]float64{"USD": 1.0})
+	handler := NewGetRatesQueryHandler(repo)
+
+	_, _, err := handler.Handle(context.Background(), GetRatesQuery{Currencies: []string{"USD"}})
+	var validationErr *RatesValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, ErrCodeTooFewCurrencies, validationErr.Code)
+
+	_, _, err = handler.Handle(context.Background(), GetRatesQuery{Currencies: []string{"usd", " xyz"}})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, ErrCodeUnsupportedCurrency, validationErr.Code)
+	assert.Equal(t, "XYZ", validationErr.Currency)
+
+	repo.SetError(&repositories.UpstreamError{Source: "test", Unavailable: true, Retryable: true, Err: errors.New("circuit open")})
+	_, _, err = handler.Handle(context.Background(), GetRatesQuery{Currencies: []string{"USD", "EUR"}})
+	var upstreamErr *repositories.UpstreamError
+	require.ErrorAs(t, err, &upstreamErr, "upstream failures keep their kind through the query")
+	assert.True(t, upstreamErr.Unavailable)
+	assert.False(t, errors.As(err, &validationErr))
+}
@@ -0,0 +1,94 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TemplateFormatText = "text"
+	TemplateFormatJSON = "json"
+)
+
+// ValidateNotificationTemplateQuery checks a tenant template by rendering it
+// against sample data of Kind. Format "json" additionally requires the output
+// to be a valid JSON document, as webhook payloads must be.
+type ValidateNotificationTemplateQuery struct {
+	Kind     string
+	Format   string
+	Template string
+}
+
+type ValidateNotificationTemplateQueryHandler struct {
+	templates services.NotificationTemplates
+}
+
+func NewValidateNotificationTemplateQueryHandler(templates services.NotificationTemplates) *ValidateNotificationTemplateQueryHandler {
+	return &ValidateNotificationTemplateQueryHandler{templates: templates}
+}
+
+// Handle returns the rendered preview. Problems with the template are
+// *services.TemplateError; any other error means the query itself is invalid.
+func (h *ValidateNotificationTemplateQueryHandler) Handle(ctx context.Context, query ValidateNotificationTemplateQuery) (string, error) {
+	sample, err := sampleNotification(entities.NotificationKind(query.Kind))
+	if err != nil {
+		return "", err
+	}
+
+	format := query.Format
+	if format == "" {
+		format = TemplateFormatText
+	}
+	if format != TemplateFormatText && format != TemplateFormatJSON {
+		return "", fmt.Errorf("format must be one of: %s, %s", TemplateFormatText, TemplateFormatJSON)
+	}
+
+	if query.Template == "" {
+		return "", fmt.Errorf("template is required")
+	}
+
+	preview, err := h.templates.Render(query.Template, sample)
+	if err != nil {
+		return "", err
+	}
+
+	if format == TemplateFormatJSON && !json.Valid([]byte(preview)) {
+		return "", &services.TemplateError{Message: "rendered output is not valid JSON; use the json function to quote values"}
+	}
+
+	return preview, nil
+}
+
+func sampleNotification(kind entities.NotificationKind) (entities.Notification, error) {
+	sample := entities.Notification{Kind: kind, Tenant: "acme"}
+
+	switch kind {
+	case entities.NotificationRateAlert:
+		sample.Alert = &entities.RateAlert{
+			From:        "WBTC",
+			To:          "USDT",
+			Direction:   entities.AlertAbove,
+			Threshold:   decimal.NewFromInt(57000),
+			Rate:        decimal.RequireFromString("57094.31"),
+			TriggeredAt: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		}
+	case entities.NotificationDailyDigest:
+		sample.Digest = &entities.RateDigest{
+			Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			Rates: []entities.ExchangeRate{
+				{From: "WBTC", To: "USDT", Rate: decimal.RequireFromString("57094.31")},
+				{From: "GATE", To: "USDT", Rate: decimal.RequireFromString("6.876877")},
+			},
+		}
+	default:
+		return entities.Notification{}, fmt.Errorf("kind must be one of: %s, %s", entities.NotificationRateAlert, entities.NotificationDailyDigest)
+	}
+
+	return sample, nil
+}
@@ -0,0 +1,64 @@
+package queries
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTemplates struct {
+	output string
+	err    error
+	last   entities.Notification
+}
+
+func (s *stubTemplates) Render(source string, notification entities.Notification) (string, error) {
+	s.last = notification
+	return s.output, s.err
+}
+
+func TestValidateNotificationTemplateQueryHandler_Handle(t *testing.T) {
+	templates := &stubTemplates{output: `{"pair":"WBTC/USDT"}`}
+	handler := NewValidateNotificationTemplateQueryHandler(templates)
+
+	preview, err := handler.Handle(context.Background(), ValidateNotificationTemplateQuery{Kind: "rate_alert", Format: "json", Template: "{{json .Pair}}"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"pair":"WBTC/USDT"}`, preview)
+	require.NotNil(t, templates.last.Alert)
+	assert.Equal(t, "WBTC", templates.last.Alert.From)
+
+	_, err = handler.Handle(context.Background(), ValidateNotificationTemplateQuery{Kind: "daily_digest", Template: "x"})
+	require.NoError(t, err)
+	require.NotNil(t, templates.last.Digest)
+
+	templates.output = "WBTC/USDT"
+	_, err = handler.Handle(context.Background(), ValidateNotificationTemplateQuery{Kind: "rate_alert", Format: "json", Template: "{{.Pair}}"})
+	var templateErr *services.TemplateError
+	require.ErrorAs(t, err, &templateErr)
+	assert.Equal(t, "rendered output is not valid JSON; use the json function to quote values", templateErr.Message)
+}
+
+func TestValidateNotificationTemplateQueryHandler_Handle_InvalidQuery(t *testing.T) {
+	handler := NewValidateNotificationTemplateQueryHandler(&stubTemplates{})
+
+	tests := []struct {
+		name          string
+		query         ValidateNotificationTemplateQuery
+		expectedError string
+	}{
+		{name: "unknown kind", query: ValidateNotificationTemplateQuery{Kind: "weekly", Template: "x"}, expectedError: "kind must be one of: rate_alert, daily_digest"},
+		{name: "unknown format", query: ValidateNotificationTemplateQuery{Kind: "rate_alert", Format: "xml", Template: "x"}, expectedError: "format must be one of: text, json"},
+		{name: "empty template", query: ValidateNotificationTemplateQuery{Kind: "rate_alert"}, expectedError: "template is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Handle(context.Background(), tt.query)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
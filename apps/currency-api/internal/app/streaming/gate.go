@@ -0,0 +1,121 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const anonymousClient = "anonymous"
+
+// Close reasons reported to clients and in metrics when a stream ends.
+const (
+	CloseClientDisconnected = "client_disconnected"
+	CloseServerShutdown     = "server_shutdown"
+	CloseSubscribeFailed    = "subscribe_failed"
+)
+
+var (
+	ErrUnauthorized       = errors.New("a valid API key is required for streaming")
+	ErrTooManyPairs       = errors.New("too many currency pairs for one connection")
+	ErrTooManyConnections = errors.New("too many concurrent streams for this client")
+)
+
+// Limits bounds what one client may hold open. Zero disables a limit.
+type Limits struct {
+	MaxPairs                int
+	MaxConnectionsPerClient int
+}
+
+// Gate admits stream connections. With API keys configured every connection
+// must present one and the connection limit applies per key; without keys
+// streams are open and the limit applies per remote address.
+type Gate struct {
+	clients map[string]string
+	limits  Limits
+
+	mu   sync.Mutex
+	open map[string]int
+
+	connections *prometheus.GaugeVec
+	rejections  *prometheus.CounterVec
+	closes      *prometheus.CounterVec
+}
+
+// NewGate takes the accepted API keys mapped to the client names used in
+// metrics and logs.
+func NewGate(clients map[string]string, limits Limits, registerer prometheus.Registerer) *Gate {
+	g := &Gate{
+		clients: clients,
+		limits:  limits,
+		open:    make(map[string]int),
+		connections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "currency_api_stream_connections",
+			Help: "Open rate streams per client.",
+		}, []string{"client"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_stream_rejections_total",
+			Help: "Rate stream connections refused by reason (unauthorized, too_many_pairs, too_many_connections).",
+		}, []string{"reason"}),
+		closes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_stream_closes_total",
+			Help: "Rate streams ended by close reason.",
+		}, []string{"reason"}),
+	}
+	registerer.MustRegister(g.connections, g.rejections, g.closes)
+	return g
+}
+
+// Connection is an admitted stream. Close must be called exactly once.
+type Connection struct {
+	gate   *Gate
+	key    string
+	Client string
+}
+
+// Admit checks a new stream for pairs currency pairs. remoteAddr identifies
+// the caller when no API keys are configured.
+func (g *Gate) Admit(apiKey, remoteAddr string, pairs int) (*Connection, error) {
+	client, key := anonymousClient, remoteAddr
+	if len(g.clients) > 0 {
+		name, known := g.clients[apiKey]
+		if apiKey == "" || !known {
+			g.rejections.WithLabelValues("unauthorized").Inc()
+			return nil, ErrUnauthorized
+		}
+		client, key = name, apiKey
+	}
+
+	if g.limits.MaxPairs > 0 && pairs > g.limits.MaxPairs {
+		g.rejections.WithLabelValues("too_many_pairs").Inc()
+		return nil, fmt.Errorf("%w: %d requested, at most %d allowed", ErrTooManyPairs, pairs, g.limits.MaxPairs)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.limits.MaxConnectionsPerClient > 0 && g.open[key] >= g.limits.MaxConnectionsPerClient {
+		g.rejections.WithLabelValues("too_many_connections").Inc()
+		return nil, fmt.Errorf("%w: at most %d allowed", ErrTooManyConnections, g.limits.MaxConnectionsPerClient)
+	}
+	g.open[key]++
+	g.connections.WithLabelValues(client).Inc()
+
+	return &Connection{gate: g, key: key, Client: client}, nil
+}
+
+// Close releases the connection's slot and records why the stream ended.
+func (c *Connection) Close(reason string) {
+	g := c.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.open[c.key]--
+	if g.open[c.key] <= 0 {
+		delete(g.open, c.key)
+	}
+	g.connections.WithLabelValues(c.Client).Dec()
+	g.closes.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGate_Admit_APIKeys(t *testing.T) {
+	gate := NewGate(map[string]string{"k-acme": "acme"}, Limits{MaxPairs: 6, MaxConnectionsPerClient: 1}, prometheus.NewRegistry())
+
+	_, err := gate.Admit("", "10.0.0.1", 2)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = gate.Admit("k-other", "10.0.0.1", 2)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = gate.Admit("k-acme", "10.0.0.1", 12)
+	assert.ErrorIs(t, err, ErrTooManyPairs)
+
+	conn, err := gate.Admit("k-acme", "10.0.0.1", 6)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", conn.Client)
+
+	_, err = gate.Admit("k-acme", "10.0.0.2", 2)
+	assert.ErrorIs(t, err, ErrTooManyConnections, "the limit applies per key, not per address")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(gate.connections.WithLabelValues("acme")))
+	conn.Close(CloseClientDisconnected)
+	assert.Equal(t, 0.0, testutil.ToFloat64(gate.connections.WithLabelValues("acme")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(gate.closes.WithLabelValues(CloseClientDisconnected)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(gate.rejections.WithLabelValues("unauthorized")))
+
+	_, err = gate.Admit("k-acme", "10.0.0.2", 2)
+	assert.NoError(t, err, "closing frees the slot")
+}
+
+func TestGate_Admit_Anonymous(t *testing.T) {
+	gate := NewGate(nil, Limits{MaxConnectionsPerClient: 1}, prometheus.NewRegistry())
+
+	conn, err := gate.Admit("", "10.0.0.1", 90)
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", conn.Client)
+
+	_, err = gate.Admit("", "10.0.0.1", 2)
+	assert.ErrorIs(t, err, ErrTooManyConnections)
+	_, err = gate.Admit("", "10.0.0.2", 2)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,295 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultRefreshInterval = 5 * time.Second
+	defaultCoalesce        = 500 * time.Millisecond
+)
+
+// Update is one message to a subscriber. A snapshot carries every pair of the
+// subscription; otherwise Rates only holds the pairs that changed since the
+// previous message.
+type Update struct {
+	Snapshot bool
+	Rates    []entities.ExchangeRate
+	At       time.Time
+}
+
+type subscriber struct {
+	currencies []string
+	out        chan Update
+
+	mu    sync.Mutex
+	dirty map[string]bool
+	// resync is set for new subscribers and for subscribers that fell behind;
+	// their next message is a snapshot instead of the missed deltas.
+	resync bool
+}
+
+// Hub refreshes the rates of all subscribed currencies and fans changes out
+// to subscribers. Updates are coalesced to at most one message per pair per
+// coalesce interval; a subscriber whose buffer is full misses deltas and is
+// sent a snapshot once it catches up, so slow clients never block the hub.
+type Hub struct {
+	repo            repositories.RatesRepository
+	refreshInterval time.Duration
+	coalesce        time.Duration
+	buffer          int
+	logger          logger.Logger
+	now             func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	rates       map[string]float64
+	subscribers map[*subscriber]struct{}
+}
+
+func NewHub(repo repositories.RatesRepository, refreshInterval, coalesce time.Duration, buffer int, log logger.Logger) *Hub {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	if coalesce <= 0 {
+		coalesce = defaultCoalesce
+	}
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Hub{
+		repo:            repo,
+		refreshInterval: refreshInterval,
+		coalesce:        coalesce,
+		buffer:          buffer,
+		logger:          log,
+		now:             time.Now,
+		ctx:             ctx,
+		cancel:          cancel,
+		rates:           make(map[string]float64),
+		subscribers:     make(map[*subscriber]struct{}),
+	}
+}
+
+// Run refreshes the subscribed currencies every refresh interval until ctx
+// is done.
+func (h *Hub) Run(ctx context.Context) error {
+	ticker := time.NewTicker(h.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			h.refreshRates()
+		}
+	}
+}
+
+// Close ends all subscriptions.
+func (h *Hub) Close() error {
+	h.cancel()
+	h.wg.Wait()
+	return nil
+}
+
+// Subscribe streams the rates between the given currencies until ctx is
+// done; the first update is a snapshot. The currencies are fetched once up
+// front, so unknown currencies fail here rather than producing a silent
+// stream.
+func (h *Hub) Subscribe(ctx context.Context, currencies []string) (<-chan Update, error) {
+	if len(currencies) < 2 {
+		return nil, fmt.Errorf("at least two currencies are required")
+	}
+
+	rates, _, err := h.repo.GetRates(ctx, currencies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rates: %w", err)
+	}
+	for _, currency := range currencies {
+		if _, exists := rates[currency]; !exists {
+			return nil, fmt.Errorf("currency '%s' is not supported or not available", currency)
+		}
+	}
+	h.Publish(rates)
+
+	sub := &subscriber{
+		currencies: currencies,
+		out:        make(chan Update, h.buffer),
+		dirty:      make(map[string]bool),
+		resync:     true,
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer close(sub.out)
+		defer h.unsubscribe(sub)
+
+		h.flush(sub)
+
+		ticker := time.NewTicker(h.coalesce)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-h.ctx.Done():
+				return
+			case <-ticker.C:
+				h.flush(sub)
+			}
+		}
+	}()
+
+	return sub.out, nil
+}
+
+// Publish records base rates and marks the affected pairs of every
+// subscriber; they are sent with the subscriber's next flush.
+func (h *Hub) Publish(rates map[string]float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := make(map[string]bool)
+	for currency, rate := range rates {
+		if previous, known := h.rates[currency]; !known || previous != rate {
+			h.rates[currency] = rate
+			changed[currency] = true
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	for sub := range h.subscribers {
+		sub.mu.Lock()
+		for _, currency := range sub.currencies {
+			if changed[currency] {
+				sub.dirty[currency] = true
+			}
+		}
+		sub.mu.Unlock()
+	}
+}
+
+// flush sends the subscriber what changed since its last message. When its
+// buffer is full the update is dropped and a snapshot is owed instead.
+func (h *Hub) flush(sub *subscriber) {
+	sub.mu.Lock()
+	dirty, snapshot := sub.dirty, sub.resync
+	sub.dirty = make(map[string]bool)
+	sub.mu.Unlock()
+
+	if !snapshot && len(dirty) == 0 {
+		return
+	}
+
+	update := Update{Snapshot: snapshot, Rates: h.pairs(sub.currencies, dirty, snapshot), At: h.now().UTC()}
+	if len(update.Rates) == 0 {
+		return
+	}
+
+	select {
+	case sub.out <- update:
+		if snapshot {
+			sub.mu.Lock()
+			sub.resync = false
+			sub.mu.Unlock()
+		}
+	default:
+		sub.mu.Lock()
+		sub.resync = true
+		sub.mu.Unlock()
+	}
+}
+
+// pairs returns the rates between the currencies, limited to pairs touching
+// a dirty currency unless all are requested.
+func (h *Hub) pairs(currencies []string, dirty map[string]bool, all bool) []entities.ExchangeRate {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var result []entities.ExchangeRate
+	for _, from := range currencies {
+		for _, to := range currencies {
+			if from == to || (!all && !dirty[from] && !dirty[to]) {
+				continue
+			}
+
+			fromRate, toRate := h.rates[from], h.rates[to]
+			if fromRate == 0 || toRate == 0 {
+				continue
+			}
+
+			result = append(result, entities.ExchangeRate{
+				From: from,
+				To:   to,
+				Rate: decimal.NewFromFloat(toRate).Div(decimal.NewFromFloat(fromRate)),
+			})
+		}
+	}
+	return result
+}
+
+func (h *Hub) refreshRates() {
+	currencies := h.subscribedCurrencies()
+	if len(currencies) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(h.ctx, h.refreshInterval)
+	defer cancel()
+
+	rates, _, err := h.repo.GetRates(ctx, currencies)
+	if err != nil {
+		h.logger.Warn("Failed to refresh streamed rates", "error", err, "currencies", len(currencies))
+		return
+	}
+	h.Publish(rates)
+}
+
+func (h *Hub) subscribedCurrencies() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	unique := make(map[string]bool)
+	for sub := range h.subscribers {
+		for _, currency := range sub.currencies {
+			unique[currency] = true
+		}
+	}
+
+	result := make([]string, 0, len(unique))
+	for currency := range unique {
+		result = append(result, currency)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+}
@@ -0,0 +1,157 @@
+package streaming
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRatesRepository struct {
+	rates map[string]float64
+}
+
+func (r stubRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	result := make(map[string]float64)
+	for _, currency := range currencies {
+		if rate, ok := r.rates[currency]; ok {
+			result[currency] = rate
+		}
+	}
+	return result, repositories.RatesInfo{Source: "stub"}, nil
+}
+
+// newTestHub never flushes on its own, so tests drive flush explicitly.
+func newTestHub(t *testing.T, buffer int) *Hub {
+	t.Helper()
+	hub := NewHub(stubRatesRepository{rates: map[string]float64{"USD": 1, "EUR": 0.5, "GBP": 0.25}}, time.Hour, time.Hour, buffer, logger.New("error"))
+	t.Cleanup(func() { _ = hub.Close() })
+	return hub
+}
+
+func onlySubscriber(t *testing.T, hub *Hub) *subscriber {
+	t.Helper()
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	require.Len(t, hub.subscribers, 1)
+	for sub := range hub.subscribers {
+		return sub
+	}
+	return nil
+}
+
+func receive(t *testing.T, updates <-chan Update) Update {
+	t.Helper()
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update received")
+		return Update{}
+	}
+}
+
+func pairs(update Update) map[string]string {
+	result := make(map[string]string)
+	for _, rate := range update.Rates {
+		result[rate.From+"-"+rate.To] = rate.Rate.String()
+	}
+	return result
+}
+
+func TestHub_Subscribe_StartsWithSnapshot(t *testing.T) {
+	hub := newTestHub(t, 4)
+
+	updates, err := hub.Subscribe(context.Background(), []string{"USD", "EUR", "GBP"})
+	require.NoError(t, err)
+
+	update := receive(t, updates)
+	assert.True(t, update.Snapshot)
+	assert.Len(t, update.Rates, 6)
+	assert.Equal(t, "0.5", pairs(update)["USD-EUR"])
+}
+
+func TestHub_Subscribe_UnknownCurrency(t *testing.T) {
+	hub := newTestHub(t, 4)
+
+	_, err := hub.Subscribe(context.Background(), []string{"USD", "XYZ"})
+
+	assert.EqualError(t, err, "currency 'XYZ' is not supported or not available")
+}
+
+func TestHub_Flush_CoalescesUpdates(t *testing.T) {
+	hub := newTestHub(t, 4)
+	updates, err := hub.Subscribe(context.Background(), []string{"USD", "EUR", "GBP"})
+	require.NoError(t, err)
+	receive(t, updates)
+	sub := onlySubscriber(t, hub)
+
+	hub.Publish(map[string]float64{"EUR": 0.6})
+	hub.Publish(map[string]float64{"EUR": 0.8, "JPY": 150})
+	hub.flush(sub)
+
+	update := receive(t, updates)
+	assert.False(t, update.Snapshot)
+	assert.Equal(t, map[string]string{
+		"USD-EUR": "0.8",
+		"EUR-USD": "1.25",
+		"EUR-GBP": "0.3125",
+		"GBP-EUR": "3.2",
+	}, pairs(update), "only the latest value of the changed pairs is sent")
+
+	hub.flush(sub)
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected update without changes: %+v", update)
+	default:
+	}
+}
+
+func TestHub_Flush_SlowSubscriberGetsSnapshot(t *testing.T) {
+	hub := newTestHub(t, 1)
+	updates, err := hub.Subscribe(context.Background(), []string{"USD", "EUR", "GBP"})
+	require.NoError(t, err)
+	sub := onlySubscriber(t, hub)
+	require.Eventually(t, func() bool { return len(updates) == 1 }, 5*time.Second, time.Millisecond)
+
+	hub.Publish(map[string]float64{"EUR": 0.6})
+	hub.flush(sub)
+	hub.Publish(map[string]float64{"GBP": 0.2})
+	hub.flush(sub)
+
+	assert.True(t, receive(t, updates).Snapshot)
+
+	hub.flush(sub)
+	update := receive(t, updates)
+	assert.True(t, update.Snapshot, "dropped deltas are replaced by a snapshot")
+	assert.Equal(t, "0.6", pairs(update)["USD-EUR"])
+	assert.Equal(t, "0.2", pairs(update)["USD-GBP"])
+
+	hub.Publish(map[string]float64{"GBP": 0.4})
+	hub.flush(sub)
+	assert.False(t, receive(t, updates).Snapshot, "deltas resume once the subscriber caught up")
+}
+
+func TestHub_Subscribe_EndsWithContext(t *testing.T) {
+	hub := newTestHub(t, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	updates, err := hub.Subscribe(ctx, []string{"USD", "EUR"})
+	require.NoError(t, err)
+	receive(t, updates)
+	cancel()
+
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-updates:
+			return !ok
+		default:
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+	assert.Empty(t, hub.subscribedCurrencies())
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// BulkConversionRow is one line of an uploaded conversion file. Date is empty
+// when the row did not specify one.
+type BulkConversionRow struct {
+	Line   int
+	From   string
+	To     string
+	Amount string
+	Date   string
+}
+
+// BulkConversionResult is the outcome of one row; Error is set instead of
+// Amount when the row could not be converted.
+type BulkConversionResult struct {
+	Row         BulkConversionRow
+	Amount      decimal.Decimal
+	PricingRule string
+	Error       string
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "github.com/shopspring/decimal"
+
+// ChainConstraints are the practical limits of an asset on one network: the
+// decimals its token uses there and the smallest amount worth transferring
+// given typical network fees.
+type ChainConstraints struct {
+	Network       string
+	DecimalPlaces int32
+	DustLimit     decimal.Decimal
+}
+
+// ChainPreview shows how a conversion result lands on the target network.
+type ChainPreview struct {
+	Network        string          `json:"network"`
+	DecimalPlaces  int32           `json:"decimal_places"`
+	DustLimit      decimal.Decimal `json:"dust_limit"`
+	BelowDustLimit bool            `json:"below_dust_limit"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+type ChangelogEntry struct {
+	Version     string `json:"version" example:"2.1.0"`
+	Date        string `json:"date" example:"2026-10-16"`
+	Type        string `json:"type" example:"added"`
+	Endpoint    string `json:"endpoint,omitempty" example:"GET /api/v1/changelog"`
+	Description string `json:"description" example:"Machine-readable changelog of API contract changes"`
+	Breaking    bool   `json:"breaking"`
+}
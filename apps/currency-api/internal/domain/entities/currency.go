@@ -13,15 +13,35 @@ type Currency struct {
 }
 
 type ExchangeRate struct {
-	From string          `json:"from"`
-	To   string          `json:"to"`
-	Rate decimal.Decimal `json:"rate"`
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Rate   decimal.Decimal `json:"rate"`
+	Mocked bool            `json:"mocked,omitempty"`
 }
 
 type ExchangeResult struct {
-	From   string          `json:"from"`
-	To     string          `json:"to"`
-	Amount decimal.Decimal `json:"amount"`
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Amount      decimal.Decimal   `json:"amount"`
+	PricingRule string            `json:"pricing_rule,omitempty"`
+	Chain       *ChainPreview     `json:"chain,omitempty"`
+	Fee         *AppliedFee       `json:"fee,omitempty"`
+	Warning     *PrecisionWarning `json:"warning,omitempty"`
+}
+
+// WarningAmountUnderflow marks a result that rounds to zero in the target
+// currency's precision.
+const WarningAmountUnderflow = "AMOUNT_UNDERFLOW"
+
+// PrecisionWarning explains a result lost to rounding. UnroundedAmount is the
+// conversion before rounding and MinMeaningfulAmount the smallest source
+// amount that yields a non-zero result.
+type PrecisionWarning struct {
+	Code                string          `json:"code" example:"AMOUNT_UNDERFLOW"`
+	Message             string          `json:"message"`
+	UnroundedAmount     decimal.Decimal `json:"unrounded_amount"`
+	DecimalPlaces       int32           `json:"decimal_places"`
+	MinMeaningfulAmount decimal.Decimal `json:"min_meaningful_amount"`
 }
 
 var CryptoCurrencies = map[string]Currency{
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// DeliveryAttempt is one try at sending a notification. ResponseCode is the
+// HTTP or SMTP status when the receiving side answered.
+type DeliveryAttempt struct {
+	Attempt      int
+	At           time.Time
+	Latency      time.Duration
+	ResponseCode int
+	Error        string
+}
+
+// Delivery records how a notification for a subscription was sent,
+// including every retry. RedeliveryOf is set on manual redeliveries.
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	Notification   Notification
+	Status         DeliveryStatus
+	Error          string
+	Attempts       []DeliveryAttempt
+	RedeliveryOf   string
+	CreatedAt      time.Time
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultFeeKey is the Fees entry used for pairs without their own fee.
+const DefaultFeeKey = "*"
+
+// basisPoints is the number of basis points in a whole.
+var basisPoints = decimal.NewFromInt(10000)
+
+// FeeSchedule is one version of the fee and amount limit table applied to
+// conversions. A version takes effect at EffectiveFrom, so changes can be
+// staged ahead of time; the newest version already in effect wins.
+type FeeSchedule struct {
+	Version       int64                  `json:"version"`
+	EffectiveFrom time.Time              `json:"effective_from"`
+	PublishedAt   time.Time              `json:"published_at"`
+	PublishedBy   string                 `json:"published_by"`
+	Fees          map[string]Fee         `json:"fees"`
+	Limits        map[string]AmountLimit `json:"limits"`
+}
+
+// Fee is charged on the converted amount, in the target currency: a spread
+// in basis points of the amount plus a flat fee.
+type Fee struct {
+	SpreadBps decimal.Decimal `json:"spread_bps"`
+	Flat      decimal.Decimal `json:"flat"`
+}
+
+// Charge returns the fee on amount.
+func (f Fee) Charge(amount decimal.Decimal) decimal.Decimal {
+	return amount.Mul(f.SpreadBps).Div(basisPoints).Add(f.Flat)
+}
+
+// AmountLimit bounds the amount of a conversion, in the source currency.
+// A zero Max means no upper bound.
+type AmountLimit struct {
+	Min decimal.Decimal `json:"min"`
+	Max decimal.Decimal `json:"max"`
+}
+
+// FeeFor returns the fee of the pair, falling back to the default entry.
+func (s FeeSchedule) FeeFor(from, to string) (Fee, bool) {
+	if fee, ok := s.Fees[from+"-"+to]; ok {
+		return fee, true
+	}
+	fee, ok := s.Fees[DefaultFeeKey]
+	return fee, ok
+}
+
+// LimitFor returns the amount limit of a source currency.
+func (s FeeSchedule) LimitFor(currency string) (AmountLimit, bool) {
+	limit, ok := s.Limits[currency]
+	return limit, ok
+}
+
+// AppliedFee reports the fee charged on a conversion.
+type AppliedFee struct {
+	ScheduleVersion int64           `json:"schedule_version"`
+	SpreadBps       decimal.Decimal `json:"spread_bps"`
+	Amount          decimal.Decimal `json:"amount"`
+}
+
+// FeeAuditEntry records who published a fee schedule version, why, and what
+// it changed compared with the version before it. Actor is the holder of the
+// credential the version was published with; ReportedActor is whoever the
+// request claimed to act for, which nothing verifies.
+type FeeAuditEntry struct {
+	Version       int64     `json:"version"`
+	Actor         string    `json:"actor"`
+	ReportedActor string    `json:"reported_actor,omitempty"`
+	Reason        string    `json:"reason"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	RecordedAt    time.Time `json:"recorded_at"`
+	Changes       []string  `json:"changes"`
+}
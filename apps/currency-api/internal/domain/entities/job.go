@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Finished reports whether the job has reached a terminal state.
+func (s JobStatus) Finished() bool {
+	return s == JobCompleted || s == JobFailed || s == JobCancelled
+}
+
+// Job is a long-running unit of work such as a bulk conversion. Progress is
+// counted in items of the job's type, e.g. uploaded rows.
+type Job struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	Tenant          string    `json:"tenant,omitempty"`
+	Status          JobStatus `json:"status"`
+	Total           int       `json:"total"`
+	Processed       int       `json:"processed"`
+	Failed          int       `json:"failed"`
+	Error           string    `json:"error,omitempty"`
+	CancelRequested bool      `json:"cancel_requested,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	StartedAt       time.Time `json:"started_at,omitempty"`
+	CompletedAt     time.Time `json:"completed_at,omitempty"`
+	HasResult       bool      `json:"has_result,omitempty"`
+}
+
+// JobResult is the downloadable output of a completed job.
+type JobResult struct {
+	ContentType string `json:"content_type"`
+	Filename    string `json:"filename"`
+	Data        []byte `json:"data"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+type MarketStatus string
+
+const (
+	MarketOpen   MarketStatus = "open"
+	MarketClosed MarketStatus = "closed"
+)
+
+// MarketState describes whether the fiat market is trading and when rates
+// are next expected to change. NextOpen is zero while the market is open.
+type MarketState struct {
+	Status       MarketStatus
+	NextUpdateAt time.Time
+	NextOpen     time.Time
+}
+
+// IsFiatOnly reports whether none of the currencies is a supported crypto token.
+func IsFiatOnly(currencies []string) bool {
+	for _, currency := range currencies {
+		if _, exists := CryptoCurrencies[currency]; exists {
+			return false
+		}
+	}
+	return len(currencies) > 0
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type NotificationKind string
+
+const (
+	NotificationRateAlert   NotificationKind = "rate_alert"
+	NotificationDailyDigest NotificationKind = "daily_digest"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail    NotificationChannel = "email"
+	ChannelSlack    NotificationChannel = "slack"
+	ChannelTelegram NotificationChannel = "telegram"
+)
+
+const (
+	AlertAbove = "above"
+	AlertBelow = "below"
+)
+
+// Notification is a message for the subscribers of one tenant. Recipients
+// are addresses on Channel: email addresses, Slack incoming webhook URLs or
+// Telegram chat IDs. Exactly one of Alert and Digest is set, matching Kind.
+// Template, when set, replaces the channel's default message format.
+// SubscriptionID links deliveries to the subscription that asked for them.
+type Notification struct {
+	SubscriptionID string
+	Kind           NotificationKind
+	Channel        NotificationChannel
+	Tenant         string
+	Recipients     []string
+	Template       string
+	Alert          *RateAlert
+	Digest         *RateDigest
+}
+
+// RateAlert reports that a pair crossed a subscriber's threshold. Direction
+// is "above" or "below".
+type RateAlert struct {
+	From        string
+	To          string
+	Direction   string
+	Threshold   decimal.Decimal
+	Rate        decimal.Decimal
+	TriggeredAt time.Time
+}
+
+// RateDigest summarises the rates of a tenant's pairs for one day.
+type RateDigest struct {
+	Date  time.Time
+	Rates []ExchangeRate
+}
+
+// AlertSubscription asks for a notification on Channel when the From/To rate
+// crosses Threshold in Direction, optionally formatted by the tenant's own
+// Template.
+type AlertSubscription struct {
+	ID         string
+	Tenant     string
+	From       string
+	To         string
+	Direction  string
+	Threshold  decimal.Decimal
+	Channel    NotificationChannel
+	Recipients []string
+	Template   string
+}
+
+// Triggered reports whether rate is past the subscription's threshold.
+func (s AlertSubscription) Triggered(rate decimal.Decimal) bool {
+	if s.Direction == AlertBelow {
+		return rate.LessThan(s.Threshold)
+	}
+	return rate.GreaterThan(s.Threshold)
+}
+
+// Alert builds the notification for a triggering rate.
+func (s AlertSubscription) Alert(rate decimal.Decimal, at time.Time) Notification {
+	return Notification{
+		SubscriptionID: s.ID,
+		Kind:           NotificationRateAlert,
+		Channel:        s.Channel,
+		Tenant:         s.Tenant,
+		Recipients:     s.Recipients,
+		Template:       s.Template,
+		Alert: &RateAlert{
+			From:        s.From,
+			To:          s.To,
+			Direction:   s.Direction,
+			Threshold:   s.Threshold,
+			Rate:        rate,
+			TriggeredAt: at,
+		},
+	}
+}
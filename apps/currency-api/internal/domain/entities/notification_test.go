@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertSubscription(t *testing.T) {
+	subscription := AlertSubscription{
+		ID:         "sub-1",
+		Tenant:     "acme",
+		From:       "WBTC",
+		To:         "USDT",
+		Direction:  AlertAbove,
+		Threshold:  decimal.NewFromInt(57000),
+		Channel:    ChannelSlack,
+		Recipients: []string{"https://hooks.slack.com/services/T000/B000/XXXX"},
+	}
+
+	assert.True(t, subscription.Triggered(decimal.NewFromInt(57001)))
+	assert.False(t, subscription.Triggered(decimal.NewFromInt(57000)))
+
+	below := subscription
+	below.Direction = AlertBelow
+	assert.True(t, below.Triggered(decimal.NewFromInt(56999)))
+	assert.False(t, below.Triggered(decimal.NewFromInt(57001)))
+
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	notification := subscription.Alert(decimal.NewFromInt(57001), at)
+	assert.Equal(t, NotificationRateAlert, notification.Kind)
+	assert.Equal(t, ChannelSlack, notification.Channel)
+	assert.Equal(t, subscription.Recipients, notification.Recipients)
+	require.NotNil(t, notification.Alert)
+	assert.Equal(t, "57000", notification.Alert.Threshold.String())
+	assert.Equal(t, at, notification.Alert.TriggeredAt)
+}
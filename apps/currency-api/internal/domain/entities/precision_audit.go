@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConversionRecord is a served conversion together with everything it was
+// priced from, so it can be recomputed after the currency table or the fee
+// schedule has moved on.
+type ConversionRecord struct {
+	At     time.Time       `json:"at"`
+	From   Currency        `json:"from"`
+	To     Currency        `json:"to"`
+	Amount decimal.Decimal `json:"amount"`
+	// Fee is the fee of the pair in the schedule that was in effect.
+	Fee                *Fee  `json:"fee,omitempty"`
+	FeeScheduleVersion int64 `json:"fee_schedule_version,omitempty"`
+	// PricingRule names the rule that adjusted the result. Rules are tenant
+	// expressions that may have changed since, so for such conversions the
+	// audit checks PricingInput, the unrounded amount handed to the rule.
+	PricingRule  string           `json:"pricing_rule,omitempty"`
+	PricingInput *decimal.Decimal `json:"pricing_input,omitempty"`
+	// DecimalPlaces is the precision of Result, below To.DecimalPlaces when
+	// the network it is delivered on cut it.
+	DecimalPlaces int32           `json:"decimal_places"`
+	Result        decimal.Decimal `json:"result"`
+}
+
+// PrecisionAuditReport is the outcome of recomputing the sampled conversions
+// of one UTC day. Examples holds the largest discrepancies, biggest first.
+// Priced counts the checked conversions a pricing rule adjusted; only the
+// conversion up to the rule is checked for those.
+type PrecisionAuditReport struct {
+	Date          time.Time              `json:"date"`
+	RanAt         time.Time              `json:"ran_at"`
+	Sampled       int                    `json:"sampled"`
+	Checked       int                    `json:"checked"`
+	Skipped       int                    `json:"skipped"`
+	Priced        int                    `json:"priced"`
+	Discrepancies int                    `json:"discrepancies"`
+	MaxULPs       decimal.Decimal        `json:"max_ulps"`
+	Examples      []PrecisionDiscrepancy `json:"examples"`
+}
+
+// PrecisionDiscrepancy is a conversion whose served result is more than one
+// unit in its last place away from the exact result. ULPs is the distance
+// in those units.
+type PrecisionDiscrepancy struct {
+	Conversion ConversionRecord `json:"conversion"`
+	Expected   decimal.Decimal  `json:"expected"`
+	ULPs       decimal.Decimal  `json:"ulps"`
+}
+
+// Drifted reports whether any conversion was off by more than one unit in
+// the last place.
+func (r PrecisionAuditReport) Drifted() bool {
+	return r.Discrepancies > 0
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// ProviderReport compares the configured rate providers over one UTC day.
+type ProviderReport struct {
+	Date      time.Time
+	Providers []ProviderStats
+}
+
+// ProviderStats is one provider's line in a ProviderReport. MeanDeviation is
+// the mean absolute relative difference between the provider's rates and the
+// median of all providers that quoted the same currency in the same hour;
+// DeviationSamples is zero when no other provider could be compared.
+type ProviderStats struct {
+	Provider         string
+	Primary          bool
+	Requests         int
+	Failures         int
+	Availability     float64
+	LatencyP50       time.Duration
+	LatencyP95       time.Duration
+	MeanDeviation    float64
+	DeviationSamples int
+	// QuotaUsed counts requests from the first of the month through the
+	// report date, the unit paid plans are sold in.
+	QuotaUsed int
+}
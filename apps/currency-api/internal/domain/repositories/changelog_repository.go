@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+type ChangelogRepository interface {
+	GetEntries(ctx context.Context) ([]entities.ChangelogEntry, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+type DeliveryRepository interface {
+	Save(ctx context.Context, delivery entities.Delivery) error
+	Get(ctx context.Context, subscriptionID, id string) (entities.Delivery, error)
+	// List returns up to limit deliveries of a subscription, newest first.
+	List(ctx context.Context, subscriptionID string, limit int) ([]entities.Delivery, error)
+}
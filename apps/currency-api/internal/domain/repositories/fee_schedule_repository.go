@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// FeeScheduleRepository stores fee schedule versions and their audit trail
+// and tells every instance when a new version is published.
+type FeeScheduleRepository interface {
+	// Publish stores schedule as the next version, records audit for it and
+	// notifies the watchers. The stored version is returned.
+	Publish(ctx context.Context, schedule entities.FeeSchedule, audit entities.FeeAuditEntry) (entities.FeeSchedule, error)
+	// Versions returns the retained versions, oldest first.
+	Versions(ctx context.Context) ([]entities.FeeSchedule, error)
+	// Audit returns up to limit audit entries, newest first.
+	Audit(ctx context.Context, limit int) ([]entities.FeeAuditEntry, error)
+	// Changes signals published versions until ctx is done. A burst of
+	// publishes may arrive as a single signal.
+	Changes(ctx context.Context) (<-chan int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type JobRepository interface {
+	Save(ctx context.Context, job entities.Job) error
+	Get(ctx context.Context, id string) (entities.Job, error)
+	SaveResult(ctx context.Context, id string, result entities.JobResult) error
+	GetResult(ctx context.Context, id string) (entities.JobResult, error)
+}
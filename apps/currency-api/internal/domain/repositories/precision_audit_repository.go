@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// PrecisionAuditRepository keeps the sampled conversions of each UTC day and
+// the precision audit reports made from them.
+type PrecisionAuditRepository interface {
+	// AddConversion stores a sampled conversion under the UTC day of its At.
+	AddConversion(ctx context.Context, record entities.ConversionRecord) error
+	// Conversions returns the sampled conversions of the UTC day containing
+	// date, oldest first.
+	Conversions(ctx context.Context, date time.Time) ([]entities.ConversionRecord, error)
+	// SaveReport stores report, replacing an earlier report of its day.
+	SaveReport(ctx context.Context, report entities.PrecisionAuditReport) error
+	// Report returns the report of the UTC day containing date; ok is false
+	// when that day has not been audited.
+	Report(ctx context.Context, date time.Time) (report entities.PrecisionAuditReport, ok bool, err error)
+}
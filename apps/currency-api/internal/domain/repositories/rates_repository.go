@@ -1,7 +1,73 @@
 package repositories
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type RatesRepository interface {
-	GetRates(ctx context.Context, currencies []string) (map[string]float64, string, error)
+	GetRates(ctx context.Context, currencies []string) (map[string]float64, RatesInfo, error)
+}
+
+// RatesInfo describes the rates returned by one GetRates call. Source is a
+// human-readable note on where they came from; PublishedAt is when the oldest
+// of them was published by the primary region, zero unless they were read
+// from a replica. StaleFetchedAt is the oldest fetch among rates served past
+// their TTL because the upstream failed, zero if all of them are fresh.
+type RatesInfo struct {
+	Source         string
+	PublishedAt    time.Time
+	StaleFetchedAt time.Time
+}
+
+// ReplicationLag returns how old the oldest replicated rate is at now.
+func (i RatesInfo) ReplicationLag(now time.Time) (time.Duration, bool) {
+	if i.PublishedAt.IsZero() {
+		return 0, false
+	}
+	return now.Sub(i.PublishedAt), true
+}
+
+// UnsupportedCurrencyError reports a currency the rates provider does not
+// know. Cached is set when the answer came from the negative cache rather
+// than the provider; CachedUntil is when the provider will be asked again,
+// zero if the answer is not cached.
+type UnsupportedCurrencyError struct {
+	Currency    string
+	Cached      bool
+	CachedUntil time.Time
+}
+
+func (e *UnsupportedCurrencyError) Error() string {
+	return fmt.Sprintf("currency '%s' is not supported by the exchange rates provider", e.Currency)
+}
+
+// UpstreamError reports that rates could not be fetched from the provider or
+// store behind a repository, as opposed to a problem with the request.
+// Unavailable is set when the upstream was not asked at all, e.g. while the
+// circuit breaker is open; Retryable when the same request may succeed later,
+// with RetryAfter as a hint, zero if unknown.
+type UpstreamError struct {
+	Source      string
+	Unavailable bool
+	Retryable   bool
+	RetryAfter  time.Duration
+	Err         error
+}
+
+func (e *UpstreamError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// RateOverrides is implemented by rates repositories that pin specific pairs
+// to fixed rates. Keys are "FROM-TO" pairs.
+type RateOverrides interface {
+	RateOverrides() map[string]decimal.Decimal
 }
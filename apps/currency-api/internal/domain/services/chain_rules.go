@@ -0,0 +1,10 @@
+package services
+
+import "github.com/ajs/currency-api/internal/domain/entities"
+
+// ChainRules knows how assets behave on the networks they can be delivered
+// to. Networks lists, sorted, the networks an asset is available on.
+type ChainRules interface {
+	Constraints(network, asset string) (entities.ChainConstraints, bool)
+	Networks(asset string) []string
+}
@@ -0,0 +1,11 @@
+package services
+
+import "github.com/ajs/currency-api/internal/domain/entities"
+
+// ConversionRecorder keeps a sample of served conversions for the precision
+// audit. Sample decides whether the current conversion is kept, so the
+// record is only built for those that are; Record must not block.
+type ConversionRecorder interface {
+	Sample() bool
+	Record(record entities.ConversionRecord)
+}
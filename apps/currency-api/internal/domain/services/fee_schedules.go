@@ -0,0 +1,9 @@
+package services
+
+import "github.com/ajs/currency-api/internal/domain/entities"
+
+// FeeSchedules provides the fee schedule version in effect; ok is false
+// while no version has been published.
+type FeeSchedules interface {
+	Current() (schedule entities.FeeSchedule, ok bool)
+}
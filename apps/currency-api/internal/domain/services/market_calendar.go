@@ -0,0 +1,12 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// MarketCalendar knows the trading hours of the fiat market.
+type MarketCalendar interface {
+	State(at time.Time) entities.MarketState
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// Notifier delivers notifications over one channel such as email.
+type Notifier interface {
+	Notify(ctx context.Context, notification entities.Notification) error
+}
+
+// NotificationTemplates renders tenant-supplied message templates. Problems
+// with the template itself are reported as *TemplateError.
+type NotificationTemplates interface {
+	Render(source string, notification entities.Notification) (string, error)
+}
+
+// TemplateError locates a problem in a notification template; Line is zero
+// when the problem has no position.
+type TemplateError struct {
+	Line    int
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
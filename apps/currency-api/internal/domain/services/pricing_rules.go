@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+type PricingInput struct {
+	Tenant        string
+	From          string
+	To            string
+	Amount        decimal.Decimal
+	Rate          decimal.Decimal
+	DecimalPlaces int32
+}
+
+var (
+	// ErrPricingRuleFailed wraps a rule that could not be evaluated, e.g. it
+	// timed out or divided by zero.
+	ErrPricingRuleFailed = errors.New("pricing rule evaluation failed")
+	// ErrInvalidPricedAmount wraps a rule that evaluated to something other
+	// than a non-negative amount.
+	ErrInvalidPricedAmount = errors.New("produced an invalid amount")
+)
+
+// PricingRules adjusts a computed conversion (markups, custom rounding).
+// rule names the rule that was applied and is empty when none matched.
+// Failures of the rule itself wrap ErrPricingRuleFailed or
+// ErrInvalidPricedAmount.
+type PricingRules interface {
+	Apply(ctx context.Context, input PricingInput) (amount decimal.Decimal, rule string, err error)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// ProviderReporter compares rate providers over the UTC day containing date.
+type ProviderReporter interface {
+	Report(date time.Time) entities.ProviderReport
+}
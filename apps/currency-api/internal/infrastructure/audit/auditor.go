@@ -0,0 +1,242 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// examplesKept bounds the discrepancies a report lists.
+	examplesKept = 20
+	// ulpPlaces is the precision discrepancies are measured to.
+	ulpPlaces = 2
+)
+
+var (
+	oneULP      = decimal.New(1, 0)
+	basisPoints = big.NewRat(10000, 1)
+)
+
+// Auditor recomputes the samples of the previous UTC day once a day and
+// stores the report. A report with discrepancies is logged as an error and
+// shows up in the metrics, which is what alerting keys on.
+type Auditor struct {
+	repo repositories.PrecisionAuditRepository
+	at   time.Duration
+	log  logger.Logger
+	now  func() time.Time
+
+	discrepancies prometheus.Gauge
+	checked       prometheus.Gauge
+	maxULPs       prometheus.Gauge
+	lastRun       prometheus.Gauge
+}
+
+// NewAuditor runs the audit at the offset at after midnight UTC.
+func NewAuditor(repo repositories.PrecisionAuditRepository, at time.Duration, registerer prometheus.Registerer, log logger.Logger) *Auditor {
+	a := &Auditor{
+		repo: repo,
+		at:   at,
+		log:  log,
+		now:  time.Now,
+		discrepancies: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "currency_api_precision_audit_discrepancies",
+			Help: "Conversions off by more than one unit in the last place in the latest precision audit.",
+		}),
+		checked: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "currency_api_precision_audit_checked_conversions",
+			Help: "Conversions recomputed by the latest precision audit.",
+		}),
+		maxULPs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "currency_api_precision_audit_max_ulps",
+			Help: "Largest distance between a served and an exact result in the latest precision audit, in units in the last place.",
+		}),
+		lastRun: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "currency_api_precision_audit_last_run_timestamp_seconds",
+			Help: "Unix time the latest precision audit finished.",
+		}),
+	}
+	registerer.MustRegister(a.discrepancies, a.checked, a.maxULPs, a.lastRun)
+	return a
+}
+
+// Run audits the previous day at the configured time every day until ctx is
+// done. A day whose audit time passed while no instance was running the job
+// is audited right away.
+func (a *Auditor) Run(ctx context.Context) error {
+	now := a.now().UTC()
+	if today := a.runAt(now); !today.After(now) {
+		a.auditIfMissing(ctx, today.AddDate(0, 0, -1))
+	}
+
+	for {
+		now := a.now().UTC()
+		next := a.runAt(now)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		if _, err := a.Audit(ctx, next.AddDate(0, 0, -1)); err != nil {
+			a.log.Error("Precision audit failed", err, "date", next.AddDate(0, 0, -1).Format(time.DateOnly))
+		}
+	}
+}
+
+// runAt is the audit time on the UTC day of now.
+func (a *Auditor) runAt(now time.Time) time.Time {
+	return now.Truncate(24 * time.Hour).Add(a.at)
+}
+
+func (a *Auditor) auditIfMissing(ctx context.Context, date time.Time) {
+	if _, ok, err := a.repo.Report(ctx, date); err != nil || ok {
+		return
+	}
+	if _, err := a.Audit(ctx, date); err != nil {
+		a.log.Error("Precision audit failed", err, "date", date.Format(time.DateOnly))
+	}
+}
+
+// Audit recomputes the samples of the UTC day containing date and stores
+// the report.
+func (a *Auditor) Audit(ctx context.Context, date time.Time) (entities.PrecisionAuditReport, error) {
+	day := date.UTC().Truncate(24 * time.Hour)
+	records, err := a.repo.Conversions(ctx, day)
+	if err != nil {
+		return entities.PrecisionAuditReport{}, fmt.Errorf("failed to load the conversion samples of %s: %w", day.Format(time.DateOnly), err)
+	}
+
+	report := entities.PrecisionAuditReport{
+		Date:     day,
+		RanAt:    a.now().UTC(),
+		Sampled:  len(records),
+		MaxULPs:  decimal.RequireFromString("0"),
+		Examples: []entities.PrecisionDiscrepancy{},
+	}
+	for _, record := range records {
+		exact, ok := Recompute(record)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+		report.Checked++
+		if record.PricingRule != "" {
+			report.Priced++
+		}
+
+		ulps := distanceInULPs(record, exact)
+		report.MaxULPs = decimal.Max(report.MaxULPs, ulps)
+		if ulps.GreaterThan(oneULP) {
+			report.Discrepancies++
+			report.Examples = append(report.Examples, entities.PrecisionDiscrepancy{
+				Conversion: record,
+				Expected:   expectedResult(record, exact),
+				ULPs:       ulps,
+			})
+		}
+	}
+	sort.SliceStable(report.Examples, func(i, j int) bool {
+		return report.Examples[i].ULPs.GreaterThan(report.Examples[j].ULPs)
+	})
+	report.Examples = report.Examples[:min(len(report.Examples), examplesKept)]
+
+	if err := a.repo.SaveReport(ctx, report); err != nil {
+		return report, err
+	}
+	a.observe(report)
+	return report, nil
+}
+
+func (a *Auditor) observe(report entities.PrecisionAuditReport) {
+	a.discrepancies.Set(float64(report.Discrepancies))
+	a.checked.Set(float64(report.Checked))
+	a.maxULPs.Set(report.MaxULPs.InexactFloat64())
+	a.lastRun.Set(float64(report.RanAt.Unix()))
+
+	attrs := []any{
+		"date", report.Date.Format(time.DateOnly),
+		"checked", report.Checked,
+		"skipped", report.Skipped,
+		"priced", report.Priced,
+		"discrepancies", report.Discrepancies,
+		"max_ulps", report.MaxULPs.String(),
+	}
+	if !report.Drifted() {
+		a.log.Info("Precision audit passed", attrs...)
+		return
+	}
+
+	worst := report.Examples[0]
+	attrs = append(attrs,
+		"worst_pair", worst.Conversion.From.Code+"-"+worst.Conversion.To.Code,
+		"worst_amount", worst.Conversion.Amount.String(),
+		"worst_result", worst.Conversion.Result.String(),
+		"worst_expected", worst.Expected.String(),
+	)
+	a.log.Error("Precision drift detected", fmt.Errorf("%d of %d conversions are off by more than one unit in the last place", report.Discrepancies, report.Checked), attrs...)
+}
+
+// Recompute works a conversion out again from the record alone, with exact
+// rational arithmetic, and returns the result before rounding. For a
+// conversion a pricing rule adjusted it returns the amount handed to the
+// rule. ok is false for conversions that cannot be recomputed, such as
+// priced ones sampled without that amount.
+func Recompute(record entities.ConversionRecord) (exact *big.Rat, ok bool) {
+	if (record.PricingRule != "" && record.PricingInput == nil) || record.To.RateToUSD.Sign() <= 0 {
+		return nil, false
+	}
+
+	result := new(big.Rat).Mul(record.Amount.Rat(), record.From.RateToUSD.Rat())
+	result.Quo(result, record.To.RateToUSD.Rat())
+	if record.Fee != nil {
+		charged := new(big.Rat).Mul(result, record.Fee.SpreadBps.Rat())
+		charged.Quo(charged, basisPoints)
+		charged.Add(charged, record.Fee.Flat.Rat())
+		result.Sub(result, charged)
+	}
+	return result, true
+}
+
+// distanceInULPs is how far the served result is from the exact one, in
+// units of its last decimal place. Correct rounding stays within half a
+// unit and truncation for a network within one. For a priced conversion it
+// measures the amount handed to the rule in units of the target currency.
+func distanceInULPs(record entities.ConversionRecord, exact *big.Rat) decimal.Decimal {
+	served, places := record.Result, record.DecimalPlaces
+	if record.PricingRule != "" {
+		served, places = *record.PricingInput, record.To.DecimalPlaces
+	}
+
+	distance := new(big.Rat).Sub(served.Rat(), exact)
+	distance.Abs(distance)
+	distance.Mul(distance, decimal.New(1, places).Rat())
+	return decimal.NewFromBigRat(distance, ulpPlaces)
+}
+
+// expectedResult rounds the exact result the way the exchange query does:
+// to the target currency's precision, then down to the network's. For a
+// priced conversion it is the amount the rule should have been handed, to
+// the target currency's precision.
+func expectedResult(record entities.ConversionRecord, exact *big.Rat) decimal.Decimal {
+	expected := decimal.NewFromBigRat(exact, record.To.DecimalPlaces)
+	if record.PricingRule == "" && record.DecimalPlaces < record.To.DecimalPlaces {
+		expected = expected.Truncate(record.DecimalPlaces)
+	}
+	return expected
+}
@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/chain"
+	infrarepositories "github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var auditDate = time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+// directRecorder stores every conversion synchronously.
+type directRecorder struct {
+	repo repositories.PrecisionAuditRepository
+}
+
+func (r directRecorder) Sample() bool { return true }
+
+func (r directRecorder) Record(record entities.ConversionRecord) {
+	record.At = auditDate.Add(12 * time.Hour)
+	_ = r.repo.AddConversion(context.Background(), record)
+}
+
+// markup prices every conversion with a 1% markup.
+type markup struct{}
+
+func (markup) Apply(_ context.Context, input services.PricingInput) (decimal.Decimal, string, error) {
+	return input.Amount.Mul(decimal.RequireFromString("1.01")), "tenant:acme", nil
+}
+
+type fixedFeeSchedule entities.FeeSchedule
+
+func (s fixedFeeSchedule) Current() (entities.FeeSchedule, bool) {
+	return entities.FeeSchedule(s), true
+}
+
+func newTestAuditor(repo repositories.PrecisionAuditRepository) (*Auditor, *prometheus.Registry) {
+	registry := prometheus.NewRegistry()
+	auditor := NewAuditor(repo, 2*time.Hour, registry, logger.New("error"))
+	auditor.now = func() time.Time { return auditDate.Add(26 * time.Hour) }
+	return auditor, registry
+}
+
+// Replays every pair through the exchange query, with fees and on networks
+// that cut precision. Conversions into 18-decimal tokens are divided to
+// decimal.DivisionPrecision places and drift; the audit has to catch those
+// and nothing else.
+func TestAuditor_ReplaysTheExchangeQuery(t *testing.T) {
+	repo := infrarepositories.NewMemoryPrecisionAuditRepository(100000)
+	handler := queries.NewExchangeQueryHandler(
+		queries.WithChainRules(chain.DefaultRules()),
+		queries.WithFeeSchedules(fixedFeeSchedule{
+			Version: 1,
+			Fees: map[string]entities.Fee{
+				"*":          {SpreadBps: decimal.RequireFromString("25"), Flat: decimal.RequireFromString("0")},
+				"WBTC-USDT":  {SpreadBps: decimal.RequireFromString("40"), Flat: decimal.RequireFromString("1.5")},
+				"USDT-FLOKI": {SpreadBps: decimal.RequireFromString("12.5"), Flat: decimal.RequireFromString("0.000000000000000001")},
+			},
+		}),
+		queries.WithConversionRecorder(directRecorder{repo: repo}),
+	)
+	plain := queries.NewExchangeQueryHandler(queries.WithConversionRecorder(directRecorder{repo: repo}))
+	priced := queries.NewExchangeQueryHandler(queries.WithPricingRules(markup{}), queries.WithConversionRecorder(directRecorder{repo: repo}))
+
+	amounts := []string{"1", "1.5", "0.00012345", "250000", "12345.678901", "0.1", "99.99", "1000000000", "0.000000000000000001", "7e-9"}
+	networks := []string{"", "ethereum", "bsc", "polygon"}
+	attempted := 0
+	for from := range entities.CryptoCurrencies {
+		for to := range entities.CryptoCurrencies {
+			for _, amount := range amounts {
+				for _, network := range networks {
+					for _, h := range []*queries.ExchangeQueryHandler{handler, plain, priced} {
+						if _, err := h.Handle(context.Background(), queries.ExchangeQuery{From: from, To: to, Amount: amount, Network: network}); err == nil {
+							attempted++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	auditor, _ := newTestAuditor(repo)
+	report, err := auditor.Audit(context.Background(), auditDate)
+
+	require.NoError(t, err)
+	assert.Greater(t, attempted, 500)
+	assert.Equal(t, attempted, report.Checked)
+	assert.Positive(t, report.Priced)
+	assert.Positive(t, report.Discrepancies)
+	for _, example := range report.Examples {
+		assert.Equal(t, int32(18), example.Conversion.To.DecimalPlaces, "%s-%s", example.Conversion.From.Code, example.Conversion.To.Code)
+	}
+}
+
+func TestAuditor_FlagsDrift(t *testing.T) {
+	usdt, wbtc := entities.CryptoCurrencies["USDT"], entities.CryptoCurrencies["WBTC"]
+	at := auditDate.Add(9 * time.Hour)
+	records := []entities.ConversionRecord{
+		// 1 WBTC at 57037.22 / 0.999 is 57094.31431431... USDT.
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57094.314314")},
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57094.314317")},
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57094.3143")},
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57100"), PricingRule: "vip"},
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57100"), PricingRule: "vip", PricingInput: decimalPtr("57094.314314314314314314")},
+		{At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6, Result: decimal.RequireFromString("57100"), PricingRule: "vip", PricingInput: decimalPtr("57094.31432")},
+		{
+			At: at, From: wbtc, To: usdt, Amount: decimal.RequireFromString("1"), DecimalPlaces: 6,
+			Fee:    &entities.Fee{SpreadBps: decimal.RequireFromString("40"), Flat: decimal.RequireFromString("1.5")},
+			Result: decimal.RequireFromString("56864.437057"),
+		},
+	}
+
+	repo := infrarepositories.NewMemoryPrecisionAuditRepository(100)
+	for _, record := range records {
+		require.NoError(t, repo.AddConversion(context.Background(), record))
+	}
+	auditor, registry := newTestAuditor(repo)
+
+	report, err := auditor.Audit(context.Background(), auditDate.Add(15*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, auditDate, report.Date)
+	assert.Equal(t, 7, report.Sampled)
+	assert.Equal(t, 6, report.Checked)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 2, report.Priced)
+	assert.Equal(t, 3, report.Discrepancies)
+	assert.True(t, report.Drifted())
+	assert.Equal(t, "14.31", report.MaxULPs.String())
+	require.Len(t, report.Examples, 3)
+	assert.Equal(t, "14.31", report.Examples[0].ULPs.String())
+	assert.Equal(t, "57094.314314", report.Examples[0].Expected.String())
+	assert.Equal(t, "5.69", report.Examples[1].ULPs.String())
+	assert.Equal(t, "vip", report.Examples[1].Conversion.PricingRule)
+	assert.Equal(t, "57094.314314", report.Examples[1].Expected.String())
+	assert.Equal(t, "2.69", report.Examples[2].ULPs.String())
+
+	stored, ok, err := repo.Report(context.Background(), auditDate)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, report, stored)
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(auditor.discrepancies))
+	assert.Equal(t, 6.0, testutil.ToFloat64(auditor.checked))
+	assert.Equal(t, 14.31, testutil.ToFloat64(auditor.maxULPs))
+	count, err := testutil.GatherAndCount(registry)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
+
+func TestAuditor_EmptyDay(t *testing.T) {
+	auditor, _ := newTestAuditor(infrarepositories.NewMemoryPrecisionAuditRepository(100))
+
+	report, err := auditor.Audit(context.Background(), auditDate)
+
+	require.NoError(t, err)
+	assert.Zero(t, report.Checked)
+	assert.False(t, report.Drifted())
+	assert.Equal(t, "0", report.MaxULPs.String())
+	assert.NotNil(t, report.Examples)
+}
+
+func TestAuditor_Run_CatchesUpOnAMissedDay(t *testing.T) {
+	repo := infrarepositories.NewMemoryPrecisionAuditRepository(100)
+	auditor, _ := newTestAuditor(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- auditor.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		_, ok, _ := repo.Report(context.Background(), auditDate)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestAuditor_RunAt(t *testing.T) {
+	auditor, _ := newTestAuditor(infrarepositories.NewMemoryPrecisionAuditRepository(100))
+
+	assert.Equal(t, time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC), auditor.runAt(time.Date(2026, 10, 16, 1, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC), auditor.runAt(time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestRecompute(t *testing.T) {
+	usdt, beer := entities.CryptoCurrencies["USDT"], entities.CryptoCurrencies["BEER"]
+
+	exact, ok := Recompute(entities.ConversionRecord{From: usdt, To: beer, Amount: decimal.RequireFromString("1"), DecimalPlaces: 18})
+	require.True(t, ok)
+	assert.Equal(t, "40593.254774481917919545", decimal.NewFromBigRat(exact, 18).String())
+
+	_, ok = Recompute(entities.ConversionRecord{From: usdt, To: beer, Amount: decimal.RequireFromString("1"), PricingRule: "vip"})
+	assert.False(t, ok)
+
+	exact, ok = Recompute(entities.ConversionRecord{From: usdt, To: beer, Amount: decimal.RequireFromString("1"), PricingRule: "vip", PricingInput: decimalPtr("40593.254774481917919545")})
+	require.True(t, ok)
+	assert.Equal(t, "40593.254774481917919545", decimal.NewFromBigRat(exact, 18).String())
+
+	_, ok = Recompute(entities.ConversionRecord{From: usdt, To: entities.Currency{Code: "XXX"}, Amount: decimal.RequireFromString("1")})
+	assert.False(t, ok)
+}
+
+func decimalPtr(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
@@ -0,0 +1,87 @@
+// Package audit recomputes a sample of served conversions with exact
+// arithmetic, so a change to the decimal pipeline that shifts results shows
+// up in a nightly report instead of in a customer's reconciliation.
+package audit
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// samplerQueueSize bounds the samples waiting to be stored; further ones
+	// are dropped rather than slowing conversions down.
+	samplerQueueSize = 1024
+	storeTimeout     = 5 * time.Second
+)
+
+// Sampler keeps a random share of the served conversions and stores them in
+// the background, so sampling never adds a store round trip to a conversion.
+type Sampler struct {
+	repo   repositories.PrecisionAuditRepository
+	rate   float64
+	queue  chan entities.ConversionRecord
+	log    logger.Logger
+	random func() float64
+
+	samples *prometheus.CounterVec
+}
+
+// NewSampler keeps rate, between 0 and 1, of the conversions.
+func NewSampler(repo repositories.PrecisionAuditRepository, rate float64, registerer prometheus.Registerer, log logger.Logger) *Sampler {
+	s := &Sampler{
+		repo:   repo,
+		rate:   rate,
+		queue:  make(chan entities.ConversionRecord, samplerQueueSize),
+		log:    log,
+		random: rand.Float64,
+		samples: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_precision_audit_samples_total",
+			Help: "Sampled conversions by result (stored, dropped, failed).",
+		}, []string{"result"}),
+	}
+	registerer.MustRegister(s.samples)
+	return s
+}
+
+func (s *Sampler) Sample() bool {
+	return s.random() < s.rate
+}
+
+func (s *Sampler) Record(record entities.ConversionRecord) {
+	select {
+	case s.queue <- record:
+	default:
+		s.samples.WithLabelValues("dropped").Inc()
+	}
+}
+
+// Run stores queued samples until ctx is done.
+func (s *Sampler) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case record := <-s.queue:
+			s.store(ctx, record)
+		}
+	}
+}
+
+func (s *Sampler) store(ctx context.Context, record entities.ConversionRecord) {
+	storeCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	if err := s.repo.AddConversion(storeCtx, record); err != nil {
+		s.samples.WithLabelValues("failed").Inc()
+		s.log.Warn("Failed to store conversion sample", "error", err)
+		return
+	}
+	s.samples.WithLabelValues("stored").Inc()
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	infrarepositories "github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampler_Sample(t *testing.T) {
+	repo := infrarepositories.NewMemoryPrecisionAuditRepository(100)
+	sampler := NewSampler(repo, 0.25, prometheus.NewRegistry(), logger.New("error"))
+
+	sampler.random = func() float64 { return 0.1 }
+	assert.True(t, sampler.Sample())
+
+	sampler.random = func() float64 { return 0.25 }
+	assert.False(t, sampler.Sample())
+}
+
+func TestSampler_StoresInTheBackground(t *testing.T) {
+	repo := infrarepositories.NewMemoryPrecisionAuditRepository(100)
+	sampler := NewSampler(repo, 1, prometheus.NewRegistry(), logger.New("error"))
+	record := entities.ConversionRecord{
+		At:            auditDate.Add(time.Hour),
+		From:          entities.CryptoCurrencies["WBTC"],
+		To:            entities.CryptoCurrencies["USDT"],
+		Amount:        decimal.RequireFromString("1"),
+		DecimalPlaces: 6,
+		Result:        decimal.RequireFromString("57094.314314"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- sampler.Run(ctx) }()
+	sampler.Record(record)
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(sampler.samples.WithLabelValues("stored")) == 1
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	stored, err := repo.Conversions(context.Background(), auditDate)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ConversionRecord{record}, stored)
+}
+
+func TestSampler_DropsWhenTheQueueIsFull(t *testing.T) {
+	sampler := NewSampler(infrarepositories.NewMemoryPrecisionAuditRepository(100), 1, prometheus.NewRegistry(), logger.New("error"))
+
+	for i := 0; i < samplerQueueSize+3; i++ {
+		sampler.Record(entities.ConversionRecord{At: auditDate})
+	}
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(sampler.samples.WithLabelValues("dropped")))
+}
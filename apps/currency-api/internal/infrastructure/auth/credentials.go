@@ -0,0 +1,55 @@
+// Package auth identifies callers by the credential they present, so what
+// they do can be attributed to them instead of to what they claim to be.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+)
+
+// Credentials maps secret tokens to the names of their holders.
+type Credentials map[string]string
+
+// Identify returns the holder of token. Every known token is compared in
+// constant time, so the time taken does not reveal how close a guess was.
+func (c Credentials) Identify(token string) (name string, ok bool) {
+	if token == "" {
+		return "", false
+	}
+	for known, holder := range c {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+			name, ok = holder, true
+		}
+	}
+	return name, ok
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the name of the
+// authenticated caller.
+func WithPrincipal(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, principalKey{}, name)
+}
+
+// Principal returns the name of the authenticated caller; ok is false when
+// the request was not authenticated.
+func Principal(ctx context.Context) (name string, ok bool) {
+	name, ok = ctx.Value(principalKey{}).(string)
+	return name, ok
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx carrying the tenant an API key was
+// issued to.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant returns the tenant the request's API key belongs to, or "" when
+// the request presented none.
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
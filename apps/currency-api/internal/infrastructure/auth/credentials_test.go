@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentials_Identify(t *testing.T) {
+	credentials := Credentials{"k-3f9a": "alice", "k-77c1": "bob"}
+
+	tests := map[string]struct {
+		token    string
+		expected string
+		ok       bool
+	}{
+		"known":      {token: "k-77c1", expected: "bob", ok: true},
+		"unknown":    {token: "k-0000"},
+		"prefix":     {token: "k-3f9"},
+		"empty":      {token: ""},
+		"whitespace": {token: " k-3f9a"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			holder, ok := credentials.Identify(tt.token)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, holder)
+		})
+	}
+}
+
+func TestCredentials_Identify_EmptyToken(t *testing.T) {
+	_, ok := Credentials{"": "nobody"}.Identify("")
+	assert.False(t, ok)
+}
+
+func TestPrincipal(t *testing.T) {
+	_, ok := Principal(context.Background())
+	assert.False(t, ok)
+
+	name, ok := Principal(WithPrincipal(context.Background(), "alice"))
+	assert.True(t, ok)
+	assert.Equal(t, "alice", name)
+}
+
+func TestTenant(t *testing.T) {
+	assert.Empty(t, Tenant(context.Background()))
+	assert.Equal(t, "acme", Tenant(WithTenant(context.Background(), "acme")))
+}
@@ -0,0 +1,135 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/infrastructure/configfile"
+	"github.com/shopspring/decimal"
+)
+
+// RulesFile is the on-disk format of CHAIN_RULES_FILE: for every network, the
+// assets that can be delivered there and their constraints.
+type RulesFile struct {
+	Networks map[string]map[string]AssetRule `json:"networks"`
+}
+
+// AssetRule holds the token decimals of an asset on a network and the
+// smallest amount worth transferring there.
+type AssetRule struct {
+	Decimals  int32           `json:"decimals"`
+	DustLimit decimal.Decimal `json:"dust_limit"`
+}
+
+// defaultRulesFile reflects where the wrapped variants are commonly bridged.
+// Dust limits follow typical fees: a WBTC transfer that costs a few dollars of
+// gas on Ethereum is not worth sending below 0.0005 WBTC, while rollups and
+// sidechains make far smaller amounts practical.
+var defaultRulesFile = RulesFile{Networks: map[string]map[string]AssetRule{
+	"ethereum": {
+		"WBTC":  {Decimals: 8, DustLimit: decimal.RequireFromString("0.0005")},
+		"USDT":  {Decimals: 6, DustLimit: decimal.RequireFromString("20")},
+		"GATE":  {Decimals: 18, DustLimit: decimal.RequireFromString("3")},
+		"FLOKI": {Decimals: 9, DustLimit: decimal.RequireFromString("150000")},
+		"BEER":  {Decimals: 18, DustLimit: decimal.RequireFromString("800000")},
+	},
+	"arbitrum": {
+		"WBTC": {Decimals: 8, DustLimit: decimal.RequireFromString("0.00001")},
+		"USDT": {Decimals: 6, DustLimit: decimal.RequireFromString("0.5")},
+	},
+	"polygon": {
+		"WBTC": {Decimals: 8, DustLimit: decimal.RequireFromString("0.000005")},
+		"USDT": {Decimals: 6, DustLimit: decimal.RequireFromString("0.1")},
+	},
+	"bsc": {
+		"USDT":  {Decimals: 18, DustLimit: decimal.RequireFromString("0.1")},
+		"FLOKI": {Decimals: 9, DustLimit: decimal.RequireFromString("1000")},
+	},
+	"tron": {
+		"USDT": {Decimals: 6, DustLimit: decimal.RequireFromString("1")},
+	},
+}}
+
+// Rules answers chain constraint lookups from a fixed table.
+type Rules struct {
+	networks map[string]map[string]entities.ChainConstraints
+}
+
+// DefaultRules returns the built-in table used without CHAIN_RULES_FILE.
+func DefaultRules() *Rules {
+	rules, err := NewRules(defaultRulesFile)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default chain rules: %v", err))
+	}
+	return rules
+}
+
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain rules: %w", err)
+	}
+
+	if err := configfile.Validate(configfile.ChainRules, data); err != nil {
+		return nil, fmt.Errorf("failed to decode chain rules: %w", err)
+	}
+
+	var file RulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode chain rules: %w", err)
+	}
+
+	return NewRules(file)
+}
+
+func NewRules(file RulesFile) (*Rules, error) {
+	rules := &Rules{networks: make(map[string]map[string]entities.ChainConstraints, len(file.Networks))}
+	for network, assets := range file.Networks {
+		network = normalizeNetwork(network)
+		if network == "" {
+			return nil, fmt.Errorf("network name cannot be empty")
+		}
+
+		constraints := make(map[string]entities.ChainConstraints, len(assets))
+		for asset, rule := range assets {
+			if rule.Decimals < 0 || rule.Decimals > 18 {
+				return nil, fmt.Errorf("%s on %s: decimals must be between 0 and 18", asset, network)
+			}
+			if rule.DustLimit.IsNegative() {
+				return nil, fmt.Errorf("%s on %s: dust_limit cannot be negative", asset, network)
+			}
+
+			constraints[strings.ToUpper(asset)] = entities.ChainConstraints{
+				Network:       network,
+				DecimalPlaces: rule.Decimals,
+				DustLimit:     rule.DustLimit,
+			}
+		}
+		rules.networks[network] = constraints
+	}
+	return rules, nil
+}
+
+func (r *Rules) Constraints(network, asset string) (entities.ChainConstraints, bool) {
+	constraints, ok := r.networks[normalizeNetwork(network)][strings.ToUpper(asset)]
+	return constraints, ok
+}
+
+func (r *Rules) Networks(asset string) []string {
+	var networks []string
+	for network, assets := range r.networks {
+		if _, ok := assets[strings.ToUpper(asset)]; ok {
+			networks = append(networks, network)
+		}
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+func normalizeNetwork(network string) string {
+	return strings.ToLower(strings.TrimSpace(network))
+}
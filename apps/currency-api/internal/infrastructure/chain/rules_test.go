@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRules(t *testing.T) {
+	rules := DefaultRules()
+
+	constraints, ok := rules.Constraints(" Ethereum ", "wbtc")
+	require.True(t, ok)
+	assert.Equal(t, "ethereum", constraints.Network)
+	assert.Equal(t, int32(8), constraints.DecimalPlaces)
+	assert.Equal(t, "0.0005", constraints.DustLimit.String())
+
+	arbitrum, ok := rules.Constraints("arbitrum", "WBTC")
+	require.True(t, ok)
+	assert.True(t, arbitrum.DustLimit.LessThan(constraints.DustLimit), "WBTC is practical in smaller amounts on a rollup")
+
+	_, ok = rules.Constraints("tron", "WBTC")
+	assert.False(t, ok)
+	_, ok = rules.Constraints("solana", "USDT")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"arbitrum", "ethereum", "polygon"}, rules.Networks("WBTC"))
+	assert.Empty(t, rules.Networks("XYZ"))
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"networks":{"Base":{"usdt":{"decimals":6,"dust_limit":"0.05"}}}}`), 0o600))
+
+	rules, err := LoadRules(path)
+
+	require.NoError(t, err)
+	constraints, ok := rules.Constraints("base", "USDT")
+	require.True(t, ok)
+	assert.Equal(t, "0.05", constraints.DustLimit.String())
+	_, ok = rules.Constraints("ethereum", "USDT")
+	assert.False(t, ok, "a rules file replaces the built-in table")
+}
+
+func TestNewRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "malformed json", content: `{"networks":`, expectedError: "failed to decode chain rules"},
+		{name: "empty network", content: `{"networks":{" ":{}}}`, expectedError: "network name cannot be empty"},
+		{name: "too many decimals", content: `{"networks":{"base":{"USDT":{"decimals":30}}}}`, expectedError: "decimals must be between 0 and 18"},
+		{name: "negative dust limit", content: `{"networks":{"base":{"USDT":{"decimals":6,"dust_limit":"-1"}}}}`, expectedError: "dust_limit cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "chains.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := LoadRules(path)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
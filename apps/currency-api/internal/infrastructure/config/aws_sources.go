@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+type SSMSource struct {
+	client ssm.GetParametersByPathAPIClient
+	prefix string
+}
+
+func NewSSMSource(client ssm.GetParametersByPathAPIClient, prefix string) *SSMSource {
+	return &SSMSource{client: client, prefix: prefix}
+}
+
+func (s *SSMSource) Name() string {
+	return "ssm:" + s.prefix
+}
+
+func (s *SSMSource) Load(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+
+	paginator := ssm.NewGetParametersByPathPaginator(s.client, &ssm.GetParametersByPathInput{
+		Path:           aws.String(s.prefix),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parameters by path: %w", err)
+		}
+		for _, parameter := range page.Parameters {
+			values[envKey(s.prefix, aws.ToString(parameter.Name))] = aws.ToString(parameter.Value)
+		}
+	}
+
+	return values, nil
+}
+
+type SecretsManagerSource struct {
+	client secretsmanager.BatchGetSecretValueAPIClient
+	prefix string
+}
+
+func NewSecretsManagerSource(client secretsmanager.BatchGetSecretValueAPIClient, prefix string) *SecretsManagerSource {
+	return &SecretsManagerSource{client: client, prefix: prefix}
+}
+
+func (s *SecretsManagerSource) Name() string {
+	return SourceSecretsManagerPrefix + s.prefix
+}
+
+// Load reads every secret whose name starts with the prefix. JSON object
+// secrets contribute each of their keys; plain string secrets are keyed by name.
+func (s *SecretsManagerSource) Load(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+
+	paginator := secretsmanager.NewBatchGetSecretValuePaginator(s.client, &secretsmanager.BatchGetSecretValueInput{
+		Filters: []smtypes.Filter{
+			{Key: smtypes.FilterNameStringTypeName, Values: []string{s.prefix}},
+		},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to batch get secret values: %w", err)
+		}
+		if len(page.Errors) > 0 {
+			return nil, fmt.Errorf("failed to read secret %s: %s",
+				aws.ToString(page.Errors[0].SecretId), aws.ToString(page.Errors[0].Message))
+		}
+		for _, secret := range page.SecretValues {
+			secretString := aws.ToString(secret.SecretString)
+
+			var fields map[string]string
+			if err := json.Unmarshal([]byte(secretString), &fields); err == nil {
+				for key, value := range fields {
+					values[key] = value
+				}
+				continue
+			}
+
+			values[envKey(s.prefix, aws.ToString(secret.Name))] = secretString
+		}
+	}
+
+	return values, nil
+}
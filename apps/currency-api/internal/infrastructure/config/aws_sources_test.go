@@ -0,0 +1,92 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSSMClient struct {
+	pages []*ssm.GetParametersByPathOutput
+	calls int
+}
+
+func (c *fakeSSMClient) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	page := c.pages[c.calls]
+	c.calls++
+	return page, nil
+}
+
+type fakeSecretsClient struct {
+	output *secretsmanager.BatchGetSecretValueOutput
+	input  *secretsmanager.BatchGetSecretValueInput
+}
+
+func (c *fakeSecretsClient) BatchGetSecretValue(ctx context.Context, params *secretsmanager.BatchGetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.BatchGetSecretValueOutput, error) {
+	c.input = params
+	return c.output, nil
+}
+
+func TestSSMSource_Load(t *testing.T) {
+	client := &fakeSSMClient{pages: []*ssm.GetParametersByPathOutput{
+		{
+			Parameters: []ssmtypes.Parameter{
+				{Name: aws.String("/currency-api/prod/OPEN_EXCHANGE_API_KEY"), Value: aws.String("secret")},
+			},
+			NextToken: aws.String("page-2"),
+		},
+		{
+			Parameters: []ssmtypes.Parameter{
+				{Name: aws.String("/currency-api/prod/redis/url"), Value: aws.String("redis://prod:6379")},
+			},
+		},
+	}}
+
+	values, err := NewSSMSource(client, "/currency-api/prod").Load(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+	assert.Equal(t, map[string]string{
+		"OPEN_EXCHANGE_API_KEY": "secret",
+		"REDIS_URL":             "redis://prod:6379",
+	}, values)
+}
+
+func TestSecretsManagerSource_Load(t *testing.T) {
+	client := &fakeSecretsClient{output: &secretsmanager.BatchGetSecretValueOutput{
+		SecretValues: []smtypes.SecretValueEntry{
+			{Name: aws.String("currency-api/prod/api-keys"), SecretString: aws.String(`{"OPEN_EXCHANGE_API_KEY":"from-json"}`)},
+			{Name: aws.String("currency-api/prod/redis-url"), SecretString: aws.String("redis://secret:6379")},
+		},
+	}}
+
+	values, err := NewSecretsManagerSource(client, "currency-api/prod/").Load(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, client.input.Filters, 1)
+	assert.Equal(t, []string{"currency-api/prod/"}, client.input.Filters[0].Values)
+	assert.Equal(t, map[string]string{
+		"OPEN_EXCHANGE_API_KEY": "from-json",
+		"REDIS_URL":             "redis://secret:6379",
+	}, values)
+}
+
+func TestSecretsManagerSource_Load_SecretError(t *testing.T) {
+	client := &fakeSecretsClient{output: &secretsmanager.BatchGetSecretValueOutput{
+		Errors: []smtypes.APIErrorType{
+			{SecretId: aws.String("currency-api/prod/api-keys"), Message: aws.String("access denied")},
+		},
+	}}
+
+	_, err := NewSecretsManagerSource(client, "currency-api/prod/").Load(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
@@ -1,31 +1,496 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	Port                string
 	GinMode             string
 	LogLevel            string
+	LogFormat           string
 	OpenExchangeAPIKey  string
 	OpenExchangeBaseURL string
 	RedisURL            string
 	Environment         string
+	DiscoveryProvider   string
+	DiscoveryAddress    string
+	ServiceName         string
+	ServiceAddress      string
+	ServiceMetadata     map[string]string
+	RateProviderPlugin  string
+	PricingRulesFile    string
+	TenantAPIKeys       map[string]string
+	ChainRulesFile      string
+	PricingRuleTimeout  time.Duration
+	MockOverridesFile   string
+
+	// RateProviderPluginTimeout bounds every call to a provider plugin; a
+	// plugin that does not answer in time is killed and started again.
+	RateProviderPluginTimeout time.Duration
+
+	FreshnessSLOs          map[string]time.Duration
+	FreshnessSLOTarget     float64
+	FreshnessSLOWindow     time.Duration
+	FreshnessCheckInterval time.Duration
+
+	Region          string
+	ReplicaMode     bool
+	SnapshotPublish bool
+
+	LeaderElection bool
+	LeaderLeaseTTL time.Duration
+	InstanceID     string
+
+	JSONEncoder string
+
+	RatesResponseBudget int
+
+	DownloadURLSecret string
+	DownloadURLTTL    time.Duration
+	DownloadBaseURL   string
+
+	BulkMaxRows        int
+	BulkMaxUploadBytes int64
+
+	JobWorkers   int
+	JobQueueSize int
+	JobStore     string
+	JobRetention time.Duration
+
+	FeeStore          string
+	FeeResyncInterval time.Duration
+
+	PrecisionAuditSampleRate float64
+	PrecisionAuditAt         time.Duration
+	PrecisionAuditMaxSamples int
+	PrecisionAuditStore      string
+
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+	SMTPTenantSenders map[string]string
+	TelegramBotToken  string
+
+	NotifyMaxAttempts     int
+	NotifyRetryBackoff    time.Duration
+	NotifyDeliveryLogSize int
+
+	MarketHolidays       []string
+	MarketUpdateInterval time.Duration
+
+	AdminToken  string
+	AdminTokens map[string]string
+
+	StreamRefreshInterval  time.Duration
+	StreamCoalesceInterval time.Duration
+	StreamBufferSize       int
+	StreamAPIKeys          map[string]string
+	StreamMaxPairs         int
+	StreamMaxConnections   int
+
+	RatesCacheMinTTL         time.Duration
+	RatesCacheMaxTTL         time.Duration
+	RatesCacheHighVolatility float64
+	RatesCacheHistorySize    int
+	RatesCacheMaxStale       time.Duration
+	RatesNegativeCacheTTL    time.Duration
+
+	CompareRateProviders      map[string]string
+	ProviderCompareInterval   time.Duration
+	ProviderCompareCurrencies []string
+
+	WorkerRestartBackoff time.Duration
+	WorkerMaxBackoff     time.Duration
+	WorkerMaxRestarts    int
+	WorkerRestartWindow  time.Duration
+
+	ReadPort            string
+	WritePort           string
+	ReadServerTimeouts  ServerTimeouts
+	WriteServerTimeouts ServerTimeouts
+	ReadMiddleware      []MiddlewareSpec
+	WriteMiddleware     []MiddlewareSpec
+
+	settings map[string]Setting
 }
 
 func Load() (*Config, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sources, err := remoteSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure remote config sources: %w", err)
+	}
+
+	return LoadWithSources(ctx, sources...)
+}
+
+// LoadWithSources builds the config from env vars layered over the given
+// sources; later sources override earlier ones and env vars override all.
+func LoadWithSources(ctx context.Context, sources ...Source) (*Config, error) {
+	remote, origins, err := loadSourceValues(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]Setting)
+	get := func(key, defaultValue string) string {
+		value, source := getValue(remote, origins, key, defaultValue)
+		settings[key] = Setting{Key: key, Value: value, Source: source}
+		return value
+	}
+
 	cfg := &Config{
-		Port:                getEnv("PORT", "8080"),
-		GinMode:             getEnv("GIN_MODE", "debug"),
-		LogLevel:            getEnv("LOG_LEVEL", "info"),
-		OpenExchangeAPIKey:  getEnv("OPEN_EXCHANGE_API_KEY", ""),
-		OpenExchangeBaseURL: getEnv("OPEN_EXCHANGE_BASE_URL", "https://openexchangerates.org/api"),
-		RedisURL:            getEnv("REDIS_URL", "redis://localhost:6379"),
-		Environment:         getEnv("ENV", "development"),
+		Port:                get("PORT", "8080"),
+		GinMode:             get("GIN_MODE", "debug"),
+		LogLevel:            get("LOG_LEVEL", "info"),
+		LogFormat:           get("LOG_FORMAT", "auto"),
+		OpenExchangeAPIKey:  get("OPEN_EXCHANGE_API_KEY", ""),
+		OpenExchangeBaseURL: get("OPEN_EXCHANGE_BASE_URL", "https://openexchangerates.org/api"),
+		RedisURL:            get("REDIS_URL", "redis://localhost:6379"),
+		Environment:         get("ENV", "development"),
+		DiscoveryProvider:   get("DISCOVERY_PROVIDER", ""),
+		DiscoveryAddress:    get("DISCOVERY_ADDRESS", ""),
+		ServiceName:         get("SERVICE_NAME", "currency-api"),
+		ServiceAddress:      get("SERVICE_ADDRESS", ""),
+		ServiceMetadata:     parseKeyValues(get("SERVICE_METADATA", "")),
+		RateProviderPlugin:  get("RATE_PROVIDER_PLUGIN", ""),
+		PricingRulesFile:    get("PRICING_RULES_FILE", ""),
+		TenantAPIKeys:       parseKeyValues(get("TENANT_API_KEYS", "")),
+		ChainRulesFile:      get("CHAIN_RULES_FILE", ""),
+		MockOverridesFile:   get("MOCK_OVERRIDES_FILE", ""),
+		Region:              get("REGION", "local"),
+		InstanceID:          get("INSTANCE_ID", ""),
+		JSONEncoder:         get("JSON_ENCODER", "std"),
+		DownloadURLSecret:   get("DOWNLOAD_URL_SECRET", ""),
+		DownloadBaseURL:     get("DOWNLOAD_BASE_URL", ""),
+		JobStore:            get("JOB_STORE", "memory"),
+		FeeStore:            get("FEE_STORE", "memory"),
+		PrecisionAuditStore: get("PRECISION_AUDIT_STORE", "memory"),
+		SMTPHost:            get("SMTP_HOST", ""),
+		SMTPUsername:        get("SMTP_USERNAME", ""),
+		SMTPPassword:        get("SMTP_PASSWORD", ""),
+		SMTPFrom:            get("SMTP_FROM", ""),
+		SMTPTenantSenders:   parseKeyValues(get("SMTP_TENANT_SENDERS", "")),
+		TelegramBotToken:    get("TELEGRAM_BOT_TOKEN", ""),
+		AdminToken:          get("ADMIN_TOKEN", ""),
+		AdminTokens:         parseKeyValues(get("ADMIN_TOKENS", "")),
+		StreamAPIKeys:       parseKeyValues(get("STREAM_API_KEYS", "")),
+	}
+
+	pricingRuleTimeout, err := time.ParseDuration(get("PRICING_RULE_TIMEOUT", "50ms"))
+	if err != nil {
+		return nil, fmt.Errorf("PRICING_RULE_TIMEOUT must be a valid duration: %w", err)
+	}
+	cfg.PricingRuleTimeout = pricingRuleTimeout
+
+	pluginTimeout, err := time.ParseDuration(get("RATE_PROVIDER_PLUGIN_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("RATE_PROVIDER_PLUGIN_TIMEOUT must be a valid duration: %w", err)
+	}
+	cfg.RateProviderPluginTimeout = pluginTimeout
+
+	freshnessSLOs, err := parseDurations(get("FRESHNESS_SLOS", "fiat=1h,crypto=1m"))
+	if err != nil {
+		return nil, fmt.Errorf("FRESHNESS_SLOS must be class=duration pairs: %w", err)
+	}
+	cfg.FreshnessSLOs = freshnessSLOs
+
+	freshnessSLOTarget, err := strconv.ParseFloat(get("FRESHNESS_SLO_TARGET", "0.99"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("FRESHNESS_SLO_TARGET must be a number: %w", err)
+	}
+	cfg.FreshnessSLOTarget = freshnessSLOTarget
+
+	freshnessSLOWindow, err := time.ParseDuration(get("FRESHNESS_SLO_WINDOW", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("FRESHNESS_SLO_WINDOW must be a valid duration: %w", err)
+	}
+	cfg.FreshnessSLOWindow = freshnessSLOWindow
+
+	freshnessCheckInterval, err := time.ParseDuration(get("FRESHNESS_CHECK_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("FRESHNESS_CHECK_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.FreshnessCheckInterval = freshnessCheckInterval
+
+	replicaMode, err := strconv.ParseBool(get("REPLICA_MODE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("REPLICA_MODE must be true or false: %w", err)
+	}
+	cfg.ReplicaMode = replicaMode
+
+	snapshotPublish, err := strconv.ParseBool(get("SNAPSHOT_PUBLISH", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("SNAPSHOT_PUBLISH must be true or false: %w", err)
+	}
+	cfg.SnapshotPublish = snapshotPublish
+
+	leaderElection, err := strconv.ParseBool(get("LEADER_ELECTION", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("LEADER_ELECTION must be true or false: %w", err)
+	}
+	cfg.LeaderElection = leaderElection
+
+	leaderLeaseTTL, err := time.ParseDuration(get("LEADER_LEASE_TTL", "15s"))
+	if err != nil {
+		return nil, fmt.Errorf("LEADER_LEASE_TTL must be a valid duration: %w", err)
+	}
+	cfg.LeaderLeaseTTL = leaderLeaseTTL
+
+	ratesResponseBudget, err := strconv.Atoi(get("RATES_RESPONSE_BUDGET_BYTES", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_RESPONSE_BUDGET_BYTES must be a number: %w", err)
+	}
+	cfg.RatesResponseBudget = ratesResponseBudget
+
+	downloadURLTTL, err := time.ParseDuration(get("DOWNLOAD_URL_TTL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("DOWNLOAD_URL_TTL must be a valid duration: %w", err)
+	}
+	cfg.DownloadURLTTL = downloadURLTTL
+
+	bulkMaxRows, err := strconv.Atoi(get("BULK_MAX_ROWS", "10000"))
+	if err != nil {
+		return nil, fmt.Errorf("BULK_MAX_ROWS must be a number: %w", err)
+	}
+	cfg.BulkMaxRows = bulkMaxRows
+
+	bulkMaxUploadBytes, err := strconv.ParseInt(get("BULK_MAX_UPLOAD_BYTES", "5242880"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("BULK_MAX_UPLOAD_BYTES must be a number: %w", err)
+	}
+	cfg.BulkMaxUploadBytes = bulkMaxUploadBytes
+
+	jobWorkers, err := strconv.Atoi(get("JOB_WORKERS", "4"))
+	if err != nil {
+		return nil, fmt.Errorf("JOB_WORKERS must be a number: %w", err)
+	}
+	cfg.JobWorkers = jobWorkers
+
+	jobQueueSize, err := strconv.Atoi(get("JOB_QUEUE_SIZE", "100"))
+	if err != nil {
+		return nil, fmt.Errorf("JOB_QUEUE_SIZE must be a number: %w", err)
+	}
+	cfg.JobQueueSize = jobQueueSize
+
+	jobRetention, err := time.ParseDuration(get("JOB_RETENTION", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("JOB_RETENTION must be a valid duration: %w", err)
+	}
+	cfg.JobRetention = jobRetention
+
+	smtpPort, err := strconv.Atoi(get("SMTP_PORT", "587"))
+	if err != nil {
+		return nil, fmt.Errorf("SMTP_PORT must be a number: %w", err)
+	}
+	cfg.SMTPPort = smtpPort
+
+	notifyMaxAttempts, err := strconv.Atoi(get("NOTIFY_MAX_ATTEMPTS", "3"))
+	if err != nil {
+		return nil, fmt.Errorf("NOTIFY_MAX_ATTEMPTS must be a number: %w", err)
+	}
+	cfg.NotifyMaxAttempts = notifyMaxAttempts
+
+	notifyRetryBackoff, err := time.ParseDuration(get("NOTIFY_RETRY_BACKOFF", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("NOTIFY_RETRY_BACKOFF must be a valid duration: %w", err)
+	}
+	cfg.NotifyRetryBackoff = notifyRetryBackoff
+
+	notifyDeliveryLogSize, err := strconv.Atoi(get("NOTIFY_DELIVERY_LOG_SIZE", "100"))
+	if err != nil {
+		return nil, fmt.Errorf("NOTIFY_DELIVERY_LOG_SIZE must be a number: %w", err)
+	}
+	cfg.NotifyDeliveryLogSize = notifyDeliveryLogSize
+
+	marketHolidays, err := parseDates(get("MARKET_HOLIDAYS", "01-01,12-25"))
+	if err != nil {
+		return nil, fmt.Errorf("MARKET_HOLIDAYS must be MM-DD dates: %w", err)
+	}
+	cfg.MarketHolidays = marketHolidays
+
+	marketUpdateInterval, err := time.ParseDuration(get("MARKET_UPDATE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("MARKET_UPDATE_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.MarketUpdateInterval = marketUpdateInterval
+
+	streamRefreshInterval, err := time.ParseDuration(get("STREAM_REFRESH_INTERVAL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("STREAM_REFRESH_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.StreamRefreshInterval = streamRefreshInterval
+
+	streamCoalesceInterval, err := time.ParseDuration(get("STREAM_COALESCE_INTERVAL", "500ms"))
+	if err != nil {
+		return nil, fmt.Errorf("STREAM_COALESCE_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.StreamCoalesceInterval = streamCoalesceInterval
+
+	streamBufferSize, err := strconv.Atoi(get("STREAM_BUFFER_SIZE", "8"))
+	if err != nil {
+		return nil, fmt.Errorf("STREAM_BUFFER_SIZE must be a number: %w", err)
+	}
+	cfg.StreamBufferSize = streamBufferSize
+
+	streamMaxPairs, err := strconv.Atoi(get("STREAM_MAX_PAIRS", "100"))
+	if err != nil {
+		return nil, fmt.Errorf("STREAM_MAX_PAIRS must be a number: %w", err)
+	}
+	cfg.StreamMaxPairs = streamMaxPairs
+
+	streamMaxConnections, err := strconv.Atoi(get("STREAM_MAX_CONNECTIONS_PER_KEY", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("STREAM_MAX_CONNECTIONS_PER_KEY must be a number: %w", err)
+	}
+	cfg.StreamMaxConnections = streamMaxConnections
+
+	ratesCacheMinTTL, err := time.ParseDuration(get("RATES_CACHE_MIN_TTL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_CACHE_MIN_TTL must be a valid duration: %w", err)
+	}
+	cfg.RatesCacheMinTTL = ratesCacheMinTTL
+
+	ratesCacheMaxTTL, err := time.ParseDuration(get("RATES_CACHE_MAX_TTL", "0s"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_CACHE_MAX_TTL must be a valid duration: %w", err)
+	}
+	cfg.RatesCacheMaxTTL = ratesCacheMaxTTL
+
+	ratesCacheHighVolatility, err := strconv.ParseFloat(get("RATES_CACHE_HIGH_VOLATILITY", "0.001"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("RATES_CACHE_HIGH_VOLATILITY must be a number: %w", err)
+	}
+	cfg.RatesCacheHighVolatility = ratesCacheHighVolatility
+
+	ratesCacheHistorySize, err := strconv.Atoi(get("RATES_CACHE_HISTORY_SIZE", "20"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_CACHE_HISTORY_SIZE must be a number: %w", err)
+	}
+	cfg.RatesCacheHistorySize = ratesCacheHistorySize
+
+	ratesCacheMaxStale, err := time.ParseDuration(get("RATES_CACHE_MAX_STALE", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_CACHE_MAX_STALE must be a valid duration: %w", err)
+	}
+	cfg.RatesCacheMaxStale = ratesCacheMaxStale
+
+	ratesNegativeCacheTTL, err := time.ParseDuration(get("RATES_NEGATIVE_CACHE_TTL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("RATES_NEGATIVE_CACHE_TTL must be a valid duration: %w", err)
+	}
+	cfg.RatesNegativeCacheTTL = ratesNegativeCacheTTL
+
+	cfg.CompareRateProviders = parseCommands(get("COMPARE_RATE_PROVIDERS", ""))
+	cfg.ProviderCompareCurrencies = parseCurrencies(get("PROVIDER_COMPARE_CURRENCIES", "EUR,GBP,JPY"))
+
+	providerCompareInterval, err := time.ParseDuration(get("PROVIDER_COMPARE_INTERVAL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("PROVIDER_COMPARE_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.ProviderCompareInterval = providerCompareInterval
+
+	workerRestartBackoff, err := time.ParseDuration(get("WORKER_RESTART_BACKOFF", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("WORKER_RESTART_BACKOFF must be a valid duration: %w", err)
+	}
+	cfg.WorkerRestartBackoff = workerRestartBackoff
+
+	workerMaxBackoff, err := time.ParseDuration(get("WORKER_MAX_BACKOFF", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("WORKER_MAX_BACKOFF must be a valid duration: %w", err)
+	}
+	cfg.WorkerMaxBackoff = workerMaxBackoff
+
+	workerMaxRestarts, err := strconv.Atoi(get("WORKER_MAX_RESTARTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("WORKER_MAX_RESTARTS must be a number: %w", err)
+	}
+	cfg.WorkerMaxRestarts = workerMaxRestarts
+
+	workerRestartWindow, err := time.ParseDuration(get("WORKER_RESTART_WINDOW", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("WORKER_RESTART_WINDOW must be a valid duration: %w", err)
+	}
+	cfg.WorkerRestartWindow = workerRestartWindow
+
+	// MIDDLEWARE is only the default of the per-listener lists, so a bad
+	// value is reported under whichever name the listener fell back to.
+	middlewareList := get("MIDDLEWARE", DefaultMiddleware)
+	listenerMiddleware := func(key string) ([]MiddlewareSpec, error) {
+		specs, err := parseMiddleware(get(key, middlewareList))
+		if err != nil {
+			if settings[key].Source == SourceDefault {
+				key = "MIDDLEWARE"
+			}
+			return nil, fmt.Errorf("%s must be a comma-separated list of middleware with key=value options: %w", key, err)
+		}
+		return specs, nil
+	}
+
+	cfg.ReadPort = get("READ_PORT", cfg.Port)
+	cfg.WritePort = get("WRITE_PORT", "")
+
+	readServerTimeouts, err := parseServerTimeouts(get("READ_SERVER_TIMEOUTS", DefaultServerTimeouts))
+	if err != nil {
+		return nil, fmt.Errorf("READ_SERVER_TIMEOUTS must be comma-separated read=, write= and idle= durations: %w", err)
+	}
+	cfg.ReadServerTimeouts = readServerTimeouts
+
+	writeServerTimeouts, err := parseServerTimeouts(get("WRITE_SERVER_TIMEOUTS", DefaultServerTimeouts))
+	if err != nil {
+		return nil, fmt.Errorf("WRITE_SERVER_TIMEOUTS must be comma-separated read=, write= and idle= durations: %w", err)
+	}
+	cfg.WriteServerTimeouts = writeServerTimeouts
+
+	readMiddleware, err := listenerMiddleware("READ_MIDDLEWARE")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ReadMiddleware = readMiddleware
+
+	writeMiddleware, err := listenerMiddleware("WRITE_MIDDLEWARE")
+	if err != nil {
+		return nil, err
+	}
+	cfg.WriteMiddleware = writeMiddleware
+
+	feeResyncInterval, err := time.ParseDuration(get("FEE_RESYNC_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("FEE_RESYNC_INTERVAL must be a valid duration: %w", err)
+	}
+	cfg.FeeResyncInterval = feeResyncInterval
+
+	precisionAuditSampleRate, err := strconv.ParseFloat(get("PRECISION_AUDIT_SAMPLE_RATE", "0"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("PRECISION_AUDIT_SAMPLE_RATE must be a number: %w", err)
 	}
+	cfg.PrecisionAuditSampleRate = precisionAuditSampleRate
+
+	precisionAuditAt, err := parseTimeOfDay(get("PRECISION_AUDIT_AT", "02:00"))
+	if err != nil {
+		return nil, fmt.Errorf("PRECISION_AUDIT_AT must be a UTC time of day as HH:MM: %w", err)
+	}
+	cfg.PrecisionAuditAt = precisionAuditAt
+
+	precisionAuditMaxSamples, err := strconv.Atoi(get("PRECISION_AUDIT_MAX_SAMPLES", "10000"))
+	if err != nil {
+		return nil, fmt.Errorf("PRECISION_AUDIT_MAX_SAMPLES must be a number: %w", err)
+	}
+	cfg.PrecisionAuditMaxSamples = precisionAuditMaxSamples
+
+	cfg.settings = settings
 
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -47,20 +512,329 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("LOG_LEVEL cannot be empty")
 	}
 
+	if c.LogFormat != "" && c.LogFormat != "auto" && c.LogFormat != "json" && c.LogFormat != "console" {
+		return fmt.Errorf("LOG_FORMAT must be one of: auto, json, console")
+	}
+
 	if _, err := strconv.Atoi(c.Port); err != nil {
 		return fmt.Errorf("PORT must be a valid number: %w", err)
 	}
 
+	if c.ReadPort != "" {
+		if _, err := strconv.Atoi(c.ReadPort); err != nil {
+			return fmt.Errorf("READ_PORT must be a valid number: %w", err)
+		}
+	}
+
+	if c.WritePort != "" {
+		if _, err := strconv.Atoi(c.WritePort); err != nil {
+			return fmt.Errorf("WRITE_PORT must be a valid number: %w", err)
+		}
+		readPort := c.ReadPort
+		if readPort == "" {
+			readPort = c.Port
+		}
+		if c.WritePort == readPort {
+			return fmt.Errorf("WRITE_PORT must differ from READ_PORT")
+		}
+	}
+
+	if c.DiscoveryProvider != "" && c.DiscoveryProvider != "consul" && c.DiscoveryProvider != "etcd" {
+		return fmt.Errorf("DISCOVERY_PROVIDER must be one of: consul, etcd (or empty to disable)")
+	}
+
+	if c.JSONEncoder != "" && c.JSONEncoder != "std" && c.JSONEncoder != "jsoniter" {
+		return fmt.Errorf("JSON_ENCODER must be one of: std, jsoniter")
+	}
+
+	if c.RatesResponseBudget < 0 {
+		return fmt.Errorf("RATES_RESPONSE_BUDGET_BYTES cannot be negative")
+	}
+
+	if c.DownloadURLSecret != "" && len(c.DownloadURLSecret) < 32 {
+		return fmt.Errorf("DOWNLOAD_URL_SECRET must be at least 32 characters")
+	}
+
+	if (c.RateProviderPlugin != "" || len(c.CompareRateProviders) > 0) && c.RateProviderPluginTimeout <= 0 {
+		return fmt.Errorf("RATE_PROVIDER_PLUGIN_TIMEOUT must be positive")
+	}
+
+	if c.DownloadURLSecret != "" && c.DownloadURLTTL <= 0 {
+		return fmt.Errorf("DOWNLOAD_URL_TTL must be positive")
+	}
+
+	if c.BulkMaxRows < 0 || c.BulkMaxUploadBytes < 0 {
+		return fmt.Errorf("BULK_MAX_ROWS and BULK_MAX_UPLOAD_BYTES cannot be negative")
+	}
+
+	if c.JobWorkers < 0 || c.JobQueueSize < 0 {
+		return fmt.Errorf("JOB_WORKERS and JOB_QUEUE_SIZE cannot be negative")
+	}
+
+	if c.JobStore != "" && c.JobStore != "memory" && c.JobStore != "redis" {
+		return fmt.Errorf("JOB_STORE must be one of: memory, redis")
+	}
+
+	if c.JobStore == "redis" && c.JobRetention <= 0 {
+		return fmt.Errorf("JOB_RETENTION must be positive")
+	}
+
+	if c.FeeStore != "" && c.FeeStore != "memory" && c.FeeStore != "redis" {
+		return fmt.Errorf("FEE_STORE must be one of: memory, redis")
+	}
+
+	if c.FeeResyncInterval < 0 {
+		return fmt.Errorf("FEE_RESYNC_INTERVAL cannot be negative")
+	}
+
+	if c.PrecisionAuditSampleRate < 0 || c.PrecisionAuditSampleRate > 1 {
+		return fmt.Errorf("PRECISION_AUDIT_SAMPLE_RATE must be between 0 and 1")
+	}
+
+	if c.PrecisionAuditMaxSamples < 0 || (c.PrecisionAuditSampleRate > 0 && c.PrecisionAuditMaxSamples == 0) {
+		return fmt.Errorf("PRECISION_AUDIT_MAX_SAMPLES must be positive")
+	}
+
+	if c.PrecisionAuditStore != "" && c.PrecisionAuditStore != "memory" && c.PrecisionAuditStore != "redis" {
+		return fmt.Errorf("PRECISION_AUDIT_STORE must be one of: memory, redis")
+	}
+
+	if c.SMTPHost != "" && c.SMTPFrom == "" {
+		return fmt.Errorf("SMTP_FROM is required when SMTP_HOST is set")
+	}
+
+	if c.SMTPHost != "" && (c.SMTPPort < 1 || c.SMTPPort > 65535) {
+		return fmt.Errorf("SMTP_PORT must be between 1 and 65535")
+	}
+
+	if c.NotifyMaxAttempts < 0 || c.NotifyRetryBackoff < 0 || c.NotifyDeliveryLogSize < 0 {
+		return fmt.Errorf("NOTIFY_MAX_ATTEMPTS, NOTIFY_RETRY_BACKOFF and NOTIFY_DELIVERY_LOG_SIZE cannot be negative")
+	}
+
+	if c.MarketUpdateInterval < 0 {
+		return fmt.Errorf("MARKET_UPDATE_INTERVAL cannot be negative")
+	}
+
+	for _, holder := range c.AdminTokens {
+		if holder == "" {
+			return fmt.Errorf("ADMIN_TOKENS entries need a holder name, as in token=name")
+		}
+	}
+	for _, tenant := range c.TenantAPIKeys {
+		if tenant == "" {
+			return fmt.Errorf("TENANT_API_KEYS entries need a tenant name, as in key=tenant")
+		}
+	}
+
+	if c.StreamRefreshInterval < 0 || c.StreamCoalesceInterval < 0 || c.StreamBufferSize < 0 {
+		return fmt.Errorf("STREAM_REFRESH_INTERVAL, STREAM_COALESCE_INTERVAL and STREAM_BUFFER_SIZE cannot be negative")
+	}
+
+	if c.StreamMaxPairs < 0 || c.StreamMaxConnections < 0 {
+		return fmt.Errorf("STREAM_MAX_PAIRS and STREAM_MAX_CONNECTIONS_PER_KEY cannot be negative")
+	}
+
+	if c.RatesCacheMinTTL < 0 || c.RatesCacheMaxTTL < 0 || c.RatesCacheHighVolatility < 0 || c.RatesCacheHistorySize < 0 {
+		return fmt.Errorf("RATES_CACHE_MIN_TTL, RATES_CACHE_MAX_TTL, RATES_CACHE_HIGH_VOLATILITY and RATES_CACHE_HISTORY_SIZE cannot be negative")
+	}
+
+	if c.RatesCacheMaxStale < 0 {
+		return fmt.Errorf("RATES_CACHE_MAX_STALE cannot be negative")
+	}
+
+	if c.RatesCacheMaxTTL > 0 && c.RatesCacheMinTTL == 0 {
+		return fmt.Errorf("RATES_CACHE_MIN_TTL must be positive when the rates cache is enabled")
+	}
+
+	if c.RatesCacheMaxTTL > 0 && c.RatesCacheMinTTL > c.RatesCacheMaxTTL {
+		return fmt.Errorf("RATES_CACHE_MIN_TTL cannot exceed RATES_CACHE_MAX_TTL")
+	}
+
+	if c.RatesNegativeCacheTTL < 0 {
+		return fmt.Errorf("RATES_NEGATIVE_CACHE_TTL cannot be negative")
+	}
+
+	if c.ProviderCompareInterval < 0 {
+		return fmt.Errorf("PROVIDER_COMPARE_INTERVAL cannot be negative")
+	}
+
+	names := make([]string, 0, len(c.CompareRateProviders))
+	for name := range c.CompareRateProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(c.CompareRateProviders[name]) == "" {
+			return fmt.Errorf("COMPARE_RATE_PROVIDERS entry %q needs a command, as in %s=/opt/plugins/%s", name, name, name)
+		}
+	}
+
+	if len(c.CompareRateProviders) > 0 && len(c.ProviderCompareCurrencies) == 0 {
+		return fmt.Errorf("PROVIDER_COMPARE_CURRENCIES cannot be empty when COMPARE_RATE_PROVIDERS is set")
+	}
+
+	if c.WorkerRestartBackoff < 0 || c.WorkerMaxBackoff < 0 || c.WorkerMaxRestarts < 0 || c.WorkerRestartWindow < 0 {
+		return fmt.Errorf("WORKER_RESTART_BACKOFF, WORKER_MAX_BACKOFF, WORKER_MAX_RESTARTS and WORKER_RESTART_WINDOW cannot be negative")
+	}
+
+	if c.ReplicaMode && c.SnapshotPublish {
+		return fmt.Errorf("REPLICA_MODE and SNAPSHOT_PUBLISH cannot both be enabled")
+	}
+
+	if c.LeaderElection && c.LeaderLeaseTTL < time.Second {
+		return fmt.Errorf("LEADER_LEASE_TTL must be at least 1s")
+	}
+
+	if c.FreshnessSLOTarget < 0 || c.FreshnessSLOTarget >= 1 {
+		return fmt.Errorf("FRESHNESS_SLO_TARGET must be at least 0 and below 1")
+	}
+
+	if c.FreshnessCheckInterval < 0 || c.FreshnessSLOWindow < c.FreshnessCheckInterval {
+		return fmt.Errorf("FRESHNESS_SLO_WINDOW must not be shorter than FRESHNESS_CHECK_INTERVAL")
+	}
+
 	return nil
 }
 
+// AdminCredentials maps every admin bearer token to the holder admin actions
+// are attributed to: the names given in ADMIN_TOKENS, and "admin" for
+// ADMIN_TOKEN.
+func (c *Config) AdminCredentials() map[string]string {
+	credentials := make(map[string]string, len(c.AdminTokens)+1)
+	for token, holder := range c.AdminTokens {
+		credentials[token] = holder
+	}
+	if c.AdminToken != "" {
+		credentials[c.AdminToken] = "admin"
+	}
+	return credentials
+}
+
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production" || c.GinMode == "release"
 }
 
-func getEnv(key, defaultValue string) string {
+// Features lists the optional capabilities this config turns on, in a fixed
+// order, for startup events and diagnostics.
+func (c *Config) Features() []string {
+	features := []string{}
+	enabled := func(name string, on bool) {
+		if on {
+			features = append(features, name)
+		}
+	}
+
+	enabled("live_rates", c.OpenExchangeAPIKey != "")
+	enabled("rate_provider_plugin", c.RateProviderPlugin != "")
+	enabled("mock_overrides", c.MockOverridesFile != "")
+	enabled("pricing_rules", c.PricingRulesFile != "")
+	enabled("tenant_auth", len(c.TenantAPIKeys) > 0)
+	enabled("replica_mode", c.ReplicaMode)
+	enabled("snapshot_publish", c.SnapshotPublish)
+	enabled("leader_election", c.LeaderElection)
+	enabled("service_discovery", c.DiscoveryProvider != "")
+	enabled("signed_downloads", c.DownloadURLSecret != "")
+	enabled("redis_job_store", c.JobStore == "redis")
+	enabled("redis_fee_store", c.FeeStore == "redis")
+	enabled("email_notifications", c.SMTPHost != "")
+	enabled("telegram_notifications", c.TelegramBotToken != "")
+	enabled("admin_auth", len(c.AdminCredentials()) > 0)
+	enabled("stream_auth", len(c.StreamAPIKeys) > 0)
+	enabled("rates_cache", c.RatesCacheMaxTTL > 0)
+	enabled("provider_comparison", len(c.CompareRateProviders) > 0)
+	enabled("split_listeners", c.SplitListeners())
+	enabled("precision_audit", c.PrecisionAuditSampleRate > 0)
+	return features
+}
+
+// getValue resolves key with env vars over remote sources over the default
+// and reports where the value came from.
+func getValue(remote, origins map[string]string, key, defaultValue string) (string, string) {
 	if value := os.Getenv(key); value != "" {
-		return value
+		return value, SourceEnv
+	}
+	if value := remote[key]; value != "" {
+		return value, origins[key]
+	}
+	return defaultValue, SourceDefault
+}
+
+// parseKeyValues parses "key=value,key2=value2" pairs, ignoring malformed entries.
+func parseKeyValues(raw string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		result[key] = strings.TrimSpace(value)
+	}
+	return result
+}
+
+// parseCommands parses "name=command line" entries separated by semicolons,
+// so command lines can carry arguments with commas. Entries without a
+// command are kept for Validate to reject.
+func parseCommands(raw string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range strings.Split(raw, ";") {
+		name, command, _ := strings.Cut(entry, "=")
+		if name = strings.TrimSpace(name); name != "" {
+			result[name] = strings.TrimSpace(command)
+		}
+	}
+	return result
+}
+
+// parseCurrencies parses a comma-separated list of currency codes.
+func parseCurrencies(raw string) []string {
+	var result []string
+	for _, currency := range strings.Split(raw, ",") {
+		if currency = strings.ToUpper(strings.TrimSpace(currency)); currency != "" {
+			result = append(result, currency)
+		}
+	}
+	return result
+}
+
+// parseDurations parses "key=duration" pairs such as "fiat=1h,crypto=1m".
+func parseDurations(raw string) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration)
+	for key, value := range parseKeyValues(raw) {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", key, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("duration for %s must be positive", key)
+		}
+		result[key] = duration
+	}
+	return result, nil
+}
+
+// parseTimeOfDay turns "HH:MM" into the offset from midnight.
+func parseTimeOfDay(raw string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", raw)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// parseDates parses a comma-separated list of yearly "MM-DD" dates.
+func parseDates(raw string) ([]string, error) {
+	var result []string
+	for _, date := range strings.Split(raw, ",") {
+		date = strings.TrimSpace(date)
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid date %q", date)
+		}
+		result = append(result, date)
 	}
-	return defaultValue
+	return result, nil
 }
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,7 +14,7 @@ func TestLoad(t *testing.T) {
 	originalEnv := make(map[string]string)
 	envVars := []string{
 		"PORT", "GIN_MODE", "LOG_LEVEL", "OPEN_EXCHANGE_API_KEY",
-		"OPEN_EXCHANGE_BASE_URL", "REDIS_URL", "ENV",
+		"OPEN_EXCHANGE_BASE_URL", "REDIS_URL", "ENV", "PRICING_RULE_TIMEOUT",
 	}
 
 	for _, env := range envVars {
@@ -112,6 +114,14 @@ func TestLoad(t *testing.T) {
 			},
 			hasError: true,
 		},
+		{
+			name: "invalid pricing rule timeout",
+			envVars: map[string]string{
+				"GIN_MODE":             "debug",
+				"PRICING_RULE_TIMEOUT": "fast",
+			},
+			hasError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -199,6 +209,25 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectedError: "PORT must be a valid number",
 		},
+		{
+			name: "invalid discovery provider",
+			config: &Config{
+				Port:              "8080",
+				GinMode:           "debug",
+				LogLevel:          "info",
+				DiscoveryProvider: "zookeeper",
+			},
+			expectedError: "DISCOVERY_PROVIDER must be one of: consul, etcd",
+		},
+		{
+			name: "consul discovery provider",
+			config: &Config{
+				Port:              "8080",
+				GinMode:           "debug",
+				LogLevel:          "info",
+				DiscoveryProvider: "consul",
+			},
+		},
 		{
 			name: "negative port should still validate",
 			config: &Config{
@@ -298,7 +327,7 @@ func TestConfig_IsProduction(t *testing.T) {
 	}
 }
 
-func TestGetEnv(t *testing.T) {
+func TestGetValue(t *testing.T) {
 	originalValue := os.Getenv("TEST_ENV_VAR")
 	defer func() {
 		if originalValue != "" {
@@ -353,7 +382,7 @@ func TestGetEnv(t *testing.T) {
 				os.Unsetenv(tt.key)
 			}
 
-			result := getEnv(tt.key, tt.defaultValue)
+			result, _ := getValue(nil, nil, tt.key, tt.defaultValue)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -500,3 +529,679 @@ func TestConfig_AllFieldsLoaded(t *testing.T) {
 	assert.Equal(t, "redis://redis-server:6380/1", config.RedisURL)
 	assert.Equal(t, "staging", config.Environment)
 }
+
+func TestParseKeyValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]string
+	}{
+		{
+			name:     "empty string",
+			raw:      "",
+			expected: map[string]string{},
+		},
+		{
+			name:     "multiple pairs with whitespace",
+			raw:      "region=eu-west-1, team = payments",
+			expected: map[string]string{"region": "eu-west-1", "team": "payments"},
+		},
+		{
+			name:     "malformed entries are skipped",
+			raw:      "region=eu,invalid,=novalue",
+			expected: map[string]string{"region": "eu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseKeyValues(tt.raw))
+		})
+	}
+}
+
+func TestLoadWithSources_FreshnessSLOs(t *testing.T) {
+	t.Setenv("FRESHNESS_SLOS", "fiat=2h, crypto=30s")
+	t.Setenv("FRESHNESS_SLO_TARGET", "0.95")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"fiat": 2 * time.Hour, "crypto": 30 * time.Second}, cfg.FreshnessSLOs)
+	assert.Equal(t, 0.95, cfg.FreshnessSLOTarget)
+	assert.Equal(t, time.Hour, cfg.FreshnessSLOWindow)
+	assert.Equal(t, 30*time.Second, cfg.FreshnessCheckInterval)
+
+	for name, env := range map[string]map[string]string{
+		"invalid objective": {"FRESHNESS_SLOS": "fiat=soon"},
+		"zero objective":    {"FRESHNESS_SLOS": "fiat=0s"},
+		"target of one":     {"FRESHNESS_SLO_TARGET": "1"},
+		"window too short":  {"FRESHNESS_SLO_WINDOW": "10s", "FRESHNESS_CHECK_INTERVAL": "1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_ReplicaMode(t *testing.T) {
+	t.Setenv("REPLICA_MODE", "true")
+	t.Setenv("REGION", "eu-west-1")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, cfg.ReplicaMode)
+	assert.False(t, cfg.SnapshotPublish)
+	assert.Equal(t, "eu-west-1", cfg.Region)
+
+	for name, env := range map[string]map[string]string{
+		"invalid replica flag": {"REPLICA_MODE": "maybe"},
+		"invalid publish flag": {"SNAPSHOT_PUBLISH": "sometimes"},
+		"replica and publish":  {"REPLICA_MODE": "true", "SNAPSHOT_PUBLISH": "true"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_LeaderElection(t *testing.T) {
+	t.Setenv("LEADER_ELECTION", "true")
+	t.Setenv("INSTANCE_ID", "currency-api-1")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, cfg.LeaderElection)
+	assert.Equal(t, 15*time.Second, cfg.LeaderLeaseTTL)
+	assert.Equal(t, "currency-api-1", cfg.InstanceID)
+
+	for name, env := range map[string]map[string]string{
+		"invalid flag":      {"LEADER_ELECTION": "yes please"},
+		"invalid lease ttl": {"LEADER_LEASE_TTL": "forever"},
+		"lease too short":   {"LEADER_LEASE_TTL": "500ms"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_JSONEncoder(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "std", cfg.JSONEncoder)
+
+	t.Setenv("JSON_ENCODER", "jsoniter")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "jsoniter", cfg.JSONEncoder)
+
+	t.Setenv("JSON_ENCODER", "sonic")
+	_, err = LoadWithSources(context.Background())
+	require.EqualError(t, err, "config validation failed: JSON_ENCODER must be one of: std, jsoniter")
+}
+
+func TestLoadWithSources_RatesResponseBudget(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, cfg.RatesResponseBudget, "the budget is disabled by default")
+
+	t.Setenv("RATES_RESPONSE_BUDGET_BYTES", "1048576")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1048576, cfg.RatesResponseBudget)
+
+	for _, value := range []string{"1MB", "-1"} {
+		t.Setenv("RATES_RESPONSE_BUDGET_BYTES", value)
+		_, err = LoadWithSources(context.Background())
+		assert.Error(t, err, value)
+	}
+}
+
+func TestLoadWithSources_DownloadURLs(t *testing.T) {
+	t.Setenv("DOWNLOAD_URL_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("DOWNLOAD_BASE_URL", "https://downloads.example.com")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, cfg.DownloadURLTTL)
+	assert.Equal(t, "https://downloads.example.com", cfg.DownloadBaseURL)
+
+	for name, env := range map[string]map[string]string{
+		"short secret": {"DOWNLOAD_URL_SECRET": "too-short"},
+		"invalid ttl":  {"DOWNLOAD_URL_TTL": "soon"},
+		"zero ttl":     {"DOWNLOAD_URL_TTL": "0s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_RateProviderPluginTimeout(t *testing.T) {
+	t.Setenv("RATE_PROVIDER_PLUGIN", "/opt/plugins/fx")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, cfg.RateProviderPluginTimeout)
+
+	for name, value := range map[string]string{
+		"invalid timeout": "slow",
+		"zero timeout":    "0s",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("RATE_PROVIDER_PLUGIN_TIMEOUT", value)
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_BulkLimits(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10000, cfg.BulkMaxRows)
+	assert.Equal(t, int64(5242880), cfg.BulkMaxUploadBytes)
+
+	for name, env := range map[string]map[string]string{
+		"invalid rows":   {"BULK_MAX_ROWS": "many"},
+		"invalid bytes":  {"BULK_MAX_UPLOAD_BYTES": "5MB"},
+		"negative rows":  {"BULK_MAX_ROWS": "-1"},
+		"negative bytes": {"BULK_MAX_UPLOAD_BYTES": "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_Jobs(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.JobWorkers)
+	assert.Equal(t, 100, cfg.JobQueueSize)
+	assert.Equal(t, "memory", cfg.JobStore)
+	assert.Equal(t, 24*time.Hour, cfg.JobRetention)
+
+	for name, env := range map[string]map[string]string{
+		"invalid workers":    {"JOB_WORKERS": "four"},
+		"negative workers":   {"JOB_WORKERS": "-1"},
+		"negative queue":     {"JOB_QUEUE_SIZE": "-1"},
+		"unknown store":      {"JOB_STORE": "postgres"},
+		"invalid retention":  {"JOB_RETENTION": "1 day"},
+		"negative retention": {"JOB_STORE": "redis", "JOB_RETENTION": "-1h"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_Notifications(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, cfg.SMTPHost)
+	assert.Equal(t, 587, cfg.SMTPPort)
+	assert.Equal(t, 3, cfg.NotifyMaxAttempts)
+	assert.Equal(t, time.Second, cfg.NotifyRetryBackoff)
+	assert.Equal(t, 100, cfg.NotifyDeliveryLogSize)
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_FROM", "alerts@example.com")
+	t.Setenv("SMTP_TENANT_SENDERS", "acme=fx@acme.example.com")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"acme": "fx@acme.example.com"}, cfg.SMTPTenantSenders)
+
+	for name, env := range map[string]map[string]string{
+		"missing sender":    {"SMTP_FROM": ""},
+		"invalid port":      {"SMTP_PORT": "smtp"},
+		"port out of range": {"SMTP_PORT": "70000"},
+		"invalid attempts":  {"NOTIFY_MAX_ATTEMPTS": "three"},
+		"negative attempts": {"NOTIFY_MAX_ATTEMPTS": "-1"},
+		"invalid backoff":   {"NOTIFY_RETRY_BACKOFF": "soon"},
+		"negative log size": {"NOTIFY_DELIVERY_LOG_SIZE": "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_MarketCalendar(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"01-01", "12-25"}, cfg.MarketHolidays)
+	assert.Equal(t, time.Hour, cfg.MarketUpdateInterval)
+
+	for name, env := range map[string]map[string]string{
+		"invalid holiday":   {"MARKET_HOLIDAYS": "01-01,christmas"},
+		"impossible date":   {"MARKET_HOLIDAYS": "02-30"},
+		"invalid interval":  {"MARKET_UPDATE_INTERVAL": "hourly"},
+		"negative interval": {"MARKET_UPDATE_INTERVAL": "-1h"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_Features(t *testing.T) {
+	assert.Empty(t, (&Config{}).Features())
+
+	cfg := &Config{
+		OpenExchangeAPIKey: "key",
+		ReplicaMode:        true,
+		JobStore:           "redis",
+		SMTPHost:           "smtp.example.com",
+		AdminToken:         "token",
+	}
+	assert.Equal(t, []string{"live_rates", "replica_mode", "redis_job_store", "email_notifications", "admin_auth"}, cfg.Features())
+}
+
+func TestLoadWithSources_AdminTokens(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "t-shared")
+	t.Setenv("ADMIN_TOKENS", "t-alice=alice@example.com,t-bob=bob@example.com")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"t-shared": "admin",
+		"t-alice":  "alice@example.com",
+		"t-bob":    "bob@example.com",
+	}, cfg.AdminCredentials())
+	assert.Contains(t, cfg.Features(), "admin_auth")
+
+	t.Setenv("ADMIN_TOKENS", "t-alice=")
+	_, err = LoadWithSources(context.Background())
+	require.EqualError(t, err, "config validation failed: ADMIN_TOKENS entries need a holder name, as in token=name")
+}
+
+func TestLoadWithSources_TenantAPIKeys(t *testing.T) {
+	t.Setenv("TENANT_API_KEYS", "k-acme=acme,k-globex=globex")
+
+	cfg, err := LoadWithSources(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"k-acme": "acme", "k-globex": "globex"}, cfg.TenantAPIKeys)
+	assert.Contains(t, cfg.Features(), "tenant_auth")
+
+	t.Setenv("TENANT_API_KEYS", "k-acme=")
+	_, err = LoadWithSources(context.Background())
+	require.EqualError(t, err, "config validation failed: TENANT_API_KEYS entries need a tenant name, as in key=tenant")
+}
+
+func TestLoadWithSources_Streaming(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.StreamRefreshInterval)
+	assert.Equal(t, 500*time.Millisecond, cfg.StreamCoalesceInterval)
+	assert.Equal(t, 8, cfg.StreamBufferSize)
+	assert.Empty(t, cfg.StreamAPIKeys)
+	assert.Equal(t, 100, cfg.StreamMaxPairs)
+	assert.Equal(t, 5, cfg.StreamMaxConnections)
+
+	t.Setenv("STREAM_API_KEYS", "k-acme=acme,k-globex=globex")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"k-acme": "acme", "k-globex": "globex"}, cfg.StreamAPIKeys)
+
+	for name, env := range map[string]map[string]string{
+		"invalid refresh interval":   {"STREAM_REFRESH_INTERVAL": "often"},
+		"negative refresh interval":  {"STREAM_REFRESH_INTERVAL": "-5s"},
+		"invalid coalesce interval":  {"STREAM_COALESCE_INTERVAL": "fast"},
+		"negative coalesce interval": {"STREAM_COALESCE_INTERVAL": "-1s"},
+		"invalid buffer size":        {"STREAM_BUFFER_SIZE": "large"},
+		"negative buffer size":       {"STREAM_BUFFER_SIZE": "-1"},
+		"invalid max pairs":          {"STREAM_MAX_PAIRS": "many"},
+		"negative max pairs":         {"STREAM_MAX_PAIRS": "-1"},
+		"invalid max connections":    {"STREAM_MAX_CONNECTIONS_PER_KEY": "few"},
+		"negative max connections":   {"STREAM_MAX_CONNECTIONS_PER_KEY": "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_RatesCache(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.RatesCacheMinTTL)
+	assert.Zero(t, cfg.RatesCacheMaxTTL)
+	assert.Equal(t, 0.001, cfg.RatesCacheHighVolatility)
+	assert.Equal(t, 20, cfg.RatesCacheHistorySize)
+	assert.Equal(t, time.Hour, cfg.RatesCacheMaxStale)
+	assert.Equal(t, 5*time.Minute, cfg.RatesNegativeCacheTTL)
+
+	t.Setenv("RATES_CACHE_MAX_TTL", "2m")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, cfg.RatesCacheMaxTTL)
+	assert.Contains(t, cfg.Features(), "rates_cache")
+
+	for name, env := range map[string]map[string]string{
+		"invalid min ttl":         {"RATES_CACHE_MIN_TTL": "short"},
+		"negative min ttl":        {"RATES_CACHE_MIN_TTL": "-1s"},
+		"zero min ttl":            {"RATES_CACHE_MIN_TTL": "0s", "RATES_CACHE_MAX_TTL": "30s"},
+		"invalid max ttl":         {"RATES_CACHE_MAX_TTL": "long"},
+		"min ttl above max ttl":   {"RATES_CACHE_MIN_TTL": "1m", "RATES_CACHE_MAX_TTL": "30s"},
+		"invalid high volatility": {"RATES_CACHE_HIGH_VOLATILITY": "wild"},
+		"negative volatility":     {"RATES_CACHE_HIGH_VOLATILITY": "-0.1"},
+		"invalid history size":    {"RATES_CACHE_HISTORY_SIZE": "some"},
+		"negative history size":   {"RATES_CACHE_HISTORY_SIZE": "-1"},
+		"invalid max stale":       {"RATES_CACHE_MAX_STALE": "old"},
+		"negative max stale":      {"RATES_CACHE_MAX_STALE": "-1h"},
+		"invalid negative ttl":    {"RATES_NEGATIVE_CACHE_TTL": "forever"},
+		"negative negative ttl":   {"RATES_NEGATIVE_CACHE_TTL": "-1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_LogFormat(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "auto", cfg.LogFormat)
+
+	t.Setenv("LOG_FORMAT", "console")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "console", cfg.LogFormat)
+
+	t.Setenv("LOG_FORMAT", "pretty")
+	_, err = LoadWithSources(context.Background())
+	require.EqualError(t, err, "config validation failed: LOG_FORMAT must be one of: auto, json, console")
+}
+
+func TestLoadWithSources_ProviderComparison(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, cfg.CompareRateProviders)
+	assert.Equal(t, 5*time.Minute, cfg.ProviderCompareInterval)
+	assert.Equal(t, []string{"EUR", "GBP", "JPY"}, cfg.ProviderCompareCurrencies)
+
+	t.Setenv("COMPARE_RATE_PROVIDERS", "fixer=/opt/plugins/fixer --plan=free --symbols=EUR,CHF; currencylayer = /opt/plugins/currencylayer ;")
+	t.Setenv("PROVIDER_COMPARE_CURRENCIES", "eur, chf")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"fixer":         "/opt/plugins/fixer --plan=free --symbols=EUR,CHF",
+		"currencylayer": "/opt/plugins/currencylayer",
+	}, cfg.CompareRateProviders)
+	assert.Equal(t, []string{"EUR", "CHF"}, cfg.ProviderCompareCurrencies)
+	assert.Contains(t, cfg.Features(), "provider_comparison")
+
+	for name, env := range map[string]map[string]string{
+		"invalid interval":  {"PROVIDER_COMPARE_INTERVAL": "hourly"},
+		"negative interval": {"PROVIDER_COMPARE_INTERVAL": "-1m"},
+		"no currencies":     {"PROVIDER_COMPARE_CURRENCIES": " , "},
+		"empty command":     {"COMPARE_RATE_PROVIDERS": "fixer="},
+		"blank command":     {"COMPARE_RATE_PROVIDERS": "fixer=/opt/plugins/fixer;currencylayer=   "},
+		"no command":        {"COMPARE_RATE_PROVIDERS": "fixer"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_WorkerSupervision(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, cfg.WorkerRestartBackoff)
+	assert.Equal(t, time.Minute, cfg.WorkerMaxBackoff)
+	assert.Equal(t, 5, cfg.WorkerMaxRestarts)
+	assert.Equal(t, 10*time.Minute, cfg.WorkerRestartWindow)
+
+	for name, env := range map[string]map[string]string{
+		"invalid backoff":      {"WORKER_RESTART_BACKOFF": "soon"},
+		"invalid max backoff":  {"WORKER_MAX_BACKOFF": "later"},
+		"invalid max restarts": {"WORKER_MAX_RESTARTS": "many"},
+		"invalid window":       {"WORKER_RESTART_WINDOW": "hourly"},
+		"negative restarts":    {"WORKER_MAX_RESTARTS": "-1"},
+		"negative window":      {"WORKER_RESTART_WINDOW": "-1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_Middleware(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []MiddlewareSpec{
+		{Name: "recovery", Options: map[string]string{}},
+		{Name: "access_log", Options: map[string]string{}},
+		{Name: "compression", Options: map[string]string{}},
+	}, cfg.ReadMiddleware)
+
+	t.Setenv("MIDDLEWARE", "recovery, access_log, Chaos error_rate=0.05 latency=200ms,")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	require.Len(t, cfg.ReadMiddleware, 3)
+	assert.Equal(t, MiddlewareSpec{Name: "chaos", Options: map[string]string{"error_rate": "0.05", "latency": "200ms"}}, cfg.ReadMiddleware[2])
+	assert.Equal(t, "chaos error_rate=0.05 latency=200ms", cfg.ReadMiddleware[2].String())
+	assert.Equal(t, cfg.ReadMiddleware, cfg.WriteMiddleware)
+
+	for name, value := range map[string]string{
+		"option without value": "compression level",
+		"empty option value":   "compression level=",
+		"option without name":  "level=5",
+		"repeated option":      "compression level=1 level=9",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("MIDDLEWARE", value)
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+			assert.Regexp(t, "^MIDDLEWARE must be", err.Error())
+		})
+	}
+}
+
+func TestLoadWithSources_FeeStore(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "memory", cfg.FeeStore)
+	assert.Equal(t, time.Minute, cfg.FeeResyncInterval)
+	assert.NotContains(t, cfg.Features(), "redis_fee_store")
+
+	t.Setenv("FEE_STORE", "redis")
+	t.Setenv("FEE_RESYNC_INTERVAL", "0")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.FeeResyncInterval)
+	assert.Contains(t, cfg.Features(), "redis_fee_store")
+
+	for name, env := range map[string]map[string]string{
+		"unknown store":     {"FEE_STORE": "postgres"},
+		"invalid interval":  {"FEE_RESYNC_INTERVAL": "often"},
+		"negative interval": {"FEE_RESYNC_INTERVAL": "-1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_Listeners(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "8080", cfg.ReadPort)
+	assert.False(t, cfg.SplitListeners())
+	assert.Equal(t, ServerTimeouts{Read: 30 * time.Second, Write: 30 * time.Second, Idle: time.Minute}, cfg.ReadServerTimeouts)
+	assert.Equal(t, cfg.ReadServerTimeouts, cfg.WriteServerTimeouts)
+	assert.Equal(t, cfg.ReadMiddleware, cfg.WriteMiddleware)
+	assert.NotContains(t, cfg.Features(), "split_listeners")
+
+	t.Setenv("MIDDLEWARE", "recovery,access_log")
+	t.Setenv("READ_PORT", "8080")
+	t.Setenv("WRITE_PORT", "8081")
+	t.Setenv("READ_SERVER_TIMEOUTS", "write=5s")
+	t.Setenv("WRITE_SERVER_TIMEOUTS", "read=2m,write=2m,idle=10s")
+	t.Setenv("READ_MIDDLEWARE", "recovery,compression")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.True(t, cfg.SplitListeners())
+	assert.Equal(t, "8081", cfg.WritePort)
+	assert.Equal(t, ServerTimeouts{Read: 30 * time.Second, Write: 5 * time.Second, Idle: time.Minute}, cfg.ReadServerTimeouts)
+	assert.Equal(t, ServerTimeouts{Read: 2 * time.Minute, Write: 2 * time.Minute, Idle: 10 * time.Second}, cfg.WriteServerTimeouts)
+	assert.Equal(t, "compression", cfg.ReadMiddleware[1].Name)
+	assert.Equal(t, []MiddlewareSpec{
+		{Name: "recovery", Options: map[string]string{}},
+		{Name: "access_log", Options: map[string]string{}},
+	}, cfg.WriteMiddleware)
+	assert.Contains(t, cfg.Features(), "split_listeners")
+
+	for name, env := range map[string]map[string]string{
+		"write port equals read port": {"WRITE_PORT": "8080"},
+		"write port equals port":      {"READ_PORT": "", "PORT": "9000", "WRITE_PORT": "9000"},
+		"invalid read port":           {"READ_PORT": "read"},
+		"invalid write port":          {"WRITE_PORT": "write"},
+		"unknown timeout":             {"READ_SERVER_TIMEOUTS": "header=5s"},
+		"invalid timeout":             {"WRITE_SERVER_TIMEOUTS": "write=soon"},
+		"non-positive timeout":        {"WRITE_SERVER_TIMEOUTS": "idle=0s"},
+		"invalid write middleware":    {"WRITE_MIDDLEWARE": "level=5"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWithSources_PrecisionAudit(t *testing.T) {
+	cfg, err := LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, cfg.PrecisionAuditSampleRate)
+	assert.Equal(t, 2*time.Hour, cfg.PrecisionAuditAt)
+	assert.Equal(t, 10000, cfg.PrecisionAuditMaxSamples)
+	assert.Equal(t, "memory", cfg.PrecisionAuditStore)
+	assert.NotContains(t, cfg.Features(), "precision_audit")
+
+	t.Setenv("PRECISION_AUDIT_SAMPLE_RATE", "0.01")
+	t.Setenv("PRECISION_AUDIT_AT", "23:45")
+	t.Setenv("PRECISION_AUDIT_STORE", "redis")
+	cfg, err = LoadWithSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0.01, cfg.PrecisionAuditSampleRate)
+	assert.Equal(t, 23*time.Hour+45*time.Minute, cfg.PrecisionAuditAt)
+	assert.Equal(t, "redis", cfg.PrecisionAuditStore)
+	assert.Contains(t, cfg.Features(), "precision_audit")
+
+	for name, env := range map[string]map[string]string{
+		"sample rate above 1":  {"PRECISION_AUDIT_SAMPLE_RATE": "1.5"},
+		"negative sample rate": {"PRECISION_AUDIT_SAMPLE_RATE": "-0.1"},
+		"invalid sample rate":  {"PRECISION_AUDIT_SAMPLE_RATE": "1%"},
+		"time with seconds":    {"PRECISION_AUDIT_AT": "02:00:00"},
+		"hour out of range":    {"PRECISION_AUDIT_AT": "25:00"},
+		"negative max samples": {"PRECISION_AUDIT_MAX_SAMPLES": "-1"},
+		"zero max samples":     {"PRECISION_AUDIT_MAX_SAMPLES": "0"},
+		"unknown store":        {"PRECISION_AUDIT_STORE": "postgres"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadWithSources(context.Background())
+
+			require.Error(t, err)
+		})
+	}
+}
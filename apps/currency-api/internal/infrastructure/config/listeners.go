@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultServerTimeouts are the net/http timeouts of a listener unless
+// READ_SERVER_TIMEOUTS or WRITE_SERVER_TIMEOUTS override them.
+const DefaultServerTimeouts = "read=30s,write=30s,idle=60s"
+
+// ServerTimeouts are the net/http timeouts of one listener, set as
+// "read=30s,write=30s,idle=60s". Omitted keys keep their default.
+type ServerTimeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	Idle  time.Duration
+}
+
+// SplitListeners reports whether command endpoints get a listener of their
+// own on WRITE_PORT instead of sharing the one on READ_PORT.
+func (c *Config) SplitListeners() bool {
+	return c.WritePort != ""
+}
+
+func parseServerTimeouts(raw string) (ServerTimeouts, error) {
+	durations, err := parseDurations(DefaultServerTimeouts + "," + raw)
+	if err != nil {
+		return ServerTimeouts{}, err
+	}
+
+	timeouts := ServerTimeouts{Read: durations["read"], Write: durations["write"], Idle: durations["idle"]}
+	for key := range durations {
+		if key != "read" && key != "write" && key != "idle" {
+			return ServerTimeouts{}, fmt.Errorf("unknown timeout %q (available: read, write, idle)", key)
+		}
+	}
+	return timeouts, nil
+}
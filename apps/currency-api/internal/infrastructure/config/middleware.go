@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultMiddleware is the global middleware stack used unless MIDDLEWARE
+// overrides it.
+const DefaultMiddleware = "recovery,access_log,compression"
+
+// MiddlewareSpec is one entry of MIDDLEWARE: a middleware name followed by
+// space-separated key=value options, e.g. "chaos error_rate=0.05 latency=200ms".
+// Which names and options exist is up to the HTTP transport.
+type MiddlewareSpec struct {
+	Name    string
+	Options map[string]string
+}
+
+// String renders the spec back in MIDDLEWARE syntax.
+func (s MiddlewareSpec) String() string {
+	options := make([]string, 0, len(s.Options))
+	for key, value := range s.Options {
+		options = append(options, key+"="+value)
+	}
+	sort.Strings(options)
+	return strings.Join(append([]string{s.Name}, options...), " ")
+}
+
+// parseMiddleware parses the ordered, comma-separated MIDDLEWARE list.
+func parseMiddleware(raw string) ([]MiddlewareSpec, error) {
+	var specs []MiddlewareSpec
+	for _, entry := range strings.Split(raw, ",") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		spec := MiddlewareSpec{Name: strings.ToLower(fields[0]), Options: make(map[string]string)}
+		if strings.Contains(spec.Name, "=") {
+			return nil, fmt.Errorf("option %q is missing a middleware name", fields[0])
+		}
+		for _, option := range fields[1:] {
+			key, value, ok := strings.Cut(option, "=")
+			if !ok || key == "" || value == "" {
+				return nil, fmt.Errorf("%s option %q must be key=value", spec.Name, option)
+			}
+			if _, exists := spec.Options[key]; exists {
+				return nil, fmt.Errorf("%s option %s is set more than once", spec.Name, key)
+			}
+			spec.Options[key] = value
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	// SourceSecretsManagerPrefix starts the Source of settings loaded from
+	// AWS Secrets Manager, all of which are treated as secrets.
+	SourceSecretsManagerPrefix = "secretsmanager:"
+)
+
+const redacted = "[REDACTED]"
+
+// Setting is one raw config value as it was resolved at load time. Source is
+// SourceDefault, SourceEnv or the name of the remote source it came from.
+type Setting struct {
+	Key      string
+	Value    string
+	Source   string
+	Redacted bool
+}
+
+// secretKeyMarkers identify settings whose values must never be shown.
+var secretKeyMarkers = []string{"SECRET", "PASSWORD", "TOKEN", "API_KEY"}
+
+// secretKeys are settings without a marker that are still never shown:
+// plugin commands are often given credentials as arguments.
+var secretKeys = map[string]bool{
+	"RATE_PROVIDER_PLUGIN":   true,
+	"COMPARE_RATE_PROVIDERS": true,
+}
+
+// Settings returns every config value read at load time, sorted by key, with
+// secrets, everything read from Secrets Manager and URL credentials redacted.
+func (c *Config) Settings() []Setting {
+	result := make([]Setting, 0, len(c.settings))
+	for _, setting := range c.settings {
+		result = append(result, redact(setting))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func redact(setting Setting) Setting {
+	if setting.Value == "" {
+		return setting
+	}
+
+	if isSecret(setting) {
+		setting.Value = redacted
+		setting.Redacted = true
+		return setting
+	}
+
+	if parsed, err := url.Parse(setting.Value); err == nil && parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			setting.Value = parsed.Redacted()
+			setting.Redacted = true
+		}
+	}
+	return setting
+}
+
+func isSecret(setting Setting) bool {
+	if secretKeys[setting.Key] || strings.HasPrefix(setting.Source, SourceSecretsManagerPrefix) {
+		return true
+	}
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(setting.Key, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// Source provides config values keyed by env var name (e.g. OPEN_EXCHANGE_API_KEY).
+type Source interface {
+	Name() string
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// loadSourceValues merges the values of all sources and records which source
+// each value was taken from.
+func loadSourceValues(ctx context.Context, sources []Source) (map[string]string, map[string]string, error) {
+	values := make(map[string]string)
+	origins := make(map[string]string)
+	for _, source := range sources {
+		loaded, err := source.Load(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load config from %s: %w", source.Name(), err)
+		}
+		for key, value := range loaded {
+			values[key] = value
+			origins[key] = source.Name()
+		}
+	}
+	return values, origins, nil
+}
+
+// remoteSources enables AWS sources only when their prefixes are set, so local
+// runs never touch AWS credentials.
+func remoteSources(ctx context.Context) ([]Source, error) {
+	ssmPrefix := os.Getenv("CONFIG_SSM_PREFIX")
+	secretsPrefix := os.Getenv("CONFIG_SECRETS_PREFIX")
+
+	if ssmPrefix == "" && secretsPrefix == "" {
+		return nil, nil
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	var sources []Source
+	if ssmPrefix != "" {
+		sources = append(sources, NewSSMSource(ssm.NewFromConfig(awsCfg), ssmPrefix))
+	}
+	if secretsPrefix != "" {
+		sources = append(sources, NewSecretsManagerSource(secretsmanager.NewFromConfig(awsCfg), secretsPrefix))
+	}
+
+	return sources, nil
+}
+
+// envKey maps a parameter or secret name below prefix to an env var name,
+// e.g. "/currency-api/prod/open-exchange-api-key" -> "OPEN_EXCHANGE_API_KEY".
+func envKey(prefix, name string) string {
+	key := strings.TrimPrefix(name, prefix)
+	key = strings.Trim(key, "/")
+	key = strings.NewReplacer("/", "_", "-", "_", ".", "_").Replace(key)
+	return strings.ToUpper(key)
+}
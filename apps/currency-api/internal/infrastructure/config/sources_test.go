@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSource struct {
+	name   string
+	values map[string]string
+	err    error
+}
+
+func (s staticSource) Name() string {
+	return s.name
+}
+
+func (s staticSource) Load(ctx context.Context) (map[string]string, error) {
+	return s.values, s.err
+}
+
+func TestLoadWithSources_Precedence(t *testing.T) {
+	envVars := []string{"PORT", "LOG_LEVEL", "OPEN_EXCHANGE_API_KEY", "SERVICE_NAME"}
+	originalEnv := make(map[string]string)
+	for _, env := range envVars {
+		originalEnv[env] = os.Getenv(env)
+		os.Unsetenv(env)
+	}
+	defer func() {
+		for _, env := range envVars {
+			if val := originalEnv[env]; val != "" {
+				os.Setenv(env, val)
+			} else {
+				os.Unsetenv(env)
+			}
+		}
+	}()
+
+	os.Setenv("LOG_LEVEL", "debug")
+
+	ssm := staticSource{name: "ssm", values: map[string]string{
+		"OPEN_EXCHANGE_API_KEY": "from-ssm",
+		"SERVICE_NAME":          "from-ssm",
+		"LOG_LEVEL":             "warn",
+	}}
+	secrets := staticSource{name: "secrets", values: map[string]string{
+		"OPEN_EXCHANGE_API_KEY": "from-secrets",
+	}}
+
+	cfg, err := LoadWithSources(context.Background(), ssm, secrets)
+
+	require.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Port, "defaults apply when no source provides a value")
+	assert.Equal(t, "debug", cfg.LogLevel, "env vars override remote sources")
+	assert.Equal(t, "from-secrets", cfg.OpenExchangeAPIKey, "later sources override earlier ones")
+	assert.Equal(t, "from-ssm", cfg.ServiceName)
+}
+
+func TestLoadWithSources_SourceError(t *testing.T) {
+	failing := staticSource{name: "ssm:/currency-api", err: errors.New("access denied")}
+
+	_, err := LoadWithSources(context.Background(), failing)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load config from ssm:/currency-api")
+}
+
+func TestEnvKey(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		name     string
+		expected string
+	}{
+		{"/currency-api/prod", "/currency-api/prod/OPEN_EXCHANGE_API_KEY", "OPEN_EXCHANGE_API_KEY"},
+		{"/currency-api/prod/", "/currency-api/prod/open-exchange-api-key", "OPEN_EXCHANGE_API_KEY"},
+		{"/currency-api/prod", "/currency-api/prod/redis/url", "REDIS_URL"},
+		{"currency-api/prod/", "currency-api/prod/log.level", "LOG_LEVEL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, envKey(tt.prefix, tt.name))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package configfile
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind names a config file format with an embedded schema.
+type Kind string
+
+const (
+	PricingRules  Kind = "pricing_rules"
+	ChainRules    Kind = "chain_rules"
+	MockOverrides Kind = "mock_overrides"
+)
+
+//go:embed schemas/*.schema.json
+var schemaFiles embed.FS
+
+var schemas = mustLoadSchemas(PricingRules, ChainRules, MockOverrides)
+
+// FieldError is one problem in a config file. Field is the dotted path of the
+// offending value, e.g. networks.base.USDT.decimals.
+type FieldError struct {
+	Field   string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s %s", e.Line, e.Column, e.Field, e.Message)
+}
+
+// ValidationError lists every problem found in a config file, in file order.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks data against the schema of kind before it is decoded, so a
+// misspelled or mistyped field fails loudly instead of silently decoding to a
+// zero value. Problems are returned as a *ValidationError.
+func Validate(kind Kind, data []byte) error {
+	schema, ok := schemas[kind]
+	if !ok {
+		return fmt.Errorf("no schema for config file kind %q", kind)
+	}
+	return ValidateSchema(schema, data)
+}
+
+// CompileSchema parses a schema in the subset Schema supports, for JSON
+// documents other than the config files, such as event payloads.
+func CompileSchema(data []byte) (*Schema, error) {
+	schema := &Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ValidateSchema is Validate against a schema from CompileSchema.
+func ValidateSchema(schema *Schema, data []byte) error {
+	document, err := parse(data)
+	if err != nil {
+		var parseErr *parseError
+		if !errors.As(err, &parseErr) {
+			return err
+		}
+		line, column := position(data, parseErr.offset)
+		return &ValidationError{Errors: []FieldError{{Field: "document", Line: line, Column: column, Message: "is not valid JSON: " + parseErr.message}}}
+	}
+
+	v := &validator{data: data}
+	v.validate(schema, document, "")
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+		return v.errors[i].Column < v.errors[j].Column
+	})
+	return &ValidationError{Errors: v.errors}
+}
+
+func readSchema(kind Kind) ([]byte, error) {
+	return schemaFiles.ReadFile("schemas/" + string(kind) + ".schema.json")
+}
+
+func mustLoadSchemas(kinds ...Kind) map[Kind]*Schema {
+	loaded := make(map[Kind]*Schema, len(kinds))
+	for _, kind := range kinds {
+		data, err := readSchema(kind)
+		if err != nil {
+			panic(fmt.Sprintf("missing schema for %s: %v", kind, err))
+		}
+
+		schema, err := CompileSchema(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid schema for %s: %v", kind, err))
+		}
+		loaded[kind] = schema
+	}
+	return loaded
+}
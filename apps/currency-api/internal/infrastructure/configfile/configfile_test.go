@@ -0,0 +1,170 @@
+package configfile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationErrors(t *testing.T, kind Kind, content string) []FieldError {
+	t.Helper()
+
+	err := Validate(kind, []byte(content))
+	require.Error(t, err)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "unexpected error %v", err)
+	return validationErr.Errors
+}
+
+func TestValidate_Valid(t *testing.T) {
+	tests := map[Kind]string{
+		PricingRules:  `{"default": "amount * 1.01", "tenants": {"acme": "amount"}}`,
+		ChainRules:    `{"networks": {"base": {"USDT": {"decimals": 6, "dust_limit": "0.05"}, "WBTC": {"decimals": 8, "dust_limit": 0.00001}}}}`,
+		MockOverrides: `{"pairs": {"USD-EUR": "1.10", "EUR-GBP": 0.5}}`,
+	}
+
+	for kind, content := range tests {
+		t.Run(string(kind), func(t *testing.T) {
+			assert.NoError(t, Validate(kind, []byte(content)))
+		})
+	}
+}
+
+func TestValidate_ReportsLineAndField(t *testing.T) {
+	content := `{
+  "default": "amount * 1.01",
+  "tenant": {
+    "acme": "amount * 1.02"
+  }
+}`
+
+	errs := validationErrors(t, PricingRules, content)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, FieldError{Field: "tenant", Line: 3, Column: 3, Message: "is not a known field (expected default, tenants)"}, errs[0])
+	assert.Equal(t, `line 3, column 3: tenant is not a known field (expected default, tenants)`, errs[0].Error())
+}
+
+func TestValidate_CollectsEveryProblem(t *testing.T) {
+	content := `{"networks": {
+  "base": {
+    "USDT": {"decimals": 30, "dust_limit": "0,05"},
+    "WBTC": {"decimals": "8"},
+    "GATE": {"dust_limit": 1}
+  }
+}}`
+
+	errs := validationErrors(t, ChainRules, content)
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		`line 3, column 26: networks.base.USDT.decimals must be between 0 and 18`,
+		`line 3, column 44: networks.base.USDT.dust_limit must be a decimal number, got "0,05"`,
+		`line 4, column 26: networks.base.WBTC.decimals must be an integer`,
+		`line 5, column 13: networks.base.GATE.decimals is required`,
+	}, messages)
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     Kind
+		content  string
+		expected FieldError
+	}{
+		{
+			name:     "empty pricing rules",
+			kind:     PricingRules,
+			content:  `{}`,
+			expected: FieldError{Field: "document", Line: 1, Column: 1, Message: "must not be empty"},
+		},
+		{
+			name:     "blank expression",
+			kind:     PricingRules,
+			content:  `{"tenants": {"acme": " "}}`,
+			expected: FieldError{Field: "tenants.acme", Line: 1, Column: 22, Message: "must not be empty"},
+		},
+		{
+			name:     "wrong root type",
+			kind:     MockOverrides,
+			content:  `[]`,
+			expected: FieldError{Field: "document", Line: 1, Column: 1, Message: "must be an object"},
+		},
+		{
+			name:     "missing pairs",
+			kind:     MockOverrides,
+			content:  `{}`,
+			expected: FieldError{Field: "pairs", Line: 1, Column: 1, Message: "is required"},
+		},
+		{
+			name:     "duplicate pair",
+			kind:     MockOverrides,
+			content:  "{\"pairs\": {\n  \"USD-EUR\": 1.1,\n  \"USD-EUR\": 1.2\n}}",
+			expected: FieldError{Field: "pairs.USD-EUR", Line: 3, Column: 3, Message: "is defined more than once"},
+		},
+		{
+			name:     "boolean rate",
+			kind:     MockOverrides,
+			content:  `{"pairs": {"USD-EUR": true}}`,
+			expected: FieldError{Field: "pairs.USD-EUR", Line: 1, Column: 23, Message: "must be a number or a string"},
+		},
+		{
+			name:     "malformed json",
+			kind:     ChainRules,
+			content:  "{\"networks\": {\n  \"base\": {\n",
+			expected: FieldError{Field: "document", Line: 3, Column: 1, Message: "is not valid JSON: unexpected end of JSON input"},
+		},
+		{
+			name:     "trailing content",
+			kind:     MockOverrides,
+			content:  `{"pairs": {"USD-EUR": 1.1}} {}`,
+			expected: FieldError{Field: "document", Line: 1, Column: 29, Message: "is not valid JSON: unexpected content after the document"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validationErrors(t, tt.kind, tt.content)
+
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.expected, errs[0])
+		})
+	}
+}
+
+func TestValidate_UnknownKind(t *testing.T) {
+	err := Validate("fee_table", []byte(`{}`))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no schema for config file kind "fee_table"`)
+}
+
+func TestValidateSchema(t *testing.T) {
+	schema, err := CompileSchema([]byte(`{
+  "type": "object",
+  "properties": {
+    "status": {"type": "string", "enum": ["running", "completed"]},
+    "at": {"type": "string", "format": "date-time"}
+  }
+}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, ValidateSchema(schema, []byte(`{"status": "running", "at": "2026-10-16T08:30:00Z"}`)))
+
+	err = ValidateSchema(schema, []byte(`{"status": "done", "at": "yesterday"}`))
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "status", Line: 1, Column: 12, Message: `must be one of running, completed, got "done"`},
+		{Field: "at", Line: 1, Column: 26, Message: `must be an RFC 3339 date-time, got "yesterday"`},
+	}, validationErr.Errors)
+
+	_, err = CompileSchema([]byte(`{"type": `))
+	assert.Error(t, err)
+}
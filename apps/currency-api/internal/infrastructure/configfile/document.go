@@ -0,0 +1,168 @@
+package configfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// node is a parsed JSON value that remembers where it starts in the file, so
+// violations can point at the offending line.
+type node struct {
+	kind   string
+	offset int64
+	// key and keyOffset name object members and where their name starts.
+	key       string
+	keyOffset int64
+
+	str     string
+	number  json.Number
+	boolean bool
+
+	keys   []string
+	fields map[string]*node
+	// duplicates holds the repeats of a member name; encoding/json silently
+	// keeps the last one.
+	duplicates []*node
+	items      []*node
+}
+
+const (
+	kindObject  = "object"
+	kindArray   = "array"
+	kindString  = "string"
+	kindNumber  = "number"
+	kindBoolean = "boolean"
+	kindNull    = "null"
+)
+
+// parseError reports where a document stops being valid JSON.
+type parseError struct {
+	offset  int64
+	message string
+}
+
+func (e *parseError) Error() string {
+	return e.message
+}
+
+// parse reads a single JSON document.
+func parse(data []byte) (*node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := parseValue(dec, data)
+	if err != nil {
+		return nil, err
+	}
+	trailing := valueStart(data, dec.InputOffset())
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, &parseError{offset: trailing, message: "unexpected content after the document"}
+	}
+	return root, nil
+}
+
+func parseValue(dec *json.Decoder, data []byte) (*node, error) {
+	offset := valueStart(data, dec.InputOffset())
+	token, err := dec.Token()
+	if err != nil {
+		return nil, syntaxError(err, data)
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '[' {
+			return parseArray(dec, data, offset)
+		}
+		return parseObject(dec, data, offset)
+	case string:
+		return &node{kind: kindString, offset: offset, str: value}, nil
+	case json.Number:
+		return &node{kind: kindNumber, offset: offset, number: value}, nil
+	case bool:
+		return &node{kind: kindBoolean, offset: offset, boolean: value}, nil
+	default:
+		return &node{kind: kindNull, offset: offset}, nil
+	}
+}
+
+func parseObject(dec *json.Decoder, data []byte, offset int64) (*node, error) {
+	object := &node{kind: kindObject, offset: offset, fields: make(map[string]*node)}
+	for dec.More() {
+		keyOffset := valueStart(data, dec.InputOffset())
+		token, err := dec.Token()
+		if err != nil {
+			return nil, syntaxError(err, data)
+		}
+		key := token.(string)
+
+		member, err := parseValue(dec, data)
+		if err != nil {
+			return nil, err
+		}
+		member.key = key
+		member.keyOffset = keyOffset
+
+		if _, exists := object.fields[key]; exists {
+			object.duplicates = append(object.duplicates, member)
+		} else {
+			object.keys = append(object.keys, key)
+		}
+		object.fields[key] = member
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, syntaxError(err, data)
+	}
+	return object, nil
+}
+
+func parseArray(dec *json.Decoder, data []byte, offset int64) (*node, error) {
+	array := &node{kind: kindArray, offset: offset}
+	for dec.More() {
+		item, err := parseValue(dec, data)
+		if err != nil {
+			return nil, err
+		}
+		array.items = append(array.items, item)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, syntaxError(err, data)
+	}
+	return array, nil
+}
+
+func syntaxError(err error, data []byte) error {
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr) && syntaxErr.Offset < int64(len(data)):
+		return &parseError{offset: max(syntaxErr.Offset-1, 0), message: syntaxErr.Error()}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return &parseError{offset: int64(len(data)), message: "unexpected end of JSON input"}
+	default:
+		return &parseError{offset: int64(len(data)), message: err.Error()}
+	}
+}
+
+// valueStart skips the separators json.Decoder leaves in front of the next
+// token.
+func valueStart(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// position turns a byte offset into a 1-based line and column.
+func position(data []byte, offset int64) (int, int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -0,0 +1,249 @@
+package configfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decimalPattern matches the decimal strings shopspring/decimal accepts from
+// config files.
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Schema is the subset of JSON Schema the embedded schemas use: type,
+// properties, required, additionalProperties, minProperties, items,
+// minLength, enum (of strings), minimum, maximum and the "decimal" and
+// "date-time" formats.
+type Schema struct {
+	Type                 schemaTypes        `json:"type"`
+	Description          string             `json:"description"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	MinProperties        int                `json:"minProperties"`
+	Items                *Schema            `json:"items"`
+	MinLength            int                `json:"minLength"`
+	Enum                 []string           `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Format               string             `json:"format"`
+
+	closed     bool
+	additional *Schema
+}
+
+// schemaTypes accepts both "type": "string" and "type": ["string", "number"].
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// compile resolves additionalProperties, which is either false or a schema
+// for the members not listed in properties.
+func (s *Schema) compile() error {
+	switch strings.TrimSpace(string(s.AdditionalProperties)) {
+	case "", "true":
+	case "false":
+		s.closed = true
+	default:
+		s.additional = &Schema{}
+		if err := json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+			return fmt.Errorf("invalid additionalProperties: %w", err)
+		}
+	}
+
+	for _, child := range s.children() {
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) children() []*Schema {
+	var children []*Schema
+	for _, property := range s.Properties {
+		children = append(children, property)
+	}
+	if s.additional != nil {
+		children = append(children, s.additional)
+	}
+	if s.Items != nil {
+		children = append(children, s.Items)
+	}
+	return children
+}
+
+// validator collects every violation of a document instead of stopping at
+// the first, so one lint run shows everything to fix.
+type validator struct {
+	data   []byte
+	errors []FieldError
+}
+
+func (v *validator) fail(field string, offset int64, format string, args ...any) {
+	if field == "" {
+		field = "document"
+	}
+	line, column := position(v.data, offset)
+	v.errors = append(v.errors, FieldError{Field: field, Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(schema *Schema, value *node, field string) {
+	if !v.checkType(schema, value, field) {
+		return
+	}
+
+	switch value.kind {
+	case kindObject:
+		v.validateObject(schema, value, field)
+	case kindArray:
+		for i, item := range value.items {
+			if schema.Items != nil {
+				v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", field, i))
+			}
+		}
+	case kindString:
+		v.validateString(schema, value, field)
+	case kindNumber:
+		v.validateNumber(schema, value, field)
+	}
+}
+
+func (v *validator) checkType(schema *Schema, value *node, field string) bool {
+	if len(schema.Type) == 0 {
+		return true
+	}
+
+	for _, expected := range schema.Type {
+		if expected == value.kind || (expected == "integer" && value.kind == kindNumber && isInteger(value.number)) {
+			return true
+		}
+	}
+
+	names := make([]string, len(schema.Type))
+	for i, expected := range schema.Type {
+		names[i] = article(expected) + " " + expected
+	}
+	v.fail(field, value.offset, "must be %s", strings.Join(names, " or "))
+	return false
+}
+
+func (v *validator) validateObject(schema *Schema, value *node, field string) {
+	for _, duplicate := range value.duplicates {
+		v.fail(join(field, duplicate.key), duplicate.keyOffset, "is defined more than once")
+	}
+
+	for _, required := range schema.Required {
+		if _, exists := value.fields[required]; !exists {
+			v.fail(join(field, required), value.offset, "is required")
+		}
+	}
+
+	if len(value.keys) < schema.MinProperties {
+		if schema.MinProperties == 1 {
+			v.fail(field, value.offset, "must not be empty")
+		} else {
+			v.fail(field, value.offset, "must have at least %d entries", schema.MinProperties)
+		}
+	}
+
+	for _, key := range value.keys {
+		member := value.fields[key]
+		if property, known := schema.Properties[key]; known {
+			v.validate(property, member, join(field, key))
+			continue
+		}
+
+		switch {
+		case schema.closed:
+			v.fail(join(field, key), member.keyOffset, "is not a known field (expected %s)", strings.Join(propertyNames(schema), ", "))
+		case schema.additional != nil:
+			v.validate(schema.additional, member, join(field, key))
+		}
+	}
+}
+
+func (v *validator) validateString(schema *Schema, value *node, field string) {
+	if schema.MinLength > 0 && len(strings.TrimSpace(value.str)) < schema.MinLength {
+		if schema.MinLength == 1 {
+			v.fail(field, value.offset, "must not be empty")
+		} else {
+			v.fail(field, value.offset, "must be at least %d characters", schema.MinLength)
+		}
+	}
+
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, value.str) {
+		v.fail(field, value.offset, "must be one of %s, got %q", strings.Join(schema.Enum, ", "), value.str)
+	}
+
+	switch schema.Format {
+	case "decimal":
+		if !decimalPattern.MatchString(value.str) {
+			v.fail(field, value.offset, "must be a decimal number, got %q", value.str)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value.str); err != nil {
+			v.fail(field, value.offset, "must be an RFC 3339 date-time, got %q", value.str)
+		}
+	}
+}
+
+func (v *validator) validateNumber(schema *Schema, value *node, field string) {
+	number, err := strconv.ParseFloat(value.number.String(), 64)
+	if err != nil {
+		v.fail(field, value.offset, "must be a number")
+		return
+	}
+
+	tooLow := schema.Minimum != nil && number < *schema.Minimum
+	tooHigh := schema.Maximum != nil && number > *schema.Maximum
+	switch {
+	case (tooLow || tooHigh) && schema.Minimum != nil && schema.Maximum != nil:
+		v.fail(field, value.offset, "must be between %v and %v", *schema.Minimum, *schema.Maximum)
+	case tooLow:
+		v.fail(field, value.offset, "must be at least %v", *schema.Minimum)
+	case tooHigh:
+		v.fail(field, value.offset, "must be at most %v", *schema.Maximum)
+	}
+}
+
+func isInteger(number json.Number) bool {
+	value, err := strconv.ParseFloat(number.String(), 64)
+	return err == nil && value == math.Trunc(value)
+}
+
+func propertyNames(schema *Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func join(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func article(kind string) string {
+	if strings.ContainsRune("aeiou", rune(kind[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ajs/go-common/logger"
+)
+
+type ConsulRegistrar struct {
+	address      string
+	registration Registration
+	httpClient   *http.Client
+	logger       logger.Logger
+}
+
+type consulServiceDefinition struct {
+	ID      string             `json:"ID"`
+	Name    string             `json:"Name"`
+	Address string             `json:"Address"`
+	Port    int                `json:"Port"`
+	Meta    map[string]string  `json:"Meta,omitempty"`
+	Check   consulServiceCheck `json:"Check"`
+}
+
+type consulServiceCheck struct {
+	HTTP                           string `json:"HTTP"`
+	Interval                       string `json:"Interval"`
+	Timeout                        string `json:"Timeout"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter"`
+}
+
+func NewConsulRegistrar(address string, registration Registration, httpClient *http.Client, log logger.Logger) *ConsulRegistrar {
+	return &ConsulRegistrar{
+		address:      strings.TrimRight(address, "/"),
+		registration: registration,
+		httpClient:   httpClient,
+		logger:       log,
+	}
+}
+
+func (r *ConsulRegistrar) Register(ctx context.Context) error {
+	definition := consulServiceDefinition{
+		ID:      r.registration.ID,
+		Name:    r.registration.Name,
+		Address: r.registration.Address,
+		Port:    r.registration.Port,
+		Meta:    r.registration.Metadata,
+		Check: consulServiceCheck{
+			HTTP:                           r.registration.HealthCheckURL,
+			Interval:                       "10s",
+			Timeout:                        "5s",
+			DeregisterCriticalServiceAfter: "1m",
+		},
+	}
+
+	body, err := json.Marshal(definition)
+	if err != nil {
+		return fmt.Errorf("failed to encode consul service definition: %w", err)
+	}
+
+	if err := r.put(ctx, "/v1/agent/service/register", body); err != nil {
+		return fmt.Errorf("failed to register service with consul: %w", err)
+	}
+
+	r.logger.Info("📡 Registered service with consul",
+		"service_id", r.registration.ID,
+		"consul", r.address,
+	)
+	return nil
+}
+
+func (r *ConsulRegistrar) Deregister(ctx context.Context) error {
+	path := "/v1/agent/service/deregister/" + url.PathEscape(r.registration.ID)
+	if err := r.put(ctx, path, nil); err != nil {
+		return fmt.Errorf("failed to deregister service from consul: %w", err)
+	}
+
+	r.logger.Info("📡 Deregistered service from consul", "service_id", r.registration.ID)
+	return nil
+}
+
+func (r *ConsulRegistrar) put(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.address+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("consul returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
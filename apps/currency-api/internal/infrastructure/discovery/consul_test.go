@@ -0,0 +1,78 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistration() Registration {
+	return Registration{
+		ID:             "currency-api-host-8080",
+		Name:           "currency-api",
+		Address:        "host",
+		Port:           8080,
+		HealthCheckURL: "http://host:8080/health",
+		Metadata:       map[string]string{"environment": "test", "region": "eu"},
+	}
+}
+
+func TestConsulRegistrar_Register(t *testing.T) {
+	var received consulServiceDefinition
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/agent/service/register", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	registrar := NewConsulRegistrar(testServer.URL, testRegistration(), testServer.Client(), logger.New("error"))
+
+	err := registrar.Register(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "currency-api-host-8080", received.ID)
+	assert.Equal(t, "currency-api", received.Name)
+	assert.Equal(t, "host", received.Address)
+	assert.Equal(t, 8080, received.Port)
+	assert.Equal(t, "eu", received.Meta["region"])
+	assert.Equal(t, "http://host:8080/health", received.Check.HTTP)
+}
+
+func TestConsulRegistrar_Deregister(t *testing.T) {
+	var path string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		path = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	registrar := NewConsulRegistrar(testServer.URL, testRegistration(), testServer.Client(), logger.New("error"))
+
+	err := registrar.Deregister(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/agent/service/deregister/currency-api-host-8080", path)
+}
+
+func TestConsulRegistrar_Register_Error(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer testServer.Close()
+
+	registrar := NewConsulRegistrar(testServer.URL, testRegistration(), testServer.Client(), logger.New("error"))
+
+	err := registrar.Register(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "consul returned status 500")
+}
@@ -0,0 +1,227 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+)
+
+const etcdLeaseTTL = 30 * time.Second
+
+type EtcdRegistrar struct {
+	address           string
+	registration      Registration
+	httpClient        *http.Client
+	logger            logger.Logger
+	keepAliveInterval time.Duration
+
+	mu      sync.Mutex
+	leaseID string
+	stop    context.CancelFunc
+	done    chan struct{}
+}
+
+type etcdServiceRecord struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Address        string            `json:"address"`
+	Port           int               `json:"port"`
+	HealthCheckURL string            `json:"health_check_url"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+type etcdLeaseGrantResponse struct {
+	ID string `json:"ID"`
+}
+
+// etcdLeaseKeepAliveResponse carries the remaining TTL of the lease, which
+// etcd reports as 0, or leaves out, once the lease has expired.
+type etcdLeaseKeepAliveResponse struct {
+	Result struct {
+		TTL json.Number `json:"TTL"`
+	} `json:"result"`
+}
+
+func NewEtcdRegistrar(address string, registration Registration, httpClient *http.Client, log logger.Logger) *EtcdRegistrar {
+	return &EtcdRegistrar{
+		address:           strings.TrimRight(address, "/"),
+		registration:      registration,
+		httpClient:        httpClient,
+		logger:            log,
+		keepAliveInterval: etcdLeaseTTL / 3,
+	}
+}
+
+// Register stores the instance under /services/<name>/<id> bound to a lease
+// which is kept alive in the background until Deregister is called. Should
+// the lease expire anyway, e.g. after etcd was unreachable for longer than
+// its TTL, the instance is registered again under a new one.
+func (r *EtcdRegistrar) Register(ctx context.Context) error {
+	leaseID, err := r.put(ctx)
+	if err != nil {
+		return err
+	}
+
+	keepAliveCtx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	r.mu.Lock()
+	r.leaseID = leaseID
+	r.stop = stop
+	r.done = done
+	r.mu.Unlock()
+
+	go r.keepAlive(keepAliveCtx, leaseID, done)
+
+	r.logger.Info("📡 Registered service with etcd",
+		"service_id", r.registration.ID,
+		"key", r.key(),
+		"etcd", r.address,
+	)
+	return nil
+}
+
+// put grants a new lease and stores the service record bound to it.
+func (r *EtcdRegistrar) put(ctx context.Context) (string, error) {
+	var grant etcdLeaseGrantResponse
+	if err := r.post(ctx, "/v3/lease/grant", map[string]any{"TTL": int64(etcdLeaseTTL.Seconds())}, &grant); err != nil {
+		return "", fmt.Errorf("failed to grant etcd lease: %w", err)
+	}
+
+	record, err := json.Marshal(etcdServiceRecord{
+		ID:             r.registration.ID,
+		Name:           r.registration.Name,
+		Address:        r.registration.Address,
+		Port:           r.registration.Port,
+		HealthCheckURL: r.registration.HealthCheckURL,
+		Metadata:       r.registration.Metadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode etcd service record: %w", err)
+	}
+
+	put := map[string]any{
+		"key":   base64.StdEncoding.EncodeToString([]byte(r.key())),
+		"value": base64.StdEncoding.EncodeToString(record),
+		"lease": grant.ID,
+	}
+	if err := r.post(ctx, "/v3/kv/put", put, nil); err != nil {
+		return "", fmt.Errorf("failed to register service with etcd: %w", err)
+	}
+	return grant.ID, nil
+}
+
+func (r *EtcdRegistrar) Deregister(ctx context.Context) error {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+
+	// The keep-alive loop may replace the lease, so it is read once the loop
+	// has stopped.
+	stop()
+	<-done
+
+	r.mu.Lock()
+	leaseID := r.leaseID
+	r.leaseID = ""
+	r.mu.Unlock()
+
+	if err := r.post(ctx, "/v3/lease/revoke", map[string]any{"ID": leaseID}, nil); err != nil {
+		return fmt.Errorf("failed to deregister service from etcd: %w", err)
+	}
+
+	r.logger.Info("📡 Deregistered service from etcd", "service_id", r.registration.ID)
+	return nil
+}
+
+func (r *EtcdRegistrar) keepAlive(ctx context.Context, leaseID string, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(r.keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var keepAlive etcdLeaseKeepAliveResponse
+		if err := r.post(ctx, "/v3/lease/keepalive", map[string]any{"ID": leaseID}, &keepAlive); err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("Failed to refresh etcd lease", "service_id", r.registration.ID, "error", err)
+			}
+			continue
+		}
+		if ttl := keepAlive.Result.TTL; ttl != "" && ttl != "0" {
+			continue
+		}
+
+		r.logger.Warn("etcd lease expired, registering again", "service_id", r.registration.ID, "lease", leaseID)
+		newLeaseID, err := r.put(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("Failed to register service with etcd again", "service_id", r.registration.ID, "error", err)
+			}
+			continue
+		}
+
+		leaseID = newLeaseID
+		r.mu.Lock()
+		r.leaseID = leaseID
+		r.mu.Unlock()
+	}
+}
+
+func (r *EtcdRegistrar) key() string {
+	return fmt.Sprintf("/services/%s/%s", r.registration.Name, r.registration.ID)
+}
+
+func (r *EtcdRegistrar) post(ctx context.Context, path string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.address+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd returned status %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdRegistrar_RegisterAndDeregister(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	var putRequest map[string]string
+	var revokeRequest map[string]string
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/v3/lease/grant":
+			_, _ = w.Write([]byte(`{"ID":"694d77aa9e38260f","TTL":"30"}`))
+		case "/v3/kv/put":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&putRequest))
+			_, _ = w.Write([]byte(`{}`))
+		case "/v3/lease/revoke":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&revokeRequest))
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	registrar := NewEtcdRegistrar(testServer.URL, testRegistration(), testServer.Client(), logger.New("error"))
+	ctx := context.Background()
+
+	require.NoError(t, registrar.Register(ctx))
+	require.NoError(t, registrar.Deregister(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []string{"/v3/lease/grant", "/v3/kv/put", "/v3/lease/revoke"}, calls)
+	assert.Equal(t, "694d77aa9e38260f", putRequest["lease"])
+	assert.Equal(t, "694d77aa9e38260f", revokeRequest["ID"])
+
+	key, err := base64.StdEncoding.DecodeString(putRequest["key"])
+	require.NoError(t, err)
+	assert.Equal(t, "/services/currency-api/currency-api-host-8080", string(key))
+
+	value, err := base64.StdEncoding.DecodeString(putRequest["value"])
+	require.NoError(t, err)
+
+	var record etcdServiceRecord
+	require.NoError(t, json.Unmarshal(value, &record))
+	assert.Equal(t, "http://host:8080/health", record.HealthCheckURL)
+	assert.Equal(t, 8080, record.Port)
+}
+
+func TestEtcdRegistrar_RegistersAgainWhenTheLeaseExpires(t *testing.T) {
+	var mu sync.Mutex
+	leases := []string{"694d77aa9e38260f", "694d77aa9e382610"}
+	var grants, keepAlives int
+	var putLeases []string
+	var revoked string
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var request map[string]string
+		switch r.URL.Path {
+		case "/v3/lease/grant":
+			_, _ = w.Write([]byte(`{"ID":"` + leases[grants] + `","TTL":"30"}`))
+			grants++
+		case "/v3/kv/put":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			putLeases = append(putLeases, request["lease"])
+			_, _ = w.Write([]byte(`{}`))
+		case "/v3/lease/keepalive":
+			keepAlives++
+			if keepAlives == 2 {
+				// etcd leaves the TTL out once the lease has expired.
+				_, _ = w.Write([]byte(`{"result":{"ID":"694d77aa9e38260f"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"result":{"ID":"694d77aa9e38260f","TTL":"30"}}`))
+		case "/v3/lease/revoke":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			revoked = request["ID"]
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	registrar := NewEtcdRegistrar(testServer.URL, testRegistration(), testServer.Client(), logger.New("error"))
+	registrar.keepAliveInterval = 10 * time.Millisecond
+
+	require.NoError(t, registrar.Register(context.Background()))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(putLeases) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, registrar.Deregister(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, leases, putLeases)
+	assert.Equal(t, "694d77aa9e382610", revoked, "the current lease is revoked")
+}
+
+func TestEtcdRegistrar_Deregister_WithoutRegister(t *testing.T) {
+	registrar := NewEtcdRegistrar("http://127.0.0.1:0", testRegistration(), http.DefaultClient, logger.New("error"))
+
+	assert.NoError(t, registrar.Deregister(context.Background()))
+}
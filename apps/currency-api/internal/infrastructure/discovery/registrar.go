@@ -0,0 +1,102 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/go-common/logger"
+)
+
+type Registrar interface {
+	Register(ctx context.Context) error
+	Deregister(ctx context.Context) error
+}
+
+type Registration struct {
+	ID             string
+	Name           string
+	Address        string
+	Port           int
+	HealthCheckURL string
+	Metadata       map[string]string
+}
+
+func NewRegistrar(cfg *config.Config, log logger.Logger) (Registrar, error) {
+	if cfg.DiscoveryProvider == "" {
+		return noopRegistrar{}, nil
+	}
+
+	registration, err := newRegistration(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	switch cfg.DiscoveryProvider {
+	case "consul":
+		return NewConsulRegistrar(getAddress(cfg, "http://localhost:8500"), registration, httpClient, log), nil
+	case "etcd":
+		return NewEtcdRegistrar(getAddress(cfg, "http://localhost:2379"), registration, httpClient, log), nil
+	default:
+		return nil, fmt.Errorf("unsupported discovery provider: %s", cfg.DiscoveryProvider)
+	}
+}
+
+func newRegistration(cfg *config.Config) (Registration, error) {
+	port, err := strconv.Atoi(cfg.ReadPort)
+	if err != nil {
+		return Registration{}, fmt.Errorf("invalid port: %w", err)
+	}
+
+	address := cfg.ServiceAddress
+	if address == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return Registration{}, fmt.Errorf("failed to resolve hostname: %w", err)
+		}
+		address = hostname
+	}
+
+	metadata := map[string]string{
+		"environment": cfg.Environment,
+	}
+	for key, value := range cfg.ServiceMetadata {
+		metadata[key] = value
+	}
+	if cfg.SplitListeners() {
+		// Gateways route the endpoints that change state to this port.
+		metadata["write_port"] = cfg.WritePort
+	}
+
+	return Registration{
+		ID:             fmt.Sprintf("%s-%s-%d", cfg.ServiceName, address, port),
+		Name:           cfg.ServiceName,
+		Address:        address,
+		Port:           port,
+		HealthCheckURL: fmt.Sprintf("http://%s:%d/health", address, port),
+		Metadata:       metadata,
+	}, nil
+}
+
+func getAddress(cfg *config.Config, defaultAddress string) string {
+	if cfg.DiscoveryAddress != "" {
+		return cfg.DiscoveryAddress
+	}
+	return defaultAddress
+}
+
+type noopRegistrar struct{}
+
+func (noopRegistrar) Register(ctx context.Context) error {
+	return nil
+}
+
+func (noopRegistrar) Deregister(ctx context.Context) error {
+	return nil
+}
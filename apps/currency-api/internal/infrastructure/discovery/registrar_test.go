@@ -0,0 +1,46 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistration(t *testing.T) {
+	cfg := &config.Config{
+		ServiceName:     "currency-api",
+		ServiceAddress:  "currency-api-1",
+		ReadPort:        "8080",
+		Environment:     "production",
+		ServiceMetadata: map[string]string{"region": "eu-west-1"},
+	}
+
+	registration, err := newRegistration(cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, Registration{
+		ID:             "currency-api-currency-api-1-8080",
+		Name:           "currency-api",
+		Address:        "currency-api-1",
+		Port:           8080,
+		HealthCheckURL: "http://currency-api-1:8080/health",
+		Metadata:       map[string]string{"environment": "production", "region": "eu-west-1"},
+	}, registration)
+}
+
+func TestNewRegistration_WriteListener(t *testing.T) {
+	cfg := &config.Config{
+		ServiceName:    "currency-api",
+		ServiceAddress: "currency-api-1",
+		ReadPort:       "8080",
+		WritePort:      "8081",
+	}
+
+	registration, err := newRegistration(cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, 8080, registration.Port)
+	assert.Equal(t, "8081", registration.Metadata["write_port"])
+}
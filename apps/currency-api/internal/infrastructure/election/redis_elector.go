@@ -0,0 +1,216 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// Job is background work that must run on exactly one instance. Its context
+// is cancelled as soon as the instance loses leadership or shuts down.
+type Job func(ctx context.Context)
+
+// Status describes this instance's view of the current leadership.
+// Leader is empty while no instance holds the lease. Jobs names the jobs
+// the leader runs, sorted.
+type Status struct {
+	InstanceID string
+	Leader     string
+	IsLeader   bool
+	Since      time.Time
+	Jobs       []string
+}
+
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisElector elects a single leader among instances sharing a Redis key.
+// The leader holds the key as a lease and renews it every third of the TTL;
+// if it stops renewing, another instance takes over once the lease expires.
+type RedisElector struct {
+	client     redis.UniversalClient
+	key        string
+	instanceID string
+	ttl        time.Duration
+	logger     logger.Logger
+	now        func() time.Time
+
+	mu     sync.Mutex
+	jobs   map[string]Job
+	status Status
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewRedisElector(client redis.UniversalClient, key, instanceID string, ttl time.Duration, log logger.Logger) *RedisElector {
+	return &RedisElector{
+		client:     client,
+		key:        key,
+		instanceID: instanceID,
+		ttl:        ttl,
+		logger:     log,
+		now:        time.Now,
+		jobs:       make(map[string]Job),
+		status:     Status{InstanceID: instanceID},
+	}
+}
+
+// Register adds a job that runs only while this instance is the leader.
+// Jobs must be registered before Run.
+func (e *RedisElector) Register(name string, job Job) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.jobs[name] = job
+}
+
+// Leadership returns the result of the last election round.
+func (e *RedisElector) Leadership() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	status := e.status
+	status.Jobs = make([]string, 0, len(e.jobs))
+	for name := range e.jobs {
+		status.Jobs = append(status.Jobs, name)
+	}
+	sort.Strings(status.Jobs)
+	return status
+}
+
+// Run campaigns immediately and then every third of the lease TTL until ctx
+// is done. A round in progress is not interrupted by ctx, so shutting down
+// never looks like a lost lease.
+func (e *RedisElector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(context.Background())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops the leader-only jobs and releases the lease so another instance
+// can take over without waiting for it to expire. Stop Run first, or it may
+// win the lease back.
+func (e *RedisElector) Close() error {
+	wasLeader := e.Leadership().IsLeader
+	e.resign()
+
+	if !wasLeader {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+	defer cancel()
+	return releaseScript.Run(ctx, e.client, []string{e.key}, e.instanceID).Err()
+}
+
+// campaign runs a single election round: the leader renews its lease,
+// everyone else tries to acquire it.
+func (e *RedisElector) campaign(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+
+	isLeader, err := e.holdLease(ctx)
+	if err != nil {
+		// Without Redis we cannot prove the lease is still ours, so step down
+		// rather than risk two leaders.
+		e.logger.Error("Leader election round failed", err, "instance", e.instanceID)
+		e.resign()
+		e.setLeader("")
+		return
+	}
+
+	if isLeader {
+		e.elect()
+		e.setLeader(e.instanceID)
+		return
+	}
+
+	e.resign()
+	leader, err := e.client.Get(ctx, e.key).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		e.logger.Error("Failed to read current leader", err)
+	}
+	e.setLeader(leader)
+}
+
+func (e *RedisElector) holdLease(ctx context.Context) (bool, error) {
+	if e.Leadership().IsLeader {
+		renewed, err := renewScript.Run(ctx, e.client, []string{e.key}, e.instanceID, e.ttl.Milliseconds()).Int()
+		if err != nil {
+			return false, err
+		}
+		if renewed == 1 {
+			return true, nil
+		}
+	}
+
+	return e.client.SetNX(ctx, e.key, e.instanceID, e.ttl).Result()
+}
+
+func (e *RedisElector) elect() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.status.IsLeader {
+		return
+	}
+
+	e.logger.Info("👑 Elected leader, starting background jobs", "instance", e.instanceID, "jobs", len(e.jobs))
+	e.status.IsLeader = true
+	e.status.Since = e.now()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	for _, job := range e.jobs {
+		e.wg.Add(1)
+		go func(job Job) {
+			defer e.wg.Done()
+			job(ctx)
+		}(job)
+	}
+}
+
+func (e *RedisElector) resign() {
+	e.mu.Lock()
+	if !e.status.IsLeader {
+		e.mu.Unlock()
+		return
+	}
+
+	e.logger.Warn("Lost leadership, stopping background jobs", "instance", e.instanceID)
+	e.status.IsLeader = false
+	e.status.Since = time.Time{}
+	e.cancel()
+	e.mu.Unlock()
+
+	e.wg.Wait()
+}
+
+func (e *RedisElector) setLeader(leader string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.Leader = leader
+}
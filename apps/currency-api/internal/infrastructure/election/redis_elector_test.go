@@ -0,0 +1,114 @@
+package election
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "currency-api:leader"
+
+func newTestElector(t *testing.T, server *miniredis.Miniredis, instanceID string) *RedisElector {
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisElector(client, testKey, instanceID, 15*time.Second, logger.New("error"))
+}
+
+func TestRedisElector_SingleLeader(t *testing.T) {
+	server := miniredis.RunT(t)
+	first := newTestElector(t, server, "instance-a")
+	second := newTestElector(t, server, "instance-b")
+
+	first.campaign(context.Background())
+	second.campaign(context.Background())
+
+	assert.True(t, first.Leadership().IsLeader)
+	assert.False(t, second.Leadership().IsLeader)
+	assert.Equal(t, "instance-a", first.Leadership().Leader)
+	assert.Equal(t, "instance-a", second.Leadership().Leader)
+	assert.Equal(t, "instance-b", second.Leadership().InstanceID)
+
+	first.campaign(context.Background())
+	assert.True(t, first.Leadership().IsLeader, "leader keeps the lease by renewing it")
+	assert.Equal(t, 15*time.Second, server.TTL(testKey))
+}
+
+func TestRedisElector_Failover(t *testing.T) {
+	server := miniredis.RunT(t)
+	first := newTestElector(t, server, "instance-a")
+	second := newTestElector(t, server, "instance-b")
+
+	first.campaign(context.Background())
+	second.campaign(context.Background())
+	require.True(t, first.Leadership().IsLeader)
+
+	// instance-a stops renewing, e.g. because it is stuck or partitioned.
+	server.FastForward(16 * time.Second)
+	second.campaign(context.Background())
+	assert.True(t, second.Leadership().IsLeader)
+
+	first.campaign(context.Background())
+	assert.False(t, first.Leadership().IsLeader, "a stale leader must step down once its lease is taken")
+	assert.Equal(t, "instance-b", first.Leadership().Leader)
+}
+
+func TestRedisElector_JobsRunOnlyWhileLeader(t *testing.T) {
+	server := miniredis.RunT(t)
+	elector := newTestElector(t, server, "instance-a")
+
+	var running atomic.Int32
+	started := make(chan struct{})
+	elector.Register("alerts", func(ctx context.Context) {
+		running.Add(1)
+		close(started)
+		<-ctx.Done()
+		running.Add(-1)
+	})
+
+	elector.campaign(context.Background())
+	<-started
+	assert.Equal(t, int32(1), running.Load())
+	assert.Equal(t, []string{"alerts"}, elector.Leadership().Jobs)
+
+	server.Set(testKey, "instance-b")
+	elector.campaign(context.Background())
+
+	assert.False(t, elector.Leadership().IsLeader)
+	assert.Equal(t, int32(0), running.Load(), "jobs are stopped when leadership is lost")
+}
+
+func TestRedisElector_StepsDownWhenRedisIsUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	elector := newTestElector(t, server, "instance-a")
+
+	elector.campaign(context.Background())
+	require.True(t, elector.Leadership().IsLeader)
+
+	server.Close()
+	elector.campaign(context.Background())
+
+	assert.False(t, elector.Leadership().IsLeader)
+	assert.Empty(t, elector.Leadership().Leader)
+}
+
+func TestRedisElector_CloseReleasesLease(t *testing.T) {
+	server := miniredis.RunT(t)
+	first := newTestElector(t, server, "instance-a")
+	second := newTestElector(t, server, "instance-b")
+
+	first.campaign(context.Background())
+	require.True(t, first.Leadership().IsLeader)
+
+	require.NoError(t, first.Close())
+	assert.False(t, server.Exists(testKey))
+
+	second.campaign(context.Background())
+	assert.True(t, second.Leadership().IsLeader)
+}
@@ -0,0 +1,53 @@
+package encoding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	EncoderStd      = "std"
+	EncoderJSONIter = "jsoniter"
+)
+
+// JSONEncoder serializes response bodies. Every implementation must produce
+// the same bytes as encoding/json so switching encoders is invisible to clients.
+type JSONEncoder interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+}
+
+func NewJSONEncoder(name string) (JSONEncoder, error) {
+	switch name {
+	case "", EncoderStd:
+		return stdEncoder{}, nil
+	case EncoderJSONIter:
+		return jsoniterEncoder{api: jsoniter.ConfigCompatibleWithStandardLibrary}, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON encoder: %s", name)
+	}
+}
+
+type stdEncoder struct{}
+
+func (stdEncoder) Name() string {
+	return EncoderStd
+}
+
+func (stdEncoder) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+type jsoniterEncoder struct {
+	api jsoniter.API
+}
+
+func (jsoniterEncoder) Name() string {
+	return EncoderJSONIter
+}
+
+func (e jsoniterEncoder) Marshal(v any) ([]byte, error) {
+	return e.api.Marshal(v)
+}
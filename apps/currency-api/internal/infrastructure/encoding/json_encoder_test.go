@@ -0,0 +1,120 @@
+package encoding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ratesPayload struct {
+	SourceInfo string                  `json:"source_info"`
+	Rates      []entities.ExchangeRate `json:"rates"`
+	LagSeconds *float64                `json:"replication_lag_seconds,omitempty"`
+}
+
+// rateMatrix builds the payload of GET /api/v1/rates for n currencies, which
+// returns every ordered pair.
+func rateMatrix(n int) ratesPayload {
+	currencies := make([]string, n)
+	for i := range currencies {
+		currencies[i] = fmt.Sprintf("C%02d", i)
+	}
+
+	payload := ratesPayload{SourceInfo: "🔑 API key provided: Using live rates <live>"}
+	for i, from := range currencies {
+		for j, to := range currencies {
+			if i == j {
+				continue
+			}
+			rate := decimal.NewFromInt(int64(j + 1)).Div(decimal.NewFromInt(int64(i + 1)))
+			payload.Rates = append(payload.Rates, entities.ExchangeRate{From: from, To: to, Rate: rate, Mocked: (i+j)%7 == 0})
+		}
+	}
+	return payload
+}
+
+func TestNewJSONEncoder(t *testing.T) {
+	for _, name := range []string{EncoderStd, EncoderJSONIter} {
+		encoder, err := NewJSONEncoder(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, encoder.Name())
+	}
+
+	_, err := NewJSONEncoder("sonic")
+	assert.EqualError(t, err, "unsupported JSON encoder: sonic")
+}
+
+func TestJSONEncoders_MatchStandardLibrary(t *testing.T) {
+	std, err := NewJSONEncoder(EncoderStd)
+	require.NoError(t, err)
+	jsoniter, err := NewJSONEncoder(EncoderJSONIter)
+	require.NoError(t, err)
+
+	lag := 12.5
+	payloads := map[string]any{
+		"rate matrix":    rateMatrix(12),
+		"optional field": ratesPayload{SourceInfo: "replica", LagSeconds: &lag},
+		"map":            map[string]any{"b": decimal.RequireFromString("0.000000012"), "a": []string{"x"}},
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			expected, err := std.Marshal(payload)
+			require.NoError(t, err)
+
+			actual, err := jsoniter.Marshal(payload)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestMetrics_Instrument(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(registry)
+	std, err := NewJSONEncoder(EncoderStd)
+	require.NoError(t, err)
+
+	encoder := metrics.Instrument(std, "rates")
+	data, err := encoder.Marshal(rateMatrix(3))
+
+	require.NoError(t, err)
+	assert.Equal(t, EncoderStd, encoder.Name())
+	assert.NotEmpty(t, data)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+	for _, family := range families {
+		require.Len(t, family.GetMetric(), 1, family.GetName())
+		assert.Equal(t, uint64(1), family.GetMetric()[0].GetHistogram().GetSampleCount(), family.GetName())
+	}
+}
+
+func BenchmarkJSONEncoders(b *testing.B) {
+	for _, currencies := range []int{10, 50, 170} {
+		payload := rateMatrix(currencies)
+
+		for _, name := range []string{EncoderStd, EncoderJSONIter} {
+			encoder, err := NewJSONEncoder(name)
+			require.NoError(b, err)
+
+			b.Run(fmt.Sprintf("%s/%d_currencies", name, currencies), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					data, err := encoder.Marshal(payload)
+					if err != nil {
+						b.Fatal(err)
+					}
+					b.SetBytes(int64(len(data)))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package encoding
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics records how long response serialization takes and how large the
+// bodies are, per encoder and endpoint. Decimal rates marshal through
+// MarshalJSON, which makes large rate matrices the dominant encoding cost.
+type Metrics struct {
+	duration *prometheus.HistogramVec
+	size     *prometheus.HistogramVec
+}
+
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "currency_api_json_encode_duration_seconds",
+			Help:    "Time spent serializing response bodies.",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 4, 9),
+		}, []string{"encoder", "endpoint"}),
+		size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "currency_api_json_encoded_bytes",
+			Help:    "Size of serialized response bodies.",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 9),
+		}, []string{"encoder", "endpoint"}),
+	}
+
+	registerer.MustRegister(m.duration, m.size)
+	return m
+}
+
+// Instrument wraps encoder so every Marshal is recorded under endpoint.
+func (m *Metrics) Instrument(encoder JSONEncoder, endpoint string) JSONEncoder {
+	return instrumentedEncoder{
+		JSONEncoder: encoder,
+		duration:    m.duration.WithLabelValues(encoder.Name(), endpoint),
+		size:        m.size.WithLabelValues(encoder.Name(), endpoint),
+	}
+}
+
+type instrumentedEncoder struct {
+	JSONEncoder
+	duration prometheus.Observer
+	size     prometheus.Observer
+}
+
+func (e instrumentedEncoder) Marshal(v any) ([]byte, error) {
+	start := time.Now()
+	data, err := e.JSONEncoder.Marshal(v)
+	e.duration.Observe(time.Since(start).Seconds())
+	if err == nil {
+		e.size.Observe(float64(len(data)))
+	}
+	return data, err
+}
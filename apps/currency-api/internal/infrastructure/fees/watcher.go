@@ -0,0 +1,170 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Watcher keeps the fee schedule versions in memory so conversions never
+// wait on the store, and reloads them whenever a version is published. The
+// version in effect is picked per call, so staged versions apply on time
+// without another reload.
+type Watcher struct {
+	repo   repositories.FeeScheduleRepository
+	resync time.Duration
+	log    logger.Logger
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	versions []entities.FeeSchedule
+
+	reloads *prometheus.CounterVec
+}
+
+// NewWatcher reloads on every change signal and additionally every resync,
+// which catches up on signals lost while the store was unreachable. A zero
+// resync disables the periodic reload.
+func NewWatcher(repo repositories.FeeScheduleRepository, resync time.Duration, registerer prometheus.Registerer, log logger.Logger) *Watcher {
+	w := &Watcher{
+		repo:   repo,
+		resync: resync,
+		log:    log,
+		now:    time.Now,
+		reloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_fee_schedule_reloads_total",
+			Help: "Fee schedule reloads by result (success, failure).",
+		}, []string{"result"}),
+	}
+
+	registerer.MustRegister(w.reloads, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "currency_api_fee_schedule_version",
+		Help: "Version of the fee schedule in effect (0 when none is).",
+	}, func() float64 {
+		schedule, _ := w.Current()
+		return float64(schedule.Version)
+	}))
+
+	return w
+}
+
+// Run watches for published versions until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	changes, err := w.repo.Changes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch fee schedules: %w", err)
+	}
+	// Loading after subscribing cannot miss a version published in between.
+	w.reload(ctx)
+
+	var resync <-chan time.Time
+	if w.resync > 0 {
+		ticker := time.NewTicker(w.resync)
+		defer ticker.Stop()
+		resync = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.New("fee schedule change feed closed")
+			}
+			w.reload(ctx)
+		case <-resync:
+			w.reload(ctx)
+		}
+	}
+}
+
+// Reload loads the stored versions now.
+func (w *Watcher) Reload(ctx context.Context) error {
+	versions, err := w.repo.Versions(ctx)
+	if err != nil {
+		w.reloads.WithLabelValues("failure").Inc()
+		return err
+	}
+	w.reloads.WithLabelValues("success").Inc()
+
+	w.mu.Lock()
+	previous, _ := w.currentLocked()
+	w.versions = versions
+	current, ok := w.currentLocked()
+	w.mu.Unlock()
+
+	if ok && current.Version != previous.Version {
+		w.log.Info("Fee schedule applied", "version", current.Version, "effective_from", current.EffectiveFrom, "published_by", current.PublishedBy)
+	}
+	return nil
+}
+
+// reload keeps the versions already loaded when the store fails, so fees
+// stay in force through an outage.
+func (w *Watcher) reload(ctx context.Context) {
+	if err := w.Reload(ctx); err != nil {
+		w.log.Error("Failed to reload fee schedules", err)
+	}
+}
+
+// Current returns the newest version whose effective time has passed.
+func (w *Watcher) Current() (entities.FeeSchedule, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.currentLocked()
+}
+
+// Pending returns the versions that take effect later, soonest first. A
+// version published after a staged one supersedes it if it takes effect no
+// later, so the staged one is not listed.
+func (w *Watcher) Pending() []entities.FeeSchedule {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	now := w.now()
+	current, _ := w.currentLocked()
+
+	pending := []entities.FeeSchedule{}
+	for i, schedule := range w.versions {
+		if !schedule.EffectiveFrom.After(now) || schedule.Version < current.Version || superseded(schedule, w.versions[i+1:]) {
+			continue
+		}
+		pending = append(pending, schedule)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].EffectiveFrom.Before(pending[j].EffectiveFrom)
+	})
+	return pending
+}
+
+func (w *Watcher) currentLocked() (entities.FeeSchedule, bool) {
+	now := w.now()
+	for i := len(w.versions) - 1; i >= 0; i-- {
+		if !w.versions[i].EffectiveFrom.After(now) {
+			return w.versions[i], true
+		}
+	}
+	return entities.FeeSchedule{}, false
+}
+
+func superseded(schedule entities.FeeSchedule, later []entities.FeeSchedule) bool {
+	for _, newer := range later {
+		if !newer.EffectiveFrom.After(schedule.EffectiveFrom) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	domainrepositories "github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestWatcher(repo domainrepositories.FeeScheduleRepository) *Watcher {
+	w := NewWatcher(repo, 0, prometheus.NewRegistry(), logger.New("error"))
+	w.now = func() time.Time { return testNow }
+	return w
+}
+
+func publish(t *testing.T, repo domainrepositories.FeeScheduleRepository, effectiveFrom time.Time) int64 {
+	t.Helper()
+
+	schedule, err := repo.Publish(context.Background(), entities.FeeSchedule{EffectiveFrom: effectiveFrom, PublishedBy: "ops"}, entities.FeeAuditEntry{Actor: "ops"})
+	require.NoError(t, err)
+	return schedule.Version
+}
+
+func TestWatcher_CurrentAndPending(t *testing.T) {
+	repo := repositories.NewMemoryFeeScheduleRepository()
+	watcher := newTestWatcher(repo)
+
+	require.NoError(t, watcher.Reload(context.Background()))
+	_, ok := watcher.Current()
+	assert.False(t, ok)
+	assert.Empty(t, watcher.Pending())
+
+	publish(t, repo, testNow.Add(-time.Hour))
+	live := publish(t, repo, testNow)
+	staged := publish(t, repo, testNow.Add(2*time.Hour))
+	publish(t, repo, testNow.Add(3*time.Hour))
+	// Takes effect before the previous version, which can never apply now.
+	replacement := publish(t, repo, testNow.Add(time.Hour))
+	require.NoError(t, watcher.Reload(context.Background()))
+
+	current, ok := watcher.Current()
+	require.True(t, ok)
+	assert.Equal(t, live, current.Version)
+
+	var pending []int64
+	for _, schedule := range watcher.Pending() {
+		pending = append(pending, schedule.Version)
+	}
+	assert.Equal(t, []int64{replacement}, pending)
+	assert.NotContains(t, pending, staged)
+
+	watcher.now = func() time.Time { return testNow.Add(90 * time.Minute) }
+	current, _ = watcher.Current()
+	assert.Equal(t, replacement, current.Version)
+	assert.Empty(t, watcher.Pending())
+}
+
+func TestWatcher_RunAppliesPublishedVersions(t *testing.T) {
+	repo := repositories.NewMemoryFeeScheduleRepository()
+	watcher := newTestWatcher(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(watcher.reloads.WithLabelValues("success")) == 1
+	}, time.Second, time.Millisecond)
+
+	version := publish(t, repo, testNow)
+	require.Eventually(t, func() bool {
+		current, ok := watcher.Current()
+		return ok && current.Version == version
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+type failingRepository struct {
+	domainrepositories.FeeScheduleRepository
+}
+
+func (failingRepository) Versions(context.Context) ([]entities.FeeSchedule, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestWatcher_KeepsVersionsWhenReloadFails(t *testing.T) {
+	repo := repositories.NewMemoryFeeScheduleRepository()
+	version := publish(t, repo, testNow)
+	watcher := newTestWatcher(repo)
+	require.NoError(t, watcher.Reload(context.Background()))
+
+	watcher.repo = failingRepository{repo}
+	require.Error(t, watcher.Reload(context.Background()))
+
+	current, ok := watcher.Current()
+	require.True(t, ok)
+	assert.Equal(t, version, current.Version)
+	assert.Equal(t, float64(1), testutil.ToFloat64(watcher.reloads.WithLabelValues("failure")))
+}
@@ -0,0 +1,202 @@
+package freshness
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	AssetClassFiat   = "fiat"
+	AssetClassCrypto = "crypto"
+)
+
+// ClassStatus is the freshness of one asset class at the last check.
+// LastUpdated is zero until rates for the class have been observed.
+type ClassStatus struct {
+	AssetClass  string
+	Objective   time.Duration
+	LastUpdated time.Time
+	Age         time.Duration
+	Violation   bool
+	BurnRate    float64
+}
+
+// Tracker measures how old the newest rates snapshot of each asset class is
+// and how fast the freshness SLO's error budget is being consumed.
+type Tracker struct {
+	objectives map[string]time.Duration
+	target     float64
+	interval   time.Duration
+	samples    int
+	now        func() time.Time
+
+	mu          sync.Mutex
+	lastUpdated map[string]time.Time
+	history     map[string][]bool
+	status      map[string]ClassStatus
+
+	ageGauge       *prometheus.GaugeVec
+	objectiveGauge *prometheus.GaugeVec
+	burnRateGauge  *prometheus.GaugeVec
+	checks         *prometheus.CounterVec
+}
+
+func NewTracker(objectives map[string]time.Duration, target float64, window, interval time.Duration, registerer prometheus.Registerer) *Tracker {
+	samples := 1
+	if interval > 0 && window > interval {
+		samples = int(window / interval)
+	}
+
+	t := &Tracker{
+		objectives:  objectives,
+		target:      target,
+		interval:    interval,
+		samples:     samples,
+		now:         time.Now,
+		lastUpdated: make(map[string]time.Time),
+		history:     make(map[string][]bool),
+		status:      make(map[string]ClassStatus),
+		ageGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "currency_api_rates_snapshot_age_seconds",
+			Help: "Age of the newest rates snapshot per asset class.",
+		}, []string{"asset_class"}),
+		objectiveGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "currency_api_freshness_slo_objective_seconds",
+			Help: "Maximum snapshot age allowed by the freshness SLO per asset class.",
+		}, []string{"asset_class"}),
+		burnRateGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "currency_api_freshness_slo_burn_rate",
+			Help: "Rate at which the freshness error budget is consumed over the SLO window (1 = exactly on budget).",
+		}, []string{"asset_class"}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_freshness_slo_checks_total",
+			Help: "Freshness checks per asset class by result (fresh, stale).",
+		}, []string{"asset_class", "result"}),
+	}
+
+	registerer.MustRegister(t.ageGauge, t.objectiveGauge, t.burnRateGauge, t.checks)
+	for class, objective := range objectives {
+		t.objectiveGauge.WithLabelValues(class).Set(objective.Seconds())
+	}
+
+	return t
+}
+
+// AssetClassOf classifies a currency code; the supported tokens are crypto,
+// everything else is treated as fiat.
+func AssetClassOf(currency string) string {
+	if _, exists := entities.CryptoCurrencies[currency]; exists {
+		return AssetClassCrypto
+	}
+	return AssetClassFiat
+}
+
+// Observe records that rates for the given currencies were refreshed at at.
+func (t *Tracker) Observe(currencies []string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, currency := range currencies {
+		class := AssetClassOf(currency)
+		if at.After(t.lastUpdated[class]) {
+			t.lastUpdated[class] = at
+		}
+	}
+}
+
+// Check samples the snapshot age of every asset class with an objective.
+// Classes that have never been observed are not counted against the SLO.
+func (t *Tracker) Check() {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for class, objective := range t.objectives {
+		status := ClassStatus{AssetClass: class, Objective: objective}
+
+		lastUpdated, observed := t.lastUpdated[class]
+		if observed {
+			status.LastUpdated = lastUpdated
+			status.Age = now.Sub(lastUpdated)
+			status.Violation = status.Age > objective
+
+			result := "fresh"
+			if status.Violation {
+				result = "stale"
+			}
+			t.checks.WithLabelValues(class, result).Inc()
+			t.ageGauge.WithLabelValues(class).Set(status.Age.Seconds())
+
+			history := append(t.history[class], status.Violation)
+			if len(history) > t.samples {
+				history = history[len(history)-t.samples:]
+			}
+			t.history[class] = history
+		}
+
+		status.BurnRate = t.burnRate(t.history[class])
+		t.burnRateGauge.WithLabelValues(class).Set(status.BurnRate)
+		t.status[class] = status
+	}
+}
+
+// Status returns the result of the last check, sorted by asset class.
+func (t *Tracker) Status() []ClassStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]ClassStatus, 0, len(t.status))
+	for _, status := range t.status {
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AssetClass < result[j].AssetClass
+	})
+	return result
+}
+
+// Run checks every interval until ctx is done. Call Check first for a status
+// before the first tick; without an interval Run only waits for ctx.
+func (t *Tracker) Run(ctx context.Context) error {
+	if t.interval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			t.Check()
+		}
+	}
+}
+
+func (t *Tracker) burnRate(history []bool) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+
+	violations := 0
+	for _, violation := range history {
+		if violation {
+			violations++
+		}
+	}
+
+	budget := 1 - t.target
+	if budget <= 0 {
+		budget = 1
+	}
+	return float64(violations) / float64(len(history)) / budget
+}
@@ -0,0 +1,111 @@
+package freshness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker(t *testing.T, now *time.Time) *Tracker {
+	tracker := NewTracker(
+		map[string]time.Duration{AssetClassFiat: time.Hour, AssetClassCrypto: time.Minute},
+		0.9,
+		4*time.Minute,
+		time.Minute,
+		prometheus.NewRegistry(),
+	)
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestAssetClassOf(t *testing.T) {
+	assert.Equal(t, AssetClassCrypto, AssetClassOf("WBTC"))
+	assert.Equal(t, AssetClassFiat, AssetClassOf("EUR"))
+}
+
+func TestTracker_Check(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+
+	tracker.Observe([]string{"USD", "EUR"}, now.Add(-30*time.Minute))
+	tracker.Observe([]string{"WBTC"}, now.Add(-2*time.Minute))
+	tracker.Check()
+
+	statuses := tracker.Status()
+	require.Len(t, statuses, 2)
+
+	crypto, fiat := statuses[0], statuses[1]
+	assert.Equal(t, AssetClassCrypto, crypto.AssetClass)
+	assert.True(t, crypto.Violation)
+	assert.Equal(t, 2*time.Minute, crypto.Age)
+	assert.InDelta(t, 10.0, crypto.BurnRate, 1e-9)
+
+	assert.Equal(t, AssetClassFiat, fiat.AssetClass)
+	assert.False(t, fiat.Violation)
+	assert.Equal(t, 30*time.Minute, fiat.Age)
+	assert.Zero(t, fiat.BurnRate)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(tracker.checks.WithLabelValues(AssetClassCrypto, "stale")))
+	assert.Equal(t, 1800.0, testutil.ToFloat64(tracker.ageGauge.WithLabelValues(AssetClassFiat)))
+	assert.Equal(t, 60.0, testutil.ToFloat64(tracker.objectiveGauge.WithLabelValues(AssetClassCrypto)))
+}
+
+func TestTracker_BurnRateUsesRollingWindow(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+
+	tracker.Observe([]string{"WBTC"}, now.Add(-5*time.Minute))
+	tracker.Check()
+
+	for i := 0; i < 4; i++ {
+		now = now.Add(time.Minute)
+		tracker.Observe([]string{"WBTC"}, now)
+		tracker.Check()
+	}
+
+	crypto := tracker.Status()[0]
+	assert.False(t, crypto.Violation)
+	assert.Zero(t, crypto.BurnRate, "stale sample should have left the 4 sample window")
+
+	now = now.Add(2 * time.Minute)
+	tracker.Check()
+
+	crypto = tracker.Status()[0]
+	assert.True(t, crypto.Violation)
+	assert.InDelta(t, 2.5, crypto.BurnRate, 1e-9)
+}
+
+func TestTracker_UnobservedClassIsNotAViolation(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+
+	tracker.Check()
+
+	for _, status := range tracker.Status() {
+		assert.False(t, status.Violation)
+		assert.True(t, status.LastUpdated.IsZero())
+		assert.Zero(t, status.BurnRate)
+	}
+}
+
+func TestTracker_Run(t *testing.T) {
+	tracker := NewTracker(map[string]time.Duration{AssetClassFiat: time.Hour}, 0.99, time.Hour, time.Millisecond, prometheus.NewRegistry())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- tracker.Run(ctx) }()
+	tracker.Observe([]string{"EUR"}, time.Now())
+
+	require.Eventually(t, func() bool {
+		status := tracker.Status()
+		return len(status) == 1 && !status[0].LastUpdated.IsZero()
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
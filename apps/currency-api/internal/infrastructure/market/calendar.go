@@ -0,0 +1,97 @@
+package market
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+// The fiat market trades around the clock from Sunday 17:00 to Friday 17:00
+// New York time. A trading day is named after the date on which it ends, so
+// it runs from 17:00 on the previous day to 17:00 on that date; Saturday and
+// Sunday trading days and holidays are closed.
+const (
+	sessionTimeZone = "America/New_York"
+	sessionRollover = 17 * time.Hour
+)
+
+// Calendar derives the fiat market state from the weekly trading hours and
+// a list of yearly holidays.
+type Calendar struct {
+	location       *time.Location
+	holidays       map[string]bool
+	updateInterval time.Duration
+}
+
+// NewCalendar returns a calendar closed on the given holidays ("MM-DD").
+// While the market is open rates are expected to change every
+// updateInterval, aligned to the clock.
+func NewCalendar(holidays []string, updateInterval time.Duration) (*Calendar, error) {
+	location, err := time.LoadLocation(sessionTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market time zone: %w", err)
+	}
+
+	c := &Calendar{
+		location:       location,
+		holidays:       make(map[string]bool, len(holidays)),
+		updateInterval: updateInterval,
+	}
+	for _, holiday := range holidays {
+		if _, err := time.Parse("01-02", holiday); err != nil {
+			return nil, fmt.Errorf("invalid market holiday %q: %w", holiday, err)
+		}
+		c.holidays[holiday] = true
+	}
+	return c, nil
+}
+
+func (c *Calendar) State(at time.Time) entities.MarketState {
+	if !c.isOpen(c.tradingDay(at)) {
+		nextOpen := c.nextOpen(at)
+		return entities.MarketState{
+			Status:       entities.MarketClosed,
+			NextUpdateAt: nextOpen,
+			NextOpen:     nextOpen,
+		}
+	}
+
+	state := entities.MarketState{Status: entities.MarketOpen}
+	if c.updateInterval <= 0 {
+		return state
+	}
+
+	next := at.Truncate(c.updateInterval).Add(c.updateInterval)
+	if !c.isOpen(c.tradingDay(next)) {
+		next = c.nextOpen(next)
+	}
+	state.NextUpdateAt = next.UTC()
+	return state
+}
+
+// tradingDay returns midnight of the trading day that at belongs to, in the
+// market time zone.
+func (c *Calendar) tradingDay(at time.Time) time.Time {
+	shifted := at.In(c.location).Add(24*time.Hour - sessionRollover)
+	return time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, c.location)
+}
+
+func (c *Calendar) isOpen(day time.Time) bool {
+	switch day.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.holidays[day.Format("01-02")]
+}
+
+// nextOpen returns the start of the first open trading day after at.
+func (c *Calendar) nextOpen(at time.Time) time.Time {
+	day := c.tradingDay(at)
+	for !c.isOpen(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	previous := day.AddDate(0, 0, -1)
+	return time.Date(previous.Year(), previous.Month(), previous.Day(), int(sessionRollover.Hours()), 0, 0, 0, c.location).UTC()
+}
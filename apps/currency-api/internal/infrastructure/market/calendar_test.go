@@ -0,0 +1,69 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_State(t *testing.T) {
+	calendar, err := NewCalendar([]string{"01-01", "12-25"}, time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected entities.MarketState
+	}{
+		{
+			name:     "weekday",
+			at:       time.Date(2026, 10, 16, 12, 20, 0, 0, time.UTC),
+			expected: entities.MarketState{Status: entities.MarketOpen, NextUpdateAt: time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "last hour before the weekly close",
+			at:   time.Date(2026, 10, 16, 20, 30, 0, 0, time.UTC),
+			expected: entities.MarketState{
+				Status:       entities.MarketOpen,
+				NextUpdateAt: time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "weekend",
+			at:   time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			expected: entities.MarketState{
+				Status:       entities.MarketClosed,
+				NextUpdateAt: time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC),
+				NextOpen:     time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "sunday after the open",
+			at:       time.Date(2026, 10, 18, 21, 30, 0, 0, time.UTC),
+			expected: entities.MarketState{Status: entities.MarketOpen, NextUpdateAt: time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "holiday before a weekend",
+			at:   time.Date(2026, 12, 24, 22, 0, 0, 0, time.UTC),
+			expected: entities.MarketState{
+				Status:       entities.MarketClosed,
+				NextUpdateAt: time.Date(2026, 12, 27, 22, 0, 0, 0, time.UTC),
+				NextOpen:     time.Date(2026, 12, 27, 22, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calendar.State(tt.at))
+		})
+	}
+}
+
+func TestNewCalendar_InvalidHoliday(t *testing.T) {
+	_, err := NewCalendar([]string{"13-01"}, time.Hour)
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+const chatRequestTimeout = 10 * time.Second
+
+// chatTemplates renders the short messages shared by the chat channels.
+type chatTemplates map[entities.NotificationKind]*template.Template
+
+func parseChatTemplates() (chatTemplates, error) {
+	templates := make(chatTemplates)
+	for _, kind := range []entities.NotificationKind{entities.NotificationRateAlert, entities.NotificationDailyDigest} {
+		tmpl, err := template.ParseFS(templateFiles, "templates/chat_"+string(kind)+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s chat template: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+	return templates, nil
+}
+
+func (t chatTemplates) render(notification entities.Notification) (string, error) {
+	if notification.Template != "" {
+		return tenantTemplates.Render(notification.Template, notification)
+	}
+
+	tmpl, exists := t[notification.Kind]
+	if !exists {
+		return "", fmt.Errorf("no chat template for %s notifications", notification.Kind)
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&text, "text", notification); err != nil {
+		return "", fmt.Errorf("failed to render chat message: %w", err)
+	}
+	return text.String(), nil
+}
+
+// statusError is a non-2xx answer from a chat API.
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// isPermanentHTTPError treats client errors other than rate limiting as
+// permanent; retrying a rejected payload or a revoked webhook cannot succeed.
+func isPermanentHTTPError(err error) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) &&
+		statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 &&
+		statusErr.StatusCode != http.StatusTooManyRequests
+}
+
+// postJSON sends payload to endpoint. Transport errors are stripped of the
+// URL, which carries credentials for both Slack and Telegram.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("invalid endpoint")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	recordResponseCode(ctx, resp.StatusCode)
+	return nil
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/go-common/logger"
+)
+
+// DeliveryLog records every notification sent for a subscription, with each
+// attempt's latency, response code and error, so integrators can see whether
+// and why a notification fired.
+type DeliveryLog struct {
+	notifier services.Notifier
+	repo     repositories.DeliveryRepository
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+func NewDeliveryLog(notifier services.Notifier, repo repositories.DeliveryRepository, log logger.Logger) *DeliveryLog {
+	return &DeliveryLog{
+		notifier: notifier,
+		repo:     repo,
+		logger:   log,
+		now:      time.Now,
+	}
+}
+
+// Notify sends the notification; it is only recorded when it belongs to a
+// subscription.
+func (d *DeliveryLog) Notify(ctx context.Context, notification entities.Notification) error {
+	if notification.SubscriptionID == "" {
+		return d.notifier.Notify(ctx, notification)
+	}
+
+	_, err := d.Deliver(ctx, notification, "")
+	return err
+}
+
+// Deliver sends the notification and records the outcome. The returned error
+// is the send error, which the delivery also records; failing to store the
+// record is logged rather than failing the notification.
+func (d *DeliveryLog) Deliver(ctx context.Context, notification entities.Notification, redeliveryOf string) (entities.Delivery, error) {
+	delivery := entities.Delivery{
+		ID:             newDeliveryID(),
+		SubscriptionID: notification.SubscriptionID,
+		Notification:   notification,
+		Status:         entities.DeliverySucceeded,
+		RedeliveryOf:   redeliveryOf,
+		CreatedAt:      d.now().UTC(),
+	}
+
+	attemptCtx, attempts := withAttemptLog(ctx)
+	err := d.notifier.Notify(attemptCtx, notification)
+	delivery.Attempts = attempts.attempts
+	if err != nil {
+		delivery.Status = entities.DeliveryFailed
+		delivery.Error = err.Error()
+	}
+
+	if saveErr := d.repo.Save(context.WithoutCancel(ctx), delivery); saveErr != nil {
+		d.logger.Error("Failed to record notification delivery", saveErr, "subscription", delivery.SubscriptionID)
+	}
+
+	return delivery, err
+}
+
+func newDeliveryID() string {
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
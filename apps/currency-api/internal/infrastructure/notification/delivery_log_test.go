@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryLog(t *testing.T) {
+	failing := true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	slack, err := NewSlackNotifier(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}, logger.New("error"))
+	require.NoError(t, err)
+	slack.client = server.Client()
+
+	repo := repositories.NewMemoryDeliveryRepository(10)
+	deliveryLog := NewDeliveryLog(NewRouter(map[entities.NotificationChannel]services.Notifier{entities.ChannelSlack: slack}), repo, logger.New("error"))
+
+	alert := rateAlert("acme")
+	alert.SubscriptionID = "sub-1"
+	alert.Channel = entities.ChannelSlack
+	alert.Recipients = []string{server.URL + "/services/T000/B000/XXXX"}
+
+	require.Error(t, deliveryLog.Notify(context.Background(), alert))
+
+	deliveries, err := repo.List(context.Background(), "sub-1", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	failed := deliveries[0]
+	assert.Equal(t, entities.DeliveryFailed, failed.Status)
+	assert.Equal(t, "webhook 1: failed to send slack notification after 2 attempt(s): unexpected status 503: ", failed.Error)
+	require.Len(t, failed.Attempts, 2)
+	assert.Equal(t, 2, failed.Attempts[1].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, failed.Attempts[1].ResponseCode)
+	assert.Equal(t, "unexpected status 503: ", failed.Attempts[1].Error)
+
+	failing = false
+	redelivered, err := deliveryLog.Deliver(context.Background(), failed.Notification, failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.DeliverySucceeded, redelivered.Status)
+	assert.Equal(t, failed.ID, redelivered.RedeliveryOf)
+	require.Len(t, redelivered.Attempts, 1)
+	assert.Equal(t, http.StatusOK, redelivered.Attempts[0].ResponseCode)
+	assert.Empty(t, redelivered.Attempts[0].Error)
+
+	deliveries, err = repo.List(context.Background(), "sub-1", 10)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+}
+
+func TestDeliveryLog_WithoutSubscription(t *testing.T) {
+	recorder := &recordingNotifier{}
+	repo := repositories.NewMemoryDeliveryRepository(10)
+	deliveryLog := NewDeliveryLog(recorder, repo, logger.New("error"))
+
+	require.NoError(t, deliveryLog.Notify(context.Background(), rateAlert("acme")))
+
+	assert.Len(t, recorder.notifications, 1)
+	deliveries, err := repo.List(context.Background(), "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
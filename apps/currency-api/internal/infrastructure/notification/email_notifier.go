@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var ErrNoRecipients = errors.New("notification has no recipients")
+
+// EmailConfig describes the SMTP relay. Senders maps tenants to their own
+// From address; tenants without one use From.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Senders  map[string]string
+	Retry    RetryPolicy
+}
+
+// EmailNotifier sends notifications as plain-text email. Failed sends are
+// retried with exponential backoff unless the relay rejects the message
+// permanently (5xx).
+type EmailNotifier struct {
+	config    EmailConfig
+	templates map[entities.NotificationKind]*template.Template
+	logger    logger.Logger
+	sendMail  func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now       func() time.Time
+}
+
+func NewEmailNotifier(config EmailConfig, log logger.Logger) (*EmailNotifier, error) {
+	templates := make(map[entities.NotificationKind]*template.Template)
+	for _, kind := range []entities.NotificationKind{entities.NotificationRateAlert, entities.NotificationDailyDigest} {
+		tmpl, err := template.ParseFS(templateFiles, "templates/"+string(kind)+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s email template: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+
+	return &EmailNotifier{
+		config:    config,
+		templates: templates,
+		logger:    log,
+		sendMail:  smtp.SendMail,
+		now:       time.Now,
+	}, nil
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, notification entities.Notification) error {
+	if len(notification.Recipients) == 0 {
+		return ErrNoRecipients
+	}
+	for _, recipient := range notification.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+	}
+
+	subject, body, err := n.render(notification)
+	if err != nil {
+		return err
+	}
+
+	from := n.sender(notification.Tenant)
+	message := n.message(from, notification.Recipients, subject, body)
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+
+	return n.config.Retry.do(ctx, n.logger, "email", isPermanentSMTPError, func() error {
+		return n.sendMail(addr, auth, from, notification.Recipients, message)
+	})
+}
+
+func (n *EmailNotifier) render(notification entities.Notification) (string, string, error) {
+	tmpl, exists := n.templates[notification.Kind]
+	if !exists {
+		return "", "", fmt.Errorf("no email template for %s notifications", notification.Kind)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", notification); err != nil {
+		return "", "", fmt.Errorf("failed to render email subject: %w", err)
+	}
+	subjectLine := strings.Join(strings.Fields(subject.String()), " ")
+
+	if notification.Template != "" {
+		custom, err := tenantTemplates.Render(notification.Template, notification)
+		return subjectLine, custom, err
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", notification); err != nil {
+		return "", "", fmt.Errorf("failed to render email body: %w", err)
+	}
+
+	return subjectLine, body.String(), nil
+}
+
+func (n *EmailNotifier) sender(tenant string) string {
+	if from, exists := n.config.Senders[tenant]; exists && from != "" {
+		return from
+	}
+	return n.config.From
+}
+
+func (n *EmailNotifier) message(from string, to []string, subject, body string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.TrimLeft(body, "\n"), "\n", "\r\n"))
+	return msg.Bytes()
+}
+
+func isPermanentSMTPError(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 500
+}
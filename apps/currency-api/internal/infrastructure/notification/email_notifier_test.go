@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestEmailNotifier(t *testing.T, failures ...error) (*EmailNotifier, *[]sentMail) {
+	notifier, err := NewEmailNotifier(EmailConfig{
+		Host:    "smtp.example.com",
+		Port:    587,
+		From:    "alerts@currency-api.example.com",
+		Senders: map[string]string{"acme": "fx@acme.example.com"},
+		Retry:   RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+	}, logger.New("error"))
+	require.NoError(t, err)
+
+	var sent []sentMail
+	notifier.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{addr: addr, from: from, to: to, msg: string(msg)})
+		if len(failures) > 0 {
+			err := failures[0]
+			failures = failures[1:]
+			return err
+		}
+		return nil
+	}
+	notifier.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return notifier, &sent
+}
+
+func rateAlert(tenant string) entities.Notification {
+	return entities.Notification{
+		Kind:       entities.NotificationRateAlert,
+		Tenant:     tenant,
+		Recipients: []string{"desk@example.com"},
+		Alert: &entities.RateAlert{
+			From:        "WBTC",
+			To:          "USDT",
+			Direction:   "above",
+			Threshold:   decimal.NewFromInt(57000),
+			Rate:        decimal.RequireFromString("57094.31"),
+			TriggeredAt: time.Date(2026, 10, 16, 11, 59, 30, 0, time.UTC),
+		},
+	}
+}
+
+func TestEmailNotifier_Notify_RateAlert(t *testing.T) {
+	notifier, sent := newTestEmailNotifier(t)
+
+	require.NoError(t, notifier.Notify(context.Background(), rateAlert("acme")))
+
+	require.Len(t, *sent, 1)
+	mail := (*sent)[0]
+	assert.Equal(t, "smtp.example.com:587", mail.addr)
+	assert.Equal(t, "fx@acme.example.com", mail.from)
+	assert.Equal(t, []string{"desk@example.com"}, mail.to)
+	assert.Contains(t, mail.msg, "Subject: WBTC/USDT is above 57000\r\n")
+	assert.Contains(t, mail.msg, "Date: Fri, 16 Oct 2026 12:00:00 +0000\r\n")
+	assert.Contains(t, mail.msg, "\r\n\r\nWBTC/USDT moved above your threshold of 57000.\r\n")
+	assert.Contains(t, mail.msg, "Current rate: 57094.31\r\n")
+	assert.Contains(t, mail.msg, "Triggered at: 2026-10-16 11:59:30 UTC\r\n")
+}
+
+func TestEmailNotifier_Notify_DailyDigest(t *testing.T) {
+	notifier, sent := newTestEmailNotifier(t)
+
+	err := notifier.Notify(context.Background(), entities.Notification{
+		Kind:       entities.NotificationDailyDigest,
+		Tenant:     "globex",
+		Recipients: []string{"finance@example.com", "ops@example.com"},
+		Digest: &entities.RateDigest{
+			Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			Rates: []entities.ExchangeRate{
+				{From: "WBTC", To: "USDT", Rate: decimal.RequireFromString("57094.31")},
+				{From: "GATE", To: "USDT", Rate: decimal.RequireFromString("6.876877")},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *sent, 1)
+	mail := (*sent)[0]
+	assert.Equal(t, "alerts@currency-api.example.com", mail.from)
+	assert.Contains(t, mail.msg, "To: finance@example.com, ops@example.com\r\n")
+	assert.Contains(t, mail.msg, "Subject: Exchange rates for 2026-10-16\r\n")
+	assert.Contains(t, mail.msg, "WBTC/USDT: 57094.31\r\nGATE/USDT: 6.876877\r\n")
+}
+
+func TestEmailNotifier_Notify_Retries(t *testing.T) {
+	t.Run("temporary failures", func(t *testing.T) {
+		notifier, sent := newTestEmailNotifier(t, errors.New("connection reset"), &textproto.Error{Code: 421, Msg: "try again later"})
+
+		require.NoError(t, notifier.Notify(context.Background(), rateAlert("acme")))
+		assert.Len(t, *sent, 3)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		failure := errors.New("connection refused")
+		notifier, sent := newTestEmailNotifier(t, failure, failure, failure)
+
+		err := notifier.Notify(context.Background(), rateAlert("acme"))
+		assert.ErrorIs(t, err, failure)
+		assert.EqualError(t, err, "failed to send email notification after 3 attempt(s): connection refused")
+		assert.Len(t, *sent, 3)
+	})
+
+	t.Run("permanent rejection", func(t *testing.T) {
+		notifier, sent := newTestEmailNotifier(t, &textproto.Error{Code: 550, Msg: "mailbox unavailable"})
+
+		err := notifier.Notify(context.Background(), rateAlert("acme"))
+		assert.Error(t, err)
+		assert.Len(t, *sent, 1)
+	})
+}
+
+func TestEmailNotifier_Notify_Rejects(t *testing.T) {
+	notifier, sent := newTestEmailNotifier(t)
+
+	noRecipients := rateAlert("acme")
+	noRecipients.Recipients = nil
+	assert.ErrorIs(t, notifier.Notify(context.Background(), noRecipients), ErrNoRecipients)
+
+	invalid := rateAlert("acme")
+	invalid.Recipients = []string{"desk@example.com\r\nBcc: everyone@example.com"}
+	assert.Error(t, notifier.Notify(context.Background(), invalid))
+
+	unknown := rateAlert("acme")
+	unknown.Kind = "weekly_report"
+	assert.EqualError(t, notifier.Notify(context.Background(), unknown), "no email template for weekly_report notifications")
+
+	assert.Empty(t, *sent)
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+)
+
+// RetryPolicy retries failed deliveries with exponential backoff starting at
+// Backoff. MaxAttempts below 1 means a single attempt.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// do calls send until it succeeds, fails permanently or runs out of attempts.
+// Every attempt is reported to the attempt log in ctx, if there is one.
+func (p RetryPolicy) do(ctx context.Context, log logger.Logger, channel string, permanent func(error) bool, send func() error) error {
+	attempts := attemptLogFrom(ctx)
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err := send()
+		attempts.add(attempt, start, err)
+
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.MaxAttempts || permanent(err) {
+			return fmt.Errorf("failed to send %s notification after %d attempt(s): %w", channel, attempt, err)
+		}
+
+		log.Warn("Retrying notification", "channel", channel, "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.Backoff << (attempt - 1)):
+		}
+	}
+}
+
+type attemptLogKey struct{}
+
+// attemptLog collects the attempts of one delivery. Notifiers with several
+// recipients add one series of attempts per recipient.
+type attemptLog struct {
+	attempts     []entities.DeliveryAttempt
+	responseCode int
+}
+
+func withAttemptLog(ctx context.Context) (context.Context, *attemptLog) {
+	log := &attemptLog{}
+	return context.WithValue(ctx, attemptLogKey{}, log), log
+}
+
+func attemptLogFrom(ctx context.Context) *attemptLog {
+	log, _ := ctx.Value(attemptLogKey{}).(*attemptLog)
+	return log
+}
+
+// recordResponseCode notes the status of a successful response, which the
+// error of a failed attempt would otherwise carry.
+func recordResponseCode(ctx context.Context, code int) {
+	if log := attemptLogFrom(ctx); log != nil {
+		log.responseCode = code
+	}
+}
+
+func (l *attemptLog) add(attempt int, start time.Time, err error) {
+	if l == nil {
+		return
+	}
+
+	record := entities.DeliveryAttempt{
+		Attempt:      attempt,
+		At:           start.UTC(),
+		Latency:      time.Since(start),
+		ResponseCode: l.responseCode,
+	}
+	l.responseCode = 0
+
+	if err != nil {
+		record.Error = err.Error()
+
+		var statusErr *statusError
+		var protoErr *textproto.Error
+		switch {
+		case errors.As(err, &statusErr):
+			record.ResponseCode = statusErr.StatusCode
+		case errors.As(err, &protoErr):
+			record.ResponseCode = protoErr.Code
+		}
+	}
+
+	l.attempts = append(l.attempts, record)
+}
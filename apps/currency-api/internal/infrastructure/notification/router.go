@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+)
+
+// Router delivers each notification over the channel its subscription
+// selected.
+type Router struct {
+	channels map[entities.NotificationChannel]services.Notifier
+}
+
+func NewRouter(channels map[entities.NotificationChannel]services.Notifier) *Router {
+	return &Router{channels: channels}
+}
+
+func (r *Router) Notify(ctx context.Context, notification entities.Notification) error {
+	notifier, exists := r.channels[notification.Channel]
+	if !exists {
+		return fmt.Errorf("notification channel %q is not configured", notification.Channel)
+	}
+	return notifier.Notify(ctx, notification)
+}
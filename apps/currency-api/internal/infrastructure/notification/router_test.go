@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	notifications []entities.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification entities.Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func TestRouter_Notify(t *testing.T) {
+	slack := &recordingNotifier{}
+	router := NewRouter(map[entities.NotificationChannel]services.Notifier{entities.ChannelSlack: slack})
+
+	alert := rateAlert("acme")
+	alert.Channel = entities.ChannelSlack
+	require.NoError(t, router.Notify(context.Background(), alert))
+	assert.Len(t, slack.notifications, 1)
+
+	alert.Channel = entities.ChannelTelegram
+	assert.EqualError(t, router.Notify(context.Background(), alert), `notification channel "telegram" is not configured`)
+}
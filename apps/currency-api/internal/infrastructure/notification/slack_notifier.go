@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+)
+
+// SlackNotifier posts notifications to Slack incoming webhooks; each
+// recipient is a webhook URL.
+type SlackNotifier struct {
+	templates chatTemplates
+	retry     RetryPolicy
+	logger    logger.Logger
+	client    *http.Client
+}
+
+func NewSlackNotifier(retry RetryPolicy, log logger.Logger) (*SlackNotifier, error) {
+	templates, err := parseChatTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlackNotifier{
+		templates: templates,
+		retry:     retry,
+		logger:    log,
+		client:    &http.Client{Timeout: chatRequestTimeout},
+	}, nil
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, notification entities.Notification) error {
+	if len(notification.Recipients) == 0 {
+		return ErrNoRecipients
+	}
+	for _, recipient := range notification.Recipients {
+		webhook, err := url.Parse(recipient)
+		if err != nil || webhook.Scheme != "https" || webhook.Host == "" {
+			return errors.New("slack recipients must be https webhook URLs")
+		}
+	}
+
+	text, err := n.templates.render(notification)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, webhook := range notification.Recipients {
+		err := n.retry.do(ctx, n.logger, "slack", isPermanentHTTPError, func() error {
+			return postJSON(ctx, n.client, webhook, map[string]string{"text": text})
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var texts []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		texts = append(texts, payload["text"])
+		if len(texts) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}, logger.New("error"))
+	require.NoError(t, err)
+	notifier.client = server.Client()
+
+	alert := rateAlert("acme")
+	alert.Recipients = []string{server.URL + "/services/T000/B000/XXXX"}
+	require.NoError(t, notifier.Notify(context.Background(), alert))
+
+	require.Len(t, texts, 2)
+	assert.Equal(t, "🔔 WBTC/USDT is above 57000\nCurrent rate: 57094.31 (11:59 UTC)", texts[1])
+}
+
+func TestSlackNotifier_Notify_Rejects(t *testing.T) {
+	calls := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}, logger.New("error"))
+	require.NoError(t, err)
+	notifier.client = server.Client()
+
+	insecure := rateAlert("acme")
+	insecure.Recipients = []string{"http://hooks.slack.com/services/T000/B000/XXXX"}
+	assert.EqualError(t, notifier.Notify(context.Background(), insecure), "slack recipients must be https webhook URLs")
+
+	revoked := rateAlert("acme")
+	revoked.Recipients = []string{server.URL + "/services/T000/B000/XXXX"}
+	err = notifier.Notify(context.Background(), revoked)
+	assert.EqualError(t, err, "webhook 1: failed to send slack notification after 1 attempt(s): unexpected status 404: no_service")
+	assert.NotContains(t, err.Error(), "XXXX")
+	assert.Equal(t, 1, calls)
+}
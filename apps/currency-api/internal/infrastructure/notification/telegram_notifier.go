@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// TelegramNotifier sends notifications through a Telegram bot; each
+// recipient is a chat ID the bot has been added to.
+type TelegramNotifier struct {
+	botToken  string
+	apiURL    string
+	templates chatTemplates
+	retry     RetryPolicy
+	logger    logger.Logger
+	client    *http.Client
+}
+
+func NewTelegramNotifier(botToken string, retry RetryPolicy, log logger.Logger) (*TelegramNotifier, error) {
+	if botToken == "" {
+		return nil, errors.New("telegram bot token is required")
+	}
+
+	templates, err := parseChatTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TelegramNotifier{
+		botToken:  botToken,
+		apiURL:    telegramAPIURL,
+		templates: templates,
+		retry:     retry,
+		logger:    log,
+		client:    &http.Client{Timeout: chatRequestTimeout},
+	}, nil
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, notification entities.Notification) error {
+	if len(notification.Recipients) == 0 {
+		return ErrNoRecipients
+	}
+	for _, chatID := range notification.Recipients {
+		if !isTelegramChatID(chatID) {
+			return fmt.Errorf("invalid telegram chat id %q", chatID)
+		}
+	}
+
+	text, err := n.templates.render(notification)
+	if err != nil {
+		return err
+	}
+
+	endpoint := n.apiURL + "/bot" + n.botToken + "/sendMessage"
+
+	var errs []error
+	for _, chatID := range notification.Recipients {
+		err := n.retry.do(ctx, n.logger, "telegram", isPermanentHTTPError, func() error {
+			return postJSON(ctx, n.client, endpoint, map[string]string{"chat_id": chatID, "text": text})
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("chat %s: %w", chatID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isTelegramChatID accepts numeric chat IDs and @channel usernames.
+func isTelegramChatID(chatID string) bool {
+	if username, found := strings.CutPrefix(chatID, "@"); found {
+		return len(username) >= 5 && strings.IndexFunc(username, func(r rune) bool {
+			return !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+		}) == -1
+	}
+	_, err := strconv.ParseInt(chatID, 10, 64)
+	return err == nil
+}
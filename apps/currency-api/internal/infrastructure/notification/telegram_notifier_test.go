@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/go-common/logger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramNotifier_Notify(t *testing.T) {
+	var paths []string
+	var payloads []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		paths = append(paths, r.URL.Path)
+		payloads = append(payloads, payload)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewTelegramNotifier("123:secret", RetryPolicy{MaxAttempts: 1}, logger.New("error"))
+	require.NoError(t, err)
+	notifier.apiURL = server.URL
+
+	err = notifier.Notify(context.Background(), entities.Notification{
+		Kind:       entities.NotificationDailyDigest,
+		Channel:    entities.ChannelTelegram,
+		Recipients: []string{"-1001234567890", "@fx_desk"},
+		Digest: &entities.RateDigest{
+			Date:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			Rates: []entities.ExchangeRate{{From: "WBTC", To: "USDT", Rate: decimal.RequireFromString("57094.31")}},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bot123:secret/sendMessage", "/bot123:secret/sendMessage"}, paths)
+	assert.Equal(t, "-1001234567890", payloads[0]["chat_id"])
+	assert.Equal(t, "@fx_desk", payloads[1]["chat_id"])
+	assert.Equal(t, "📊 Exchange rates for 2026-10-16\nWBTC/USDT: 57094.31", payloads[0]["text"])
+}
+
+func TestTelegramNotifier_Notify_Errors(t *testing.T) {
+	_, err := NewTelegramNotifier("", RetryPolicy{}, logger.New("error"))
+	assert.Error(t, err)
+
+	notifier, err := NewTelegramNotifier("123:secret", RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}, logger.New("error"))
+	require.NoError(t, err)
+
+	invalid := rateAlert("acme")
+	invalid.Recipients = []string{"fx desk"}
+	assert.EqualError(t, notifier.Notify(context.Background(), invalid), `invalid telegram chat id "fx desk"`)
+
+	notifier.apiURL = "http://127.0.0.1:1"
+	unreachable := rateAlert("acme")
+	unreachable.Recipients = []string{"42"}
+	err = notifier.Notify(context.Background(), unreachable)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
@@ -0,0 +1,297 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/go-common/timefmt"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	maxTemplateBytes = 4 << 10
+	maxOutputBytes   = 16 << 10
+	renderTimeout    = 100 * time.Millisecond
+
+	// maxRoundPlaces and maxRoundDigits bound what round formats. Formatting
+	// cost grows with the digits written and the render goroutine cannot be
+	// stopped once it runs, so the limits have to be checked up front.
+	maxRoundPlaces = 18
+	maxRoundDigits = 64
+)
+
+// templateFuncs are the only functions tenant templates may call, on top of
+// the comparison and logic builtins in allowedBuiltins.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"json": func(v any) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+	"round": func(value string, places int) (string, error) {
+		if places < 0 || places > maxRoundPlaces {
+			return "", fmt.Errorf("places must be between 0 and %d", maxRoundPlaces)
+		}
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return "", err
+		}
+		if int64(amount.NumDigits())+int64(amount.Exponent()) > maxRoundDigits {
+			return "", fmt.Errorf("value has more than %d integer digits", maxRoundDigits)
+		}
+		return amount.StringFixed(int32(places)), nil
+	},
+	"formatTime": func(layout, value string) (string, error) {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return "", err
+		}
+		return parsed.Format(layout), nil
+	},
+}
+
+// allowedBuiltins leaves out call, which could invoke arbitrary functions,
+// and printf, whose width verbs can allocate without bound.
+var allowedBuiltins = map[string]bool{
+	"and": true, "or": true, "not": true, "len": true, "index": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+	"print": true, "println": true, "html": true, "js": true, "urlquery": true,
+}
+
+// tenantTemplates renders the custom templates notifications carry.
+var tenantTemplates = NewTemplateEngine()
+
+var templateErrorPattern = regexp.MustCompile(`^template: message:(\d+)(?::\d+)?: (.*)$`)
+
+// TemplateData is what tenant templates see. Values are pre-formatted
+// strings so templates cannot reach methods of the domain types.
+type TemplateData struct {
+	Kind   string
+	Tenant string
+
+	// Rate alerts.
+	Pair        string
+	From        string
+	To          string
+	Direction   string
+	Threshold   string
+	Rate        string
+	TriggeredAt string
+
+	// Daily digests.
+	Date  string
+	Rates []TemplateRate
+}
+
+type TemplateRate struct {
+	Pair string
+	From string
+	To   string
+	Rate string
+}
+
+// TemplateEngine renders tenant templates in a sandbox: only whitelisted
+// functions, no nested template definitions, loops only over data and
+// bounded template size, output size and render time.
+type TemplateEngine struct{}
+
+func NewTemplateEngine() *TemplateEngine {
+	return &TemplateEngine{}
+}
+
+func (e *TemplateEngine) Render(source string, notification entities.Notification) (string, error) {
+	tmpl, err := e.parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), renderTimeout)
+	defer cancel()
+
+	type rendered struct {
+		output string
+		err    error
+	}
+	done := make(chan rendered, 1)
+	go func() {
+		out := &limitedBuffer{limit: maxOutputBytes}
+		err := tmpl.Execute(out, newTemplateData(notification))
+		done <- rendered{output: out.String(), err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", &services.TemplateError{Message: "template took too long to render"}
+	case result := <-done:
+		if result.err != nil {
+			if errors.Is(result.err, errOutputTooLarge) {
+				return "", &services.TemplateError{Message: fmt.Sprintf("rendered output exceeds %d bytes", maxOutputBytes)}
+			}
+			return "", newTemplateError(result.err)
+		}
+		return result.output, nil
+	}
+}
+
+func (e *TemplateEngine) parse(source string) (*template.Template, error) {
+	if len(source) > maxTemplateBytes {
+		return nil, &services.TemplateError{Message: fmt.Sprintf("template exceeds %d bytes", maxTemplateBytes)}
+	}
+
+	tmpl, err := template.New("message").Option("missingkey=error").Funcs(templateFuncs).Parse(source)
+	if err != nil {
+		return nil, newTemplateError(err)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, &services.TemplateError{Message: "define and block are not allowed"}
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+	if err := checkNode(tmpl.Tree, tmpl.Tree.Root); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// checkNode rejects constructs the sandbox does not allow.
+func checkNode(tree *parse.Tree, node parse.Node) error {
+	reject := func(message string) error {
+		location, _ := tree.ErrorContext(node)
+		return newTemplateError(fmt.Errorf("template: %s: %s", location, message))
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkNode(tree, child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkNode(tree, n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkNode(tree, cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkNode(tree, arg); err != nil {
+				return err
+			}
+		}
+	case *parse.IdentifierNode:
+		if _, custom := templateFuncs[n.Ident]; !custom && !allowedBuiltins[n.Ident] {
+			return reject(fmt.Sprintf("function %q is not allowed", n.Ident))
+		}
+	case *parse.IfNode:
+		return checkBranch(tree, &n.BranchNode)
+	case *parse.WithNode:
+		return checkBranch(tree, &n.BranchNode)
+	case *parse.RangeNode:
+		if !rangesOverData(n.Pipe) {
+			return reject("range may only iterate over data fields such as .Rates")
+		}
+		return checkBranch(tree, &n.BranchNode)
+	case *parse.TemplateNode:
+		return reject("template calls are not allowed")
+	}
+	return nil
+}
+
+func checkBranch(tree *parse.Tree, branch *parse.BranchNode) error {
+	if err := checkNode(tree, branch.Pipe); err != nil {
+		return err
+	}
+	if err := checkNode(tree, branch.List); err != nil {
+		return err
+	}
+	return checkNode(tree, branch.ElseList)
+}
+
+// rangesOverData only accepts plain field references, so loops are bounded
+// by the data instead of by literals such as {{range 1000000000}}.
+func rangesOverData(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode, *parse.DotNode:
+		return true
+	}
+	return false
+}
+
+func newTemplateError(err error) *services.TemplateError {
+	match := templateErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return &services.TemplateError{Message: strings.TrimPrefix(err.Error(), "template: ")}
+	}
+	line, _ := strconv.Atoi(match[1])
+	return &services.TemplateError{Line: line, Message: match[2]}
+}
+
+func newTemplateData(notification entities.Notification) TemplateData {
+	data := TemplateData{
+		Kind:   string(notification.Kind),
+		Tenant: notification.Tenant,
+	}
+
+	if alert := notification.Alert; alert != nil {
+		data.Pair = alert.From + "/" + alert.To
+		data.From = alert.From
+		data.To = alert.To
+		data.Direction = alert.Direction
+		data.Threshold = alert.Threshold.String()
+		data.Rate = alert.Rate.String()
+		data.TriggeredAt = timefmt.Format(alert.TriggeredAt, nil)
+	}
+
+	if digest := notification.Digest; digest != nil {
+		data.Date = digest.Date.Format("2006-01-02")
+		for _, rate := range digest.Rates {
+			data.Rates = append(data.Rates, TemplateRate{
+				Pair: rate.From + "/" + rate.To,
+				From: rate.From,
+				To:   rate.To,
+				Rate: rate.Rate.String(),
+			})
+		}
+	}
+
+	return data
+}
+
+var errOutputTooLarge = errors.New("output too large")
+
+type limitedBuffer struct {
+	strings.Builder
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errOutputTooLarge
+	}
+	return b.Builder.Write(p)
+}
@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateEngine_Render(t *testing.T) {
+	engine := NewTemplateEngine()
+	digest := entities.Notification{
+		Kind:   entities.NotificationDailyDigest,
+		Tenant: "acme",
+		Digest: &entities.RateDigest{
+			Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			Rates: []entities.ExchangeRate{
+				{From: "WBTC", To: "USDT", Rate: decimal.RequireFromString("57094.31")},
+				{From: "GATE", To: "USDT", Rate: decimal.RequireFromString("6.876877")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		notification entities.Notification
+		template     string
+		expected     string
+	}{
+		{
+			name:         "alert fields and functions",
+			notification: rateAlert("acme"),
+			template:     `{{upper .Tenant}}: {{.Pair}} {{.Direction}} {{.Threshold}}, now {{round .Rate 1}} at {{formatTime "15:04" .TriggeredAt}}`,
+			expected:     "ACME: WBTC/USDT above 57000, now 57094.3 at 11:59",
+		},
+		{
+			name:         "json payload",
+			notification: rateAlert("acme"),
+			template:     `{"pair":{{json .Pair}},"rate":{{.Rate}}}`,
+			expected:     `{"pair":"WBTC/USDT","rate":57094.31}`,
+		},
+		{
+			name:         "digest loop",
+			notification: digest,
+			template:     `{{.Date}}{{range $i, $rate := .Rates}}{{if $i}};{{end}} {{$rate.Pair}}={{$rate.Rate}}{{end}}`,
+			expected:     "2026-10-16 WBTC/USDT=57094.31; GATE/USDT=6.876877",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := engine.Render(tt.template, tt.notification)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestTemplateEngine_Render_Sandbox(t *testing.T) {
+	engine := NewTemplateEngine()
+
+	tests := []struct {
+		name     string
+		template string
+		line     int
+		message  string
+	}{
+		{name: "syntax error", template: "{{.Pair}}\n{{if .Rate}}", line: 2, message: "unexpected EOF"},
+		{name: "unknown function", template: "{{env \"HOME\"}}", line: 1, message: `function "env" not defined`},
+		{name: "call", template: "ok\n{{call .Pair}}", line: 2, message: `function "call" is not allowed`},
+		{name: "printf", template: `{{printf "%999999999d" 1}}`, line: 1, message: `function "printf" is not allowed`},
+		{name: "range over literal", template: "{{range 1000000000}}{{end}}", line: 1, message: "range may only iterate over data fields such as .Rates"},
+		{name: "range over variable", template: "{{$n := 5}}{{range $n}}{{end}}", line: 1, message: "range may only iterate over data fields such as .Rates"},
+		{name: "define", template: `{{define "x"}}{{end}}`, message: "define and block are not allowed"},
+		{name: "template call", template: `{{template "message" .}}`, line: 1, message: "template calls are not allowed"},
+		{name: "unknown field", template: "{{.Secret}}", line: 1, message: `executing "message" at <.Secret>: can't evaluate field Secret in type notification.TemplateData`},
+		{name: "round with huge places", template: `{{round "1" 2000000000}}`, line: 1, message: `executing "message" at <round "1" 2000000000>: error calling round: places must be between 0 and 18`},
+		{name: "round with negative places", template: `{{round "1" -3}}`, line: 1, message: `executing "message" at <round "1" -3>: error calling round: places must be between 0 and 18`},
+		{name: "round with huge value", template: `{{round "1e2000000000" 2}}`, line: 1, message: `executing "message" at <round "1e2000000000" 2>: error calling round: value has more than 64 integer digits`},
+		{name: "template too large", template: strings.Repeat("x", maxTemplateBytes+1), message: "template exceeds 4096 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.Render(tt.template, rateAlert("acme"))
+
+			var templateErr *services.TemplateError
+			require.ErrorAs(t, err, &templateErr)
+			assert.Equal(t, tt.line, templateErr.Line)
+			assert.Equal(t, tt.message, templateErr.Message)
+		})
+	}
+}
+
+func TestTemplateEngine_Render_OutputLimit(t *testing.T) {
+	digest := entities.Notification{
+		Kind:   entities.NotificationDailyDigest,
+		Digest: &entities.RateDigest{Rates: make([]entities.ExchangeRate, 10)},
+	}
+
+	_, err := NewTemplateEngine().Render(`{{range .Rates}}`+strings.Repeat("x", 2000)+`{{end}}`, digest)
+
+	var templateErr *services.TemplateError
+	require.ErrorAs(t, err, &templateErr)
+	assert.Equal(t, "rendered output exceeds 16384 bytes", templateErr.Message)
+}
+
+func TestNotifiers_CustomTemplate(t *testing.T) {
+	notifier, sent := newTestEmailNotifier(t)
+
+	alert := rateAlert("acme")
+	alert.Template = "{{.Pair}} hit {{.Rate}}"
+	require.NoError(t, notifier.Notify(t.Context(), alert))
+
+	require.Len(t, *sent, 1)
+	assert.Contains(t, (*sent)[0].msg, "Subject: WBTC/USDT is above 57000\r\n")
+	assert.True(t, strings.HasSuffix((*sent)[0].msg, "\r\n\r\nWBTC/USDT hit 57094.31"))
+
+	chat, err := parseChatTemplates()
+	require.NoError(t, err)
+	text, err := chat.render(alert)
+	require.NoError(t, err)
+	assert.Equal(t, "WBTC/USDT hit 57094.31", text)
+}
@@ -0,0 +1,308 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/configfile"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	maxExpressionNodes = 200
+	memoryBudget       = 10000
+	maxRoundPlaces     = 18
+	// divisionPlaces is how many decimal places a division inside a rule
+	// keeps before the result is rounded to the target currency.
+	divisionPlaces = 36
+)
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// RulesFile is the on-disk format of PRICING_RULES_FILE. Expressions see
+// amount and rate as exact decimals, from, to, tenant and decimals (target
+// precision). Arithmetic and comparisons on amount and rate stay in decimal;
+// number literals mixed in are taken at their written value.
+type RulesFile struct {
+	Default string            `json:"default"`
+	Tenants map[string]string `json:"tenants"`
+}
+
+type exprEnv struct {
+	Amount   decimal.Decimal `expr:"amount"`
+	Rate     decimal.Decimal `expr:"rate"`
+	From     string          `expr:"from"`
+	To       string          `expr:"to"`
+	Tenant   string          `expr:"tenant"`
+	Decimals int             `expr:"decimals"`
+}
+
+type ExprPricingRules struct {
+	defaultRule *vm.Program
+	tenantRules map[string]*vm.Program
+	timeout     time.Duration
+}
+
+func LoadExprPricingRules(path string, timeout time.Duration) (*ExprPricingRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing rules: %w", err)
+	}
+
+	if err := configfile.Validate(configfile.PricingRules, data); err != nil {
+		return nil, fmt.Errorf("failed to decode pricing rules: %w", err)
+	}
+
+	var file RulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode pricing rules: %w", err)
+	}
+
+	return NewExprPricingRules(file, timeout)
+}
+
+func NewExprPricingRules(file RulesFile, timeout time.Duration) (*ExprPricingRules, error) {
+	rules := &ExprPricingRules{
+		tenantRules: make(map[string]*vm.Program, len(file.Tenants)),
+		timeout:     timeout,
+	}
+
+	if file.Default != "" {
+		program, err := compile(file.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default pricing rule: %w", err)
+		}
+		rules.defaultRule = program
+	}
+
+	for tenant, expression := range file.Tenants {
+		program, err := compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pricing rule for tenant %s: %w", tenant, err)
+		}
+		rules.tenantRules[tenant] = program
+	}
+
+	return rules, nil
+}
+
+func compile(expression string) (*vm.Program, error) {
+	options := []expr.Option{
+		expr.Env(exprEnv{}),
+		expr.MaxNodes(maxExpressionNodes),
+		expr.Function("roundTo", roundTo(decimal.Decimal.Round), roundSignatures...),
+		expr.Function("floorTo", roundTo(decimal.Decimal.RoundFloor), roundSignatures...),
+		expr.Function("ceilTo", roundTo(decimal.Decimal.RoundCeil), roundSignatures...),
+	}
+	for _, op := range decimalOperators {
+		options = append(options,
+			expr.Function(op.name, op.fn, op.signatures...),
+			expr.Operator(op.token, op.name),
+		)
+	}
+
+	program, err := expr.Compile(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+	if kind := program.Node().Type(); kind != nil && kind.Kind() != reflect.Interface && !isNumeric(kind) {
+		return nil, fmt.Errorf("expression must produce a number, not %s", kind)
+	}
+	return program, nil
+}
+
+func isNumeric(kind reflect.Type) bool {
+	return kind == decimalType || kind.Kind() == reflect.Int || kind.Kind() == reflect.Float64
+}
+
+var roundSignatures = []any{
+	new(func(decimal.Decimal, int) decimal.Decimal),
+	new(func(float64, int) decimal.Decimal),
+	new(func(int, int) decimal.Decimal),
+}
+
+func roundTo(fn func(decimal.Decimal, int32) decimal.Decimal) func(params ...any) (any, error) {
+	return func(params ...any) (any, error) {
+		value, err := toDecimal(params[0])
+		if err != nil {
+			return nil, err
+		}
+		places := params[1].(int)
+		if places < 0 || places > maxRoundPlaces {
+			return nil, fmt.Errorf("places must be between 0 and %d", maxRoundPlaces)
+		}
+		return fn(value, int32(places)), nil
+	}
+}
+
+type decimalOperator struct {
+	token      string
+	name       string
+	fn         func(params ...any) (any, error)
+	signatures []any
+}
+
+// decimalOperators replace the float arithmetic expr would otherwise use
+// whenever amount or rate (or a result derived from them) is an operand.
+var decimalOperators = []decimalOperator{
+	arithmetic("+", "decimalAdd", func(a, b decimal.Decimal) (decimal.Decimal, error) { return a.Add(b), nil }),
+	arithmetic("-", "decimalSub", func(a, b decimal.Decimal) (decimal.Decimal, error) { return a.Sub(b), nil }),
+	arithmetic("*", "decimalMul", func(a, b decimal.Decimal) (decimal.Decimal, error) { return a.Mul(b), nil }),
+	arithmetic("/", "decimalDiv", func(a, b decimal.Decimal) (decimal.Decimal, error) {
+		if b.IsZero() {
+			return decimal.Zero, fmt.Errorf("division by zero")
+		}
+		return a.DivRound(b, divisionPlaces), nil
+	}),
+	comparison("==", "decimalEq", func(c int) bool { return c == 0 }),
+	comparison("!=", "decimalNe", func(c int) bool { return c != 0 }),
+	comparison("<", "decimalLt", func(c int) bool { return c < 0 }),
+	comparison("<=", "decimalLe", func(c int) bool { return c <= 0 }),
+	comparison(">", "decimalGt", func(c int) bool { return c > 0 }),
+	comparison(">=", "decimalGe", func(c int) bool { return c >= 0 }),
+}
+
+func arithmetic(token, name string, fn func(a, b decimal.Decimal) (decimal.Decimal, error)) decimalOperator {
+	return decimalOperator{
+		token: token,
+		name:  name,
+		fn: func(params ...any) (any, error) {
+			a, b, err := decimalOperands(params)
+			if err != nil {
+				return nil, err
+			}
+			return fn(a, b)
+		},
+		signatures: []any{
+			new(func(decimal.Decimal, decimal.Decimal) decimal.Decimal),
+			new(func(decimal.Decimal, float64) decimal.Decimal),
+			new(func(float64, decimal.Decimal) decimal.Decimal),
+			new(func(decimal.Decimal, int) decimal.Decimal),
+			new(func(int, decimal.Decimal) decimal.Decimal),
+		},
+	}
+}
+
+func comparison(token, name string, holds func(c int) bool) decimalOperator {
+	return decimalOperator{
+		token: token,
+		name:  name,
+		fn: func(params ...any) (any, error) {
+			a, b, err := decimalOperands(params)
+			if err != nil {
+				return nil, err
+			}
+			return holds(a.Cmp(b)), nil
+		},
+		signatures: []any{
+			new(func(decimal.Decimal, decimal.Decimal) bool),
+			new(func(decimal.Decimal, float64) bool),
+			new(func(float64, decimal.Decimal) bool),
+			new(func(decimal.Decimal, int) bool),
+			new(func(int, decimal.Decimal) bool),
+		},
+	}
+}
+
+func decimalOperands(params []any) (decimal.Decimal, decimal.Decimal, error) {
+	a, err := toDecimal(params[0])
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	b, err := toDecimal(params[1])
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	return a, b, nil
+}
+
+// toDecimal converts an operand or result to a decimal. Float literals keep
+// their shortest representation, so 0.995 stays 0.995.
+func toDecimal(value any) (decimal.Decimal, error) {
+	switch v := value.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case int:
+		return decimal.NewFromInt(int64(v)), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return decimal.Zero, fmt.Errorf("%v is not a finite number", v)
+		}
+		return decimal.NewFromFloat(v), nil
+	default:
+		return decimal.Zero, fmt.Errorf("expression must produce a number, not %T", value)
+	}
+}
+
+func (r *ExprPricingRules) Apply(ctx context.Context, input services.PricingInput) (decimal.Decimal, string, error) {
+	rule := "tenant:" + input.Tenant
+	program, exists := r.tenantRules[input.Tenant]
+	if !exists {
+		rule = "default"
+		program = r.defaultRule
+	}
+	if program == nil {
+		return input.Amount, "", nil
+	}
+
+	env := exprEnv{
+		Amount:   input.Amount,
+		Rate:     input.Rate,
+		From:     input.From,
+		To:       input.To,
+		Tenant:   input.Tenant,
+		Decimals: int(input.DecimalPlaces),
+	}
+
+	result, err := r.run(ctx, program, env)
+	if err != nil {
+		return decimal.Zero, "", fmt.Errorf("%s: %w", rule, err)
+	}
+
+	value, err := toDecimal(result)
+	if err != nil {
+		return decimal.Zero, "", fmt.Errorf("%s %w: %w", rule, services.ErrInvalidPricedAmount, err)
+	}
+	if value.IsNegative() {
+		return decimal.Zero, "", fmt.Errorf("%s %w: %s", rule, services.ErrInvalidPricedAmount, value.String())
+	}
+
+	return value.Round(input.DecimalPlaces), rule, nil
+}
+
+// run evaluates program with a memory budget and a wall-clock limit. Programs
+// are loop-free and node-limited, so a timed-out evaluation ends on its own.
+func (r *ExprPricingRules) run(ctx context.Context, program *vm.Program, env exprEnv) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	type outcome struct {
+		value any
+		err   error
+	}
+	done := make(chan outcome, 1)
+
+	go func() {
+		machine := vm.VM{MemoryBudget: memoryBudget}
+		value, err := machine.Run(program, env)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: timed out after %s", services.ErrPricingRuleFailed, r.timeout)
+	case result := <-done:
+		if result.err != nil {
+			return nil, fmt.Errorf("%w: %w", services.ErrPricingRuleFailed, result.err)
+		}
+		return result.value, nil
+	}
+}
@@ -0,0 +1,192 @@
+package pricing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInput(tenant string) services.PricingInput {
+	return services.PricingInput{
+		Tenant:        tenant,
+		From:          "WBTC",
+		To:            "USDT",
+		Amount:        decimal.RequireFromString("57094.314314"),
+		Rate:          decimal.RequireFromString("57094.314314"),
+		DecimalPlaces: 6,
+	}
+}
+
+func TestExprPricingRules_Apply(t *testing.T) {
+	rules, err := NewExprPricingRules(RulesFile{
+		Default: "amount * 0.999",
+		Tenants: map[string]string{
+			"acme":    "roundTo(amount * 0.995, 2)",
+			"globex":  "from == 'WBTC' ? floorTo(amount, 0) : amount",
+			"initech": "ceilTo(amount, decimals - 4)",
+		},
+	}, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	tests := []struct {
+		tenant       string
+		expected     string
+		expectedRule string
+	}{
+		{tenant: "acme", expected: "56808.84", expectedRule: "tenant:acme"},
+		{tenant: "globex", expected: "57094", expectedRule: "tenant:globex"},
+		{tenant: "initech", expected: "57094.32", expectedRule: "tenant:initech"},
+		{tenant: "unknown", expected: "57037.22", expectedRule: "default"},
+		{tenant: "", expected: "57037.22", expectedRule: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tenant, func(t *testing.T) {
+			amount, rule, err := rules.Apply(context.Background(), testInput(tt.tenant))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRule, rule)
+			assert.True(t, decimal.RequireFromString(tt.expected).Equal(amount),
+				"expected %s, got %s", tt.expected, amount.String())
+		})
+	}
+}
+
+func TestExprPricingRules_FullPrecision(t *testing.T) {
+	input := services.PricingInput{
+		From:          "USDT",
+		To:            "BEER",
+		Amount:        decimal.RequireFromString("123456789.123456789012345678"),
+		Rate:          decimal.RequireFromString("0.000000000000000001"),
+		DecimalPlaces: 18,
+	}
+
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{expression: "amount", expected: "123456789.123456789012345678"},
+		{expression: "amount * 1", expected: "123456789.123456789012345678"},
+		{expression: "amount / 3 * 3", expected: "123456789.123456789012345678"},
+		{expression: "amount + rate", expected: "123456789.123456789012345679"},
+		{expression: "amount * 0.5", expected: "61728394.561728394506172839"},
+		{expression: "rate < 0.000001 ? amount - rate : amount", expected: "123456789.123456789012345677"},
+		{expression: "floorTo(amount, 17)", expected: "123456789.12345678901234567"},
+		{expression: "1 + amount", expected: "123456790.123456789012345678"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			rules, err := NewExprPricingRules(RulesFile{Default: tt.expression}, 50*time.Millisecond)
+			require.NoError(t, err)
+
+			amount, _, err := rules.Apply(context.Background(), input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount.String())
+		})
+	}
+}
+
+func TestExprPricingRules_DivisionByZero(t *testing.T) {
+	rules, err := NewExprPricingRules(RulesFile{Default: "amount / (rate - rate)"}, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	_, _, err = rules.Apply(context.Background(), testInput(""))
+
+	require.ErrorIs(t, err, services.ErrPricingRuleFailed)
+	assert.Contains(t, err.Error(), "division by zero")
+}
+
+func TestExprPricingRules_NoDefault(t *testing.T) {
+	rules, err := NewExprPricingRules(RulesFile{Tenants: map[string]string{"acme": "amount"}}, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	input := testInput("other")
+	amount, rule, err := rules.Apply(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Empty(t, rule)
+	assert.True(t, input.Amount.Equal(amount))
+}
+
+func TestNewExprPricingRules_InvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          RulesFile
+		expectedError string
+	}{
+		{
+			name:          "syntax error",
+			file:          RulesFile{Tenants: map[string]string{"acme": "amount *"}},
+			expectedError: "invalid pricing rule for tenant acme",
+		},
+		{
+			name:          "unknown variable",
+			file:          RulesFile{Default: "amount * markup"},
+			expectedError: "invalid default pricing rule",
+		},
+		{
+			name:          "non numeric result",
+			file:          RulesFile{Default: "from"},
+			expectedError: "invalid default pricing rule",
+		},
+		{
+			name:          "too many nodes",
+			file:          RulesFile{Default: "amount" + strings.Repeat(" + 1", 300)},
+			expectedError: "invalid default pricing rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewExprPricingRules(tt.file, 50*time.Millisecond)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestExprPricingRules_RejectsNegativeResult(t *testing.T) {
+	rules, err := NewExprPricingRules(RulesFile{Default: "amount - 1000000"}, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	_, _, err = rules.Apply(context.Background(), testInput(""))
+
+	require.ErrorIs(t, err, services.ErrInvalidPricedAmount)
+	assert.Contains(t, err.Error(), "default produced an invalid amount")
+}
+
+func TestExprPricingRules_MemoryBudget(t *testing.T) {
+	rules, err := NewExprPricingRules(RulesFile{Default: "len(map(1..100000, # * 2)) + amount"}, time.Second)
+	require.NoError(t, err)
+
+	_, _, err = rules.Apply(context.Background(), testInput(""))
+
+	require.ErrorIs(t, err, services.ErrPricingRuleFailed)
+}
+
+func TestLoadExprPricingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pricing.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tenants":{"acme":"amount * 2"}}`), 0o600))
+
+	rules, err := LoadExprPricingRules(path, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	amount, rule, err := rules.Apply(context.Background(), testInput("acme"))
+	require.NoError(t, err)
+	assert.Equal(t, "tenant:acme", rule)
+	assert.Equal(t, "114188.628628", amount.String())
+
+	_, err = LoadExprPricingRules(filepath.Join(t.TempDir(), "missing.json"), time.Second)
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+)
+
+const defaultPollInterval = 5 * time.Minute
+
+// Poller asks comparison providers for the same currencies on a fixed
+// interval, so they show up in reports without serving any traffic. The
+// repositories are expected to record their requests with a Tracker.
+type Poller struct {
+	providers  map[string]repositories.RatesRepository
+	currencies []string
+	interval   time.Duration
+	logger     logger.Logger
+}
+
+func NewPoller(providers map[string]repositories.RatesRepository, currencies []string, interval time.Duration, log logger.Logger) *Poller {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return &Poller{
+		providers:  providers,
+		currencies: currencies,
+		interval:   interval,
+		logger:     log,
+	}
+}
+
+// Run polls every provider right away and then once per interval until ctx
+// is done.
+func (p *Poller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Poller) poll(ctx context.Context) {
+	names := make([]string, 0, len(p.providers))
+	for name := range p.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pollCtx, cancel := context.WithTimeout(ctx, p.interval)
+		if _, _, err := p.providers[name].GetRates(pollCtx, p.currencies); err != nil {
+			p.logger.Warn("Comparison provider failed", "provider", name, "error", err)
+		}
+		cancel()
+	}
+}
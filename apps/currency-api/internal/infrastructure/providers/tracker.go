@@ -0,0 +1,225 @@
+package providers
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+)
+
+const (
+	// retentionDays keeps enough history for month-to-date quota usage.
+	retentionDays = 35
+	// maxLatencySamples bounds the per-day latency reservoir of a provider.
+	maxLatencySamples = 2048
+)
+
+type dayStats struct {
+	requests  int
+	failures  int
+	latencies []time.Duration
+	seen      int
+	// rates holds the last rate per currency per hour of the day.
+	rates map[int]map[string]float64
+}
+
+// Tracker records every request to a rate provider and builds daily
+// comparison reports from them.
+type Tracker struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	providers []string
+	primary   map[string]bool
+	days      map[string]map[string]*dayStats
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		now:     time.Now,
+		primary: make(map[string]bool),
+		days:    make(map[string]map[string]*dayStats),
+	}
+}
+
+// Register lists a provider in reports even before it served a request.
+func (t *Tracker) Register(provider string, primary bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, known := t.primary[provider]; !known {
+		t.providers = append(t.providers, provider)
+	}
+	t.primary[provider] = primary
+}
+
+// Record adds one provider request that took latency and returned rates, or
+// failed with err.
+func (t *Tracker) Record(provider string, latency time.Duration, rates map[string]float64, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now().UTC()
+	if _, known := t.primary[provider]; !known {
+		t.providers = append(t.providers, provider)
+		t.primary[provider] = false
+	}
+
+	day := t.day(now)[provider]
+	day.requests++
+	day.seen++
+	if len(day.latencies) < maxLatencySamples {
+		day.latencies = append(day.latencies, latency)
+	} else if i := rand.IntN(day.seen); i < maxLatencySamples {
+		day.latencies[i] = latency
+	}
+
+	if err != nil {
+		day.failures++
+		return
+	}
+
+	hour := day.rates[now.Hour()]
+	if hour == nil {
+		hour = make(map[string]float64)
+		day.rates[now.Hour()] = hour
+	}
+	for currency, rate := range rates {
+		hour[currency] = rate
+	}
+}
+
+func (t *Tracker) Report(date time.Time) entities.ProviderReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	date = date.UTC().Truncate(24 * time.Hour)
+	days := t.days[dayKey(date)]
+	deviations, samples := deviationFromConsensus(days)
+
+	report := entities.ProviderReport{Date: date, Providers: make([]entities.ProviderStats, 0, len(t.providers))}
+	for _, provider := range t.providers {
+		stats := entities.ProviderStats{
+			Provider:         provider,
+			Primary:          t.primary[provider],
+			DeviationSamples: samples[provider],
+			QuotaUsed:        t.monthToDate(provider, date),
+		}
+		if samples[provider] > 0 {
+			stats.MeanDeviation = deviations[provider] / float64(samples[provider])
+		}
+
+		if day := days[provider]; day != nil && day.requests > 0 {
+			stats.Requests = day.requests
+			stats.Failures = day.failures
+			stats.Availability = float64(day.requests-day.failures) / float64(day.requests)
+			stats.LatencyP50 = percentile(day.latencies, 0.5)
+			stats.LatencyP95 = percentile(day.latencies, 0.95)
+		}
+		report.Providers = append(report.Providers, stats)
+	}
+	return report
+}
+
+// day returns the stats of every provider for the day of now, creating them
+// and dropping days past the retention.
+func (t *Tracker) day(now time.Time) map[string]*dayStats {
+	key := dayKey(now)
+	days, exists := t.days[key]
+	if !exists {
+		days = make(map[string]*dayStats)
+		t.days[key] = days
+
+		oldest := dayKey(now.AddDate(0, 0, -retentionDays))
+		for existing := range t.days {
+			if existing < oldest {
+				delete(t.days, existing)
+			}
+		}
+	}
+
+	for _, provider := range t.providers {
+		if days[provider] == nil {
+			days[provider] = &dayStats{rates: make(map[int]map[string]float64)}
+		}
+	}
+	return days
+}
+
+func (t *Tracker) monthToDate(provider string, date time.Time) int {
+	total := 0
+	for day := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC); !day.After(date); day = day.AddDate(0, 0, 1) {
+		if stats := t.days[dayKey(day)][provider]; stats != nil {
+			total += stats.requests
+		}
+	}
+	return total
+}
+
+// deviationFromConsensus sums, per provider, the absolute relative deviation
+// from the median of every hour and currency quoted by at least two
+// providers.
+func deviationFromConsensus(days map[string]*dayStats) (map[string]float64, map[string]int) {
+	sums := make(map[string]float64)
+	samples := make(map[string]int)
+
+	for hour := 0; hour < 24; hour++ {
+		quotes := make(map[string]map[string]float64)
+		for provider, day := range days {
+			for currency, rate := range day.rates[hour] {
+				if quotes[currency] == nil {
+					quotes[currency] = make(map[string]float64)
+				}
+				quotes[currency][provider] = rate
+			}
+		}
+
+		for _, byProvider := range quotes {
+			if len(byProvider) < 2 {
+				continue
+			}
+
+			rates := make([]float64, 0, len(byProvider))
+			for _, rate := range byProvider {
+				rates = append(rates, rate)
+			}
+			consensus := median(rates)
+			if consensus == 0 {
+				continue
+			}
+
+			for provider, rate := range byProvider {
+				sums[provider] += math.Abs(rate/consensus - 1)
+				samples[provider]++
+			}
+		}
+	}
+	return sums, samples
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2
+	}
+	return values[middle]
+}
+
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(index, 0)]
+}
+
+func dayKey(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
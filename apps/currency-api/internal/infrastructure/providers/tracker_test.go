@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker(now *time.Time) *Tracker {
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestTracker_Report(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 15, 0, 0, time.UTC)
+	tracker := newTestTracker(&now)
+	tracker.Register("openexchangerates", true)
+	tracker.Register("fixer", false)
+	tracker.Register("idle", false)
+
+	tracker.Record("openexchangerates", 100*time.Millisecond, map[string]float64{"EUR": 0.90, "GBP": 0.80}, nil)
+	tracker.Record("openexchangerates", 300*time.Millisecond, nil, errors.New("API returned status 500"))
+	tracker.Record("fixer", 200*time.Millisecond, map[string]float64{"EUR": 0.91}, nil)
+	tracker.Record("ecb", 50*time.Millisecond, map[string]float64{"EUR": 0.89}, nil)
+
+	report := tracker.Report(now)
+
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), report.Date)
+	require.Len(t, report.Providers, 4)
+	assert.Equal(t, []string{"openexchangerates", "fixer", "idle", "ecb"},
+		[]string{report.Providers[0].Provider, report.Providers[1].Provider, report.Providers[2].Provider, report.Providers[3].Provider})
+
+	primary := report.Providers[0]
+	assert.True(t, primary.Primary)
+	assert.Equal(t, 2, primary.Requests)
+	assert.Equal(t, 1, primary.Failures)
+	assert.Equal(t, 0.5, primary.Availability)
+	assert.Equal(t, 100*time.Millisecond, primary.LatencyP50)
+	assert.Equal(t, 300*time.Millisecond, primary.LatencyP95)
+	assert.Equal(t, 1, primary.DeviationSamples, "GBP had no second quote")
+	assert.InDelta(t, 0, primary.MeanDeviation, 1e-9, "the primary quoted the median")
+
+	fixer := report.Providers[1]
+	assert.Equal(t, 1, fixer.DeviationSamples)
+	assert.InDelta(t, 0.01/0.90, fixer.MeanDeviation, 1e-9)
+
+	idle := report.Providers[2]
+	assert.Zero(t, idle.Requests)
+	assert.Zero(t, idle.Availability)
+	assert.Zero(t, idle.DeviationSamples)
+}
+
+func TestTracker_Report_ComparesWithinTheHour(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(&now)
+
+	tracker.Record("a", time.Millisecond, map[string]float64{"EUR": 0.90}, nil)
+	now = now.Add(time.Hour)
+	tracker.Record("b", time.Millisecond, map[string]float64{"EUR": 0.95}, nil)
+
+	for _, stats := range tracker.Report(now).Providers {
+		assert.Zero(t, stats.DeviationSamples, "%s: quotes from different hours are not compared", stats.Provider)
+	}
+}
+
+func TestTracker_Report_QuotaUsedMonthToDate(t *testing.T) {
+	now := time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(&now)
+
+	tracker.Record("openexchangerates", time.Millisecond, nil, nil)
+	for _, day := range []int{1, 2, 2, 16, 17} {
+		now = time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC)
+		tracker.Record("openexchangerates", time.Millisecond, nil, nil)
+	}
+
+	report := tracker.Report(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+	require.Len(t, report.Providers, 1)
+	assert.Equal(t, 1, report.Providers[0].Requests)
+	assert.Equal(t, 4, report.Providers[0].QuotaUsed, "September and days after the report date are not counted")
+}
@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// VolatilityTTLPolicy derives how long a cached rate stays valid from how
+// much the rate moved over its recent fetches: stable rates are kept up to
+// MaxTTL, rates whose volatility reaches HighVolatility only MinTTL, with a
+// linear scale in between. Volatility is the root mean square of the relative
+// change between consecutive fetches of the last HistorySize rates.
+type VolatilityTTLPolicy struct {
+	MinTTL         time.Duration
+	MaxTTL         time.Duration
+	HighVolatility float64
+	HistorySize    int
+}
+
+// TTL returns the cache lifetime for a rate with the given fetch history,
+// oldest first. Without at least two fetches the rate is treated as volatile.
+func (p VolatilityTTLPolicy) TTL(history []float64) time.Duration {
+	volatility, ok := relativeVolatility(history)
+	if !ok || p.HighVolatility <= 0 {
+		return p.MinTTL
+	}
+
+	scale := math.Min(volatility/p.HighVolatility, 1)
+	return p.MaxTTL - time.Duration(scale*float64(p.MaxTTL-p.MinTTL))
+}
+
+func relativeVolatility(history []float64) (float64, bool) {
+	sum, changes := 0.0, 0
+	for i := 1; i < len(history); i++ {
+		if history[i-1] == 0 {
+			continue
+		}
+		change := history[i]/history[i-1] - 1
+		sum += change * change
+		changes++
+	}
+	if changes == 0 {
+		return 0, false
+	}
+	return math.Sqrt(sum / float64(changes)), true
+}
+
+type cachedRate struct {
+	rate        float64
+	fetchedAt   time.Time
+	expiresAt   time.Time
+	publishedAt time.Time
+	history     []float64
+}
+
+// CachingRatesRepository serves rates from memory until their volatility-
+// based TTL runs out and only fetches the currencies that expired, saving
+// upstream quota on stable rates while volatile ones stay fresh.
+type CachingRatesRepository struct {
+	inner    repositories.RatesRepository
+	policy   VolatilityTTLPolicy
+	maxStale time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	rates    map[string]*cachedRate
+	lastInfo string
+
+	requests *prometheus.CounterVec
+}
+
+type CachingRatesOption func(*CachingRatesRepository)
+
+// WithStaleFallback keeps serving expired rates fetched within maxStale while
+// the upstream fails, instead of failing the request. Zero disables it.
+func WithStaleFallback(maxStale time.Duration) CachingRatesOption {
+	return func(r *CachingRatesRepository) {
+		r.maxStale = maxStale
+	}
+}
+
+func NewCachingRatesRepository(inner repositories.RatesRepository, policy VolatilityTTLPolicy, registerer prometheus.Registerer, opts ...CachingRatesOption) repositories.RatesRepository {
+	if policy.HistorySize < 2 {
+		policy.HistorySize = 2
+	}
+
+	r := &CachingRatesRepository{
+		inner:  inner,
+		policy: policy,
+		now:    time.Now,
+		rates:  make(map[string]*cachedRate),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_rates_cache_requests_total",
+			Help: "Currencies looked up in the rates cache by result (hit, miss, stale).",
+		}, []string{"result"}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	registerer.MustRegister(r.requests)
+	return r
+}
+
+func (r *CachingRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	result, missing, info := r.lookup(currencies)
+	r.requests.WithLabelValues("hit").Add(float64(len(result)))
+	r.requests.WithLabelValues("miss").Add(float64(len(missing)))
+	if len(missing) == 0 {
+		return result, info, nil
+	}
+
+	fetched, fetchedInfo, err := r.inner.GetRates(ctx, missing)
+	if err != nil {
+		stale, fetchedAt, publishedAt, ok := r.staleFallback(missing, err)
+		if !ok {
+			return nil, repositories.RatesInfo{}, err
+		}
+		r.requests.WithLabelValues("stale").Add(float64(len(stale)))
+		for currency, rate := range stale {
+			result[currency] = rate
+		}
+		info.PublishedAt = oldest(info.PublishedAt, publishedAt)
+		info.StaleFetchedAt = fetchedAt
+		return result, info, nil
+	}
+
+	r.store(fetched, fetchedInfo)
+	for currency, rate := range fetched {
+		result[currency] = rate
+	}
+	fetchedInfo.PublishedAt = oldest(info.PublishedAt, fetchedInfo.PublishedAt)
+	return result, fetchedInfo, nil
+}
+
+// lookup returns the cached rates of currencies that have not expired, the
+// currencies to fetch and the info of the last fetch with the publish time of
+// the oldest cached rate returned.
+func (r *CachingRatesRepository) lookup(currencies []string) (map[string]float64, []string, repositories.RatesInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	result := make(map[string]float64, len(currencies))
+	info := repositories.RatesInfo{Source: r.lastInfo}
+	var missing []string
+	for _, currency := range currencies {
+		if cached, exists := r.rates[currency]; exists && now.Before(cached.expiresAt) {
+			result[currency] = cached.rate
+			info.PublishedAt = oldest(info.PublishedAt, cached.publishedAt)
+			continue
+		}
+		missing = append(missing, currency)
+	}
+	return result, missing, info
+}
+
+// oldest returns the earlier of two times, ignoring zero ones.
+func oldest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// staleFallback returns the expired rates of missing with the oldest of their
+// fetch and publish times when the upstream failed and all of them were
+// fetched within maxStale. Other errors, such as an unsupported currency, are
+// never hidden.
+func (r *CachingRatesRepository) staleFallback(missing []string, err error) (map[string]float64, time.Time, time.Time, bool) {
+	var upstreamErr *repositories.UpstreamError
+	if r.maxStale <= 0 || !errors.As(err, &upstreamErr) {
+		return nil, time.Time{}, time.Time{}, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	stale := make(map[string]float64, len(missing))
+	var fetchedAt, publishedAt time.Time
+	for _, currency := range missing {
+		cached, exists := r.rates[currency]
+		if !exists || now.Sub(cached.fetchedAt) > r.maxStale {
+			return nil, time.Time{}, time.Time{}, false
+		}
+		stale[currency] = cached.rate
+		fetchedAt = oldest(fetchedAt, cached.fetchedAt)
+		publishedAt = oldest(publishedAt, cached.publishedAt)
+	}
+	return stale, fetchedAt, publishedAt, true
+}
+
+func (r *CachingRatesRepository) store(rates map[string]float64, info repositories.RatesInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for currency, rate := range rates {
+		cached, exists := r.rates[currency]
+		if !exists {
+			cached = &cachedRate{}
+			r.rates[currency] = cached
+		}
+
+		cached.rate = rate
+		cached.fetchedAt = now
+		cached.publishedAt = info.PublishedAt
+		cached.history = append(cached.history, rate)
+		if len(cached.history) > r.policy.HistorySize {
+			cached.history = cached.history[len(cached.history)-r.policy.HistorySize:]
+		}
+		cached.expiresAt = now.Add(r.policy.TTL(cached.history))
+	}
+	r.lastInfo = info.Source
+}
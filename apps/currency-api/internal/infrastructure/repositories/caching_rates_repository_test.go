@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingRatesRepository struct {
+	rates       map[string]float64
+	err         error
+	publishedAt time.Time
+	requested   [][]string
+}
+
+func (r *countingRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	r.requested = append(r.requested, currencies)
+	if r.err != nil {
+		return nil, repositories.RatesInfo{}, r.err
+	}
+
+	result := make(map[string]float64)
+	for _, currency := range currencies {
+		if rate, ok := r.rates[currency]; ok {
+			result[currency] = rate
+		}
+	}
+	return result, repositories.RatesInfo{Source: "counting rates", PublishedAt: r.publishedAt}, nil
+}
+
+var testTTLPolicy = VolatilityTTLPolicy{
+	MinTTL:         10 * time.Second,
+	MaxTTL:         110 * time.Second,
+	HighVolatility: 0.01,
+	HistorySize:    5,
+}
+
+func TestVolatilityTTLPolicy_TTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []float64
+		expected time.Duration
+	}{
+		{name: "no history", history: nil, expected: 10 * time.Second},
+		{name: "single fetch", history: []float64{1.1}, expected: 10 * time.Second},
+		{name: "stable", history: []float64{1.1, 1.1, 1.1}, expected: 110 * time.Second},
+		{name: "half the volatility threshold", history: []float64{1, 1.005}, expected: 60 * time.Second},
+		{name: "above the volatility threshold", history: []float64{1, 1.2, 0.9, 1.3}, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, testTTLPolicy.TTL(tt.history), float64(time.Millisecond))
+		})
+	}
+}
+
+func TestCachingRatesRepository_GetRates(t *testing.T) {
+	inner := &countingRatesRepository{rates: map[string]float64{"USD": 1, "EUR": 0.9, "GBP": 0.8}}
+	repo := NewCachingRatesRepository(inner, testTTLPolicy, prometheus.NewRegistry()).(*CachingRatesRepository)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 0.9}, rates)
+	assert.Equal(t, "counting rates", info.Source)
+
+	now = now.Add(5 * time.Second)
+	rates, info, err = repo.GetRates(context.Background(), []string{"USD", "EUR", "GBP"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 0.9, "GBP": 0.8}, rates)
+	assert.Equal(t, "counting rates", info.Source)
+	assert.Equal(t, [][]string{{"USD", "EUR"}, {"GBP"}}, inner.requested, "only uncached currencies are fetched")
+
+	now = now.Add(6 * time.Second)
+	_, _, err = repo.GetRates(context.Background(), []string{"USD", "EUR", "GBP"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"USD", "EUR"}, inner.requested[2], "expired currencies are fetched again")
+}
+
+func TestCachingRatesRepository_ReplicatedRates(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	inner := &countingRatesRepository{rates: map[string]float64{"USD": 1, "EUR": 0.9}, publishedAt: now.Add(-20 * time.Second)}
+	repo := NewCachingRatesRepository(inner, testTTLPolicy, prometheus.NewRegistry()).(*CachingRatesRepository)
+	repo.now = func() time.Time { return now }
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD"})
+	require.NoError(t, err)
+
+	inner.publishedAt = now.Add(-5 * time.Second)
+	_, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-20*time.Second), info.PublishedAt, "a cached rate keeps the publish time it was fetched with")
+
+	_, info, err = repo.GetRates(context.Background(), []string{"EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-5*time.Second), info.PublishedAt)
+}
+
+func TestCachingRatesRepository_StableRatesLiveLonger(t *testing.T) {
+	inner := &countingRatesRepository{rates: map[string]float64{"USD": 1, "BTC": 0.00002}}
+	repo := NewCachingRatesRepository(inner, testTTLPolicy, prometheus.NewRegistry()).(*CachingRatesRepository)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	for _, btc := range []float64{0.00002, 0.000025, 0.00002} {
+		inner.rates["BTC"] = btc
+		_, _, err := repo.GetRates(context.Background(), []string{"USD", "BTC"})
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+	}
+
+	inner.requested = nil
+	now = now.Add(-time.Minute).Add(30 * time.Second)
+	_, _, err := repo.GetRates(context.Background(), []string{"USD", "BTC"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"BTC"}}, inner.requested, "the stable rate is still cached, the volatile one is not")
+}
+
+func TestCachingRatesRepository_GetRates_Error(t *testing.T) {
+	inner := &countingRatesRepository{err: errors.New("upstream down")}
+	repo := NewCachingRatesRepository(inner, testTTLPolicy, prometheus.NewRegistry())
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	assert.EqualError(t, err, "upstream down")
+}
+
+func TestCachingRatesRepository_StaleFallback(t *testing.T) {
+	inner := &countingRatesRepository{rates: map[string]float64{"USD": 1, "EUR": 0.9}}
+	repo := NewCachingRatesRepository(inner, testTTLPolicy, prometheus.NewRegistry(), WithStaleFallback(time.Hour)).(*CachingRatesRepository)
+	fetchedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	now := fetchedAt
+	repo.now = func() time.Time { return now }
+
+	_, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.True(t, info.StaleFetchedAt.IsZero())
+
+	inner.err = &repositories.UpstreamError{Source: "test", Retryable: true, Err: errors.New("upstream down")}
+	now = now.Add(30 * time.Minute)
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 0.9}, rates)
+	assert.Equal(t, "counting rates", info.Source)
+	assert.Equal(t, fetchedAt, info.StaleFetchedAt)
+
+	_, _, err = repo.GetRates(context.Background(), []string{"USD", "GBP"})
+	assert.Error(t, err, "a currency without a cached rate cannot be served stale")
+
+	now = fetchedAt.Add(61 * time.Minute)
+	_, _, err = repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	assert.Error(t, err, "rates older than the maximum staleness are not served")
+
+	inner.err = &repositories.UnsupportedCurrencyError{Currency: "EUR"}
+	now = fetchedAt.Add(30 * time.Minute)
+	_, _, err = repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	assert.Error(t, err, "only upstream failures fall back to stale rates")
+
+	inner.err = nil
+	_, info, err = repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.True(t, info.StaleFetchedAt.IsZero())
+}
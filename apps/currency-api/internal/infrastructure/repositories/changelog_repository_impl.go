@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+//go:embed data/changelog.json
+var changelogJSON []byte
+
+type ChangelogRepositoryImpl struct {
+	once    sync.Once
+	entries []entities.ChangelogEntry
+	err     error
+}
+
+func NewChangelogRepositoryImpl() repositories.ChangelogRepository {
+	return &ChangelogRepositoryImpl{}
+}
+
+func (r *ChangelogRepositoryImpl) GetEntries(ctx context.Context) ([]entities.ChangelogEntry, error) {
+	r.once.Do(func() {
+		if err := json.Unmarshal(changelogJSON, &r.entries); err != nil {
+			r.err = fmt.Errorf("failed to decode embedded changelog: %w", err)
+		}
+	})
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	entries := make([]entities.ChangelogEntry, len(r.entries))
+	copy(entries, r.entries)
+	return entries, nil
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelogRepositoryImpl_EmbeddedEntriesAreValid(t *testing.T) {
+	repo := NewChangelogRepositoryImpl()
+
+	entries, err := repo.GetEntries(context.Background())
+
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	validTypes := map[string]bool{"added": true, "changed": true, "deprecated": true, "removed": true, "fixed": true}
+	for _, entry := range entries {
+		assert.Regexp(t, `^\d+\.\d+\.\d+$`, entry.Version, "entry %+v", entry)
+		_, err := time.Parse("2006-01-02", entry.Date)
+		assert.NoError(t, err, "entry %+v has invalid date", entry)
+		assert.True(t, validTypes[entry.Type], "entry %+v has unknown type", entry)
+		assert.NotEmpty(t, entry.Description, "entry %+v has no description", entry)
+	}
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeScheduleRepositories(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repos := map[string]repositories.FeeScheduleRepository{
+		"memory": NewMemoryFeeScheduleRepository(),
+		"redis":  NewRedisFeeScheduleRepository(client),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			publishedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+			versions, err := repo.Versions(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, versions)
+
+			changes, err := repo.Changes(ctx)
+			require.NoError(t, err)
+
+			first := entities.FeeSchedule{
+				EffectiveFrom: publishedAt,
+				PublishedAt:   publishedAt,
+				PublishedBy:   "ops",
+				Fees:          map[string]entities.Fee{"*": {SpreadBps: decimal.RequireFromString("25"), Flat: decimal.RequireFromString("0")}},
+				Limits:        map[string]entities.AmountLimit{"USDT": {Min: decimal.RequireFromString("10"), Max: decimal.RequireFromString("0")}},
+			}
+			stored, err := repo.Publish(ctx, first, entities.FeeAuditEntry{Actor: "ops", Reason: "launch", Changes: []string{"fees.* added: flat=0 spread_bps=25"}})
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), stored.Version)
+			assert.Equal(t, int64(1), receiveVersion(t, changes))
+
+			second := first
+			second.EffectiveFrom = publishedAt.Add(time.Hour)
+			second.Fees = map[string]entities.Fee{"*": {SpreadBps: decimal.RequireFromString("40"), Flat: decimal.RequireFromString("0")}}
+			stored, err = repo.Publish(ctx, second, entities.FeeAuditEntry{Actor: "ops", Reason: "volatility"})
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), stored.Version)
+			assert.Equal(t, int64(2), receiveVersion(t, changes))
+
+			versions, err = repo.Versions(ctx)
+			require.NoError(t, err)
+			require.Len(t, versions, 2)
+			first.Version = 1
+			assert.Equal(t, first, versions[0])
+			assert.Equal(t, int64(2), versions[1].Version)
+			assert.Equal(t, "40", versions[1].Fees["*"].SpreadBps.String())
+
+			audit, err := repo.Audit(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, audit, 2)
+			assert.Equal(t, int64(2), audit[0].Version)
+			assert.Equal(t, "volatility", audit[0].Reason)
+			assert.Equal(t, int64(1), audit[1].Version)
+
+			audit, err = repo.Audit(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, audit, 1)
+			assert.Equal(t, int64(2), audit[0].Version)
+
+			// The feed closes once ctx is done.
+			cancel()
+			for range changes {
+			}
+		})
+	}
+}
+
+func TestFeeScheduleRepositories_Retention(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repos := map[string]repositories.FeeScheduleRepository{
+		"memory": NewMemoryFeeScheduleRepository(),
+		"redis":  NewRedisFeeScheduleRepository(client),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < feeSchedulesKept+5; i++ {
+				_, err := repo.Publish(ctx, entities.FeeSchedule{PublishedBy: "ops"}, entities.FeeAuditEntry{Actor: "ops"})
+				require.NoError(t, err)
+			}
+
+			versions, err := repo.Versions(ctx)
+			require.NoError(t, err)
+			require.Len(t, versions, feeSchedulesKept)
+			assert.Equal(t, int64(6), versions[0].Version)
+			assert.Equal(t, int64(feeSchedulesKept+5), versions[len(versions)-1].Version)
+		})
+	}
+}
+
+func receiveVersion(t *testing.T, changes <-chan int64) int64 {
+	t.Helper()
+
+	select {
+	case version := <-changes:
+		return version
+	case <-time.After(time.Second):
+		t.Fatal("no change signal received")
+		return 0
+	}
+}
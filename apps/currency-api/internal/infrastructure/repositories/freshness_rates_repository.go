@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/freshness"
+)
+
+// FreshnessRatesRepository records every successful fetch with the freshness
+// tracker so snapshot age can be measured per asset class.
+type FreshnessRatesRepository struct {
+	inner   repositories.RatesRepository
+	tracker *freshness.Tracker
+}
+
+func NewFreshnessRatesRepository(inner repositories.RatesRepository, tracker *freshness.Tracker) repositories.RatesRepository {
+	return &FreshnessRatesRepository{
+		inner:   inner,
+		tracker: tracker,
+	}
+}
+
+func (r *FreshnessRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	rates, info, err := r.inner.GetRates(ctx, currencies)
+	if err != nil {
+		return nil, repositories.RatesInfo{}, err
+	}
+
+	fetched := make([]string, 0, len(rates))
+	for currency := range rates {
+		fetched = append(fetched, currency)
+	}
+
+	// Replicated rates are as old as their snapshot, not as the read.
+	observedAt := time.Now()
+	if !info.PublishedAt.IsZero() {
+		observedAt = info.PublishedAt
+	}
+	r.tracker.Observe(fetched, observedAt)
+
+	return rates, info, nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRepositories(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repos := map[string]repositories.JobRepository{
+		"memory": NewMemoryJobRepository(),
+		"redis":  NewRedisJobRepository(client, time.Hour),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			createdAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			job := entities.Job{ID: "job-" + name, Type: "bulk_conversion", Status: entities.JobRunning, Total: 10, Processed: 4, CreatedAt: createdAt}
+
+			_, err := repo.Get(ctx, job.ID)
+			assert.ErrorIs(t, err, repositories.ErrJobNotFound)
+
+			require.NoError(t, repo.Save(ctx, job))
+			stored, err := repo.Get(ctx, job.ID)
+			require.NoError(t, err)
+			assert.Equal(t, job, stored)
+
+			_, err = repo.GetResult(ctx, job.ID)
+			assert.ErrorIs(t, err, repositories.ErrJobNotFound)
+
+			result := entities.JobResult{ContentType: "text/csv", Filename: "results.csv", Data: []byte("a,b\n")}
+			require.NoError(t, repo.SaveResult(ctx, job.ID, result))
+			storedResult, err := repo.GetResult(ctx, job.ID)
+			require.NoError(t, err)
+			assert.Equal(t, result, storedResult)
+		})
+	}
+}
+
+func TestRedisJobRepository_Retention(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	repo := NewRedisJobRepository(client, time.Hour)
+
+	require.NoError(t, repo.Save(context.Background(), entities.Job{ID: "job-1", Status: entities.JobCompleted}))
+	server.FastForward(2 * time.Hour)
+
+	_, err := repo.Get(context.Background(), "job-1")
+	assert.ErrorIs(t, err, repositories.ErrJobNotFound)
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// MemoryDeliveryRepository keeps the most recent deliveries of each
+// subscription in process memory; older ones are dropped once a subscription
+// has more than capacity.
+type MemoryDeliveryRepository struct {
+	capacity int
+
+	mu         sync.RWMutex
+	deliveries map[string][]entities.Delivery
+}
+
+func NewMemoryDeliveryRepository(capacity int) repositories.DeliveryRepository {
+	return &MemoryDeliveryRepository{
+		capacity:   capacity,
+		deliveries: make(map[string][]entities.Delivery),
+	}
+}
+
+func (r *MemoryDeliveryRepository) Save(ctx context.Context, delivery entities.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := append(r.deliveries[delivery.SubscriptionID], delivery)
+	if r.capacity > 0 && len(deliveries) > r.capacity {
+		deliveries = append([]entities.Delivery(nil), deliveries[len(deliveries)-r.capacity:]...)
+	}
+	r.deliveries[delivery.SubscriptionID] = deliveries
+	return nil
+}
+
+func (r *MemoryDeliveryRepository) Get(ctx context.Context, subscriptionID, id string) (entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, delivery := range r.deliveries[subscriptionID] {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return entities.Delivery{}, repositories.ErrDeliveryNotFound
+}
+
+func (r *MemoryDeliveryRepository) List(ctx context.Context, subscriptionID string, limit int) ([]entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.deliveries[subscriptionID]
+	result := make([]entities.Delivery, 0, min(limit, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, stored[i])
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDeliveryRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryDeliveryRepository(3)
+
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, repo.Save(ctx, entities.Delivery{ID: fmt.Sprintf("d%d", i), SubscriptionID: "sub-1"}))
+	}
+	require.NoError(t, repo.Save(ctx, entities.Delivery{ID: "other", SubscriptionID: "sub-2"}))
+
+	deliveries, err := repo.List(ctx, "sub-1", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	assert.Equal(t, "d4", deliveries[0].ID)
+	assert.Equal(t, "d2", deliveries[2].ID)
+
+	deliveries, err = repo.List(ctx, "sub-1", 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "d4", deliveries[0].ID)
+
+	delivery, err := repo.Get(ctx, "sub-1", "d3")
+	require.NoError(t, err)
+	assert.Equal(t, "d3", delivery.ID)
+
+	_, err = repo.Get(ctx, "sub-1", "d1")
+	assert.ErrorIs(t, err, repositories.ErrDeliveryNotFound)
+	_, err = repo.Get(ctx, "sub-2", "d3")
+	assert.ErrorIs(t, err, repositories.ErrDeliveryNotFound)
+
+	deliveries, err = repo.List(ctx, "unknown", 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+const (
+	// feeSchedulesKept bounds the stored versions; staged versions and the
+	// one in effect are always among the newest.
+	feeSchedulesKept = 50
+	feeAuditKept     = 500
+)
+
+// MemoryFeeScheduleRepository keeps fee schedules in process memory. Changes
+// only reach this instance, so it suits single-instance deployments.
+type MemoryFeeScheduleRepository struct {
+	mu       sync.Mutex
+	versions []entities.FeeSchedule
+	audit    []entities.FeeAuditEntry
+	watchers map[chan int64]struct{}
+}
+
+func NewMemoryFeeScheduleRepository() repositories.FeeScheduleRepository {
+	return &MemoryFeeScheduleRepository{watchers: make(map[chan int64]struct{})}
+}
+
+func (r *MemoryFeeScheduleRepository) Publish(ctx context.Context, schedule entities.FeeSchedule, audit entities.FeeAuditEntry) (entities.FeeSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedule.Version = 1
+	if len(r.versions) > 0 {
+		schedule.Version = r.versions[len(r.versions)-1].Version + 1
+	}
+	audit.Version = schedule.Version
+
+	r.versions = append(r.versions, schedule)
+	if len(r.versions) > feeSchedulesKept {
+		r.versions = append([]entities.FeeSchedule(nil), r.versions[len(r.versions)-feeSchedulesKept:]...)
+	}
+	r.audit = append(r.audit, audit)
+	if len(r.audit) > feeAuditKept {
+		r.audit = append([]entities.FeeAuditEntry(nil), r.audit[len(r.audit)-feeAuditKept:]...)
+	}
+
+	for watcher := range r.watchers {
+		select {
+		case watcher <- schedule.Version:
+		default:
+		}
+	}
+	return schedule, nil
+}
+
+func (r *MemoryFeeScheduleRepository) Versions(ctx context.Context) ([]entities.FeeSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]entities.FeeSchedule(nil), r.versions...), nil
+}
+
+func (r *MemoryFeeScheduleRepository) Audit(ctx context.Context, limit int) ([]entities.FeeAuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]entities.FeeAuditEntry, 0, min(limit, len(r.audit)))
+	for i := len(r.audit) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, r.audit[i])
+	}
+	return result, nil
+}
+
+func (r *MemoryFeeScheduleRepository) Changes(ctx context.Context) (<-chan int64, error) {
+	changes := make(chan int64, 1)
+
+	r.mu.Lock()
+	r.watchers[changes] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.mu.Lock()
+		delete(r.watchers, changes)
+		r.mu.Unlock()
+		close(changes)
+	}()
+	return changes, nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// MemoryJobRepository keeps jobs in process memory. Jobs do not survive a
+// restart and are only visible on the instance that accepted them.
+type MemoryJobRepository struct {
+	mu      sync.RWMutex
+	jobs    map[string]entities.Job
+	results map[string]entities.JobResult
+}
+
+func NewMemoryJobRepository() repositories.JobRepository {
+	return &MemoryJobRepository{
+		jobs:    make(map[string]entities.Job),
+		results: make(map[string]entities.JobResult),
+	}
+}
+
+func (r *MemoryJobRepository) Save(ctx context.Context, job entities.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *MemoryJobRepository) Get(ctx context.Context, id string) (entities.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return entities.Job{}, repositories.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (r *MemoryJobRepository) SaveResult(ctx context.Context, id string, result entities.JobResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[id]; !exists {
+		return repositories.ErrJobNotFound
+	}
+	r.results[id] = result
+	return nil
+}
+
+func (r *MemoryJobRepository) GetResult(ctx context.Context, id string) (entities.JobResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, exists := r.results[id]
+	if !exists {
+		return entities.JobResult{}, repositories.ErrJobNotFound
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+const (
+	// conversionSamplesTTL keeps a day's samples until the audit after
+	// midnight has had a chance to read them.
+	conversionSamplesTTL = 48 * time.Hour
+	precisionReportsTTL  = 90 * 24 * time.Hour
+)
+
+// MemoryPrecisionAuditRepository keeps samples and reports in process memory,
+// so each instance audits only the conversions it served.
+type MemoryPrecisionAuditRepository struct {
+	maxSamples int
+
+	mu          sync.Mutex
+	conversions map[string][]entities.ConversionRecord
+	reports     map[string]entities.PrecisionAuditReport
+}
+
+// NewMemoryPrecisionAuditRepository keeps up to maxSamples conversions per
+// day; later ones are dropped.
+func NewMemoryPrecisionAuditRepository(maxSamples int) repositories.PrecisionAuditRepository {
+	return &MemoryPrecisionAuditRepository{
+		maxSamples:  maxSamples,
+		conversions: make(map[string][]entities.ConversionRecord),
+		reports:     make(map[string]entities.PrecisionAuditReport),
+	}
+}
+
+func (r *MemoryPrecisionAuditRepository) AddConversion(ctx context.Context, record entities.ConversionRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for day := range r.conversions {
+		if auditDayExpired(day, record.At, conversionSamplesTTL) {
+			delete(r.conversions, day)
+		}
+	}
+
+	day := auditDay(record.At)
+	if len(r.conversions[day]) < r.maxSamples {
+		r.conversions[day] = append(r.conversions[day], record)
+	}
+	return nil
+}
+
+func (r *MemoryPrecisionAuditRepository) Conversions(ctx context.Context, date time.Time) ([]entities.ConversionRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]entities.ConversionRecord(nil), r.conversions[auditDay(date)]...), nil
+}
+
+func (r *MemoryPrecisionAuditRepository) SaveReport(ctx context.Context, report entities.PrecisionAuditReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for day := range r.reports {
+		if auditDayExpired(day, report.RanAt, precisionReportsTTL) {
+			delete(r.reports, day)
+		}
+	}
+	r.reports[auditDay(report.Date)] = report
+	return nil
+}
+
+func (r *MemoryPrecisionAuditRepository) Report(ctx context.Context, date time.Time) (entities.PrecisionAuditReport, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, ok := r.reports[auditDay(date)]
+	return report, ok, nil
+}
+
+func auditDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// auditDayExpired reports whether the UTC day ended more than ttl before now.
+func auditDayExpired(day string, now time.Time, ttl time.Duration) bool {
+	start, err := time.Parse(time.DateOnly, day)
+	return err != nil || now.Sub(start.Add(24*time.Hour)) > ttl
+}
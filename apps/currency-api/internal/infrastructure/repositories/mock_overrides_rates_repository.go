@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/configfile"
+	"github.com/shopspring/decimal"
+)
+
+// MockOverridesFile is the on-disk format of MOCK_OVERRIDES_FILE. Pairs are
+// "FROM-TO" keys; the inverse pair is derived unless configured explicitly.
+type MockOverridesFile struct {
+	Pairs map[string]decimal.Decimal `json:"pairs"`
+}
+
+// MockOverridesRatesRepository decorates a rates repository with fixed rates
+// for selected pairs, e.g. to keep demo environments deterministic.
+type MockOverridesRatesRepository struct {
+	inner     repositories.RatesRepository
+	overrides map[string]decimal.Decimal
+}
+
+func LoadMockOverrides(path string) (map[string]decimal.Decimal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock overrides: %w", err)
+	}
+
+	if err := configfile.Validate(configfile.MockOverrides, data); err != nil {
+		return nil, fmt.Errorf("failed to decode mock overrides: %w", err)
+	}
+
+	var file MockOverridesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode mock overrides: %w", err)
+	}
+
+	return normalizeMockOverrides(file.Pairs)
+}
+
+func NewMockOverridesRatesRepository(inner repositories.RatesRepository, overrides map[string]decimal.Decimal) repositories.RatesRepository {
+	return &MockOverridesRatesRepository{
+		inner:     inner,
+		overrides: overrides,
+	}
+}
+
+func (r *MockOverridesRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	rates, info, err := r.inner.GetRates(ctx, currencies)
+	if err != nil {
+		return nil, repositories.RatesInfo{}, err
+	}
+
+	if pinned := r.pinnedPairs(currencies); len(pinned) > 0 {
+		info.Source = fmt.Sprintf("%s | 🎭 Mock overrides: %s", info.Source, strings.Join(pinned, ","))
+	}
+
+	return rates, info, nil
+}
+
+func (r *MockOverridesRatesRepository) RateOverrides() map[string]decimal.Decimal {
+	return r.overrides
+}
+
+func (r *MockOverridesRatesRepository) pinnedPairs(currencies []string) []string {
+	var pinned []string
+	for _, from := range currencies {
+		for _, to := range currencies {
+			if _, exists := r.overrides[from+"-"+to]; exists && from != to {
+				pinned = append(pinned, from+"-"+to)
+			}
+		}
+	}
+	return pinned
+}
+
+func normalizeMockOverrides(pairs map[string]decimal.Decimal) (map[string]decimal.Decimal, error) {
+	explicit := make(map[string]decimal.Decimal, len(pairs))
+	for key, rate := range pairs {
+		from, to, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(key)), "-")
+		if !ok || from == "" || to == "" || from == to {
+			return nil, fmt.Errorf("invalid mock override pair %q, expected FROM-TO", key)
+		}
+		if !rate.IsPositive() {
+			return nil, fmt.Errorf("mock override for %s must be positive", key)
+		}
+		explicit[from+"-"+to] = rate
+	}
+
+	overrides := make(map[string]decimal.Decimal, len(explicit)*2)
+	for pair, rate := range explicit {
+		overrides[pair] = rate
+
+		from, to, _ := strings.Cut(pair, "-")
+		if _, exists := explicit[to+"-"+from]; !exists {
+			overrides[to+"-"+from] = decimal.NewFromInt(1).Div(rate)
+		}
+	}
+
+	return overrides, nil
+}
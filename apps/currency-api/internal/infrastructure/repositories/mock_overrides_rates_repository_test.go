@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticRatesRepository struct {
+	rates map[string]float64
+	err   error
+}
+
+func (r staticRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	if r.err != nil {
+		return nil, repositories.RatesInfo{}, r.err
+	}
+	return r.rates, repositories.RatesInfo{Source: "static rates"}, nil
+}
+
+func writeMockOverrides(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadMockOverrides(t *testing.T) {
+	path := writeMockOverrides(t, `{"pairs":{"usd-eur":"1.10","EUR-GBP":0.5,"GBP-EUR":2.5}}`)
+
+	overrides, err := LoadMockOverrides(path)
+
+	require.NoError(t, err)
+	assert.Len(t, overrides, 4)
+	assert.True(t, decimal.RequireFromString("1.10").Equal(overrides["USD-EUR"]))
+	assert.True(t, decimal.NewFromInt(1).Div(decimal.RequireFromString("1.10")).Equal(overrides["EUR-USD"]))
+	assert.True(t, decimal.RequireFromString("0.5").Equal(overrides["EUR-GBP"]))
+	assert.True(t, decimal.RequireFromString("2.5").Equal(overrides["GBP-EUR"]), "explicit inverse must win")
+}
+
+func TestLoadMockOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{name: "malformed json", content: `{"pairs":`, expectedError: "failed to decode mock overrides"},
+		{name: "missing separator", content: `{"pairs":{"USDEUR":1.1}}`, expectedError: "expected FROM-TO"},
+		{name: "same currency", content: `{"pairs":{"USD-USD":1}}`, expectedError: "expected FROM-TO"},
+		{name: "non positive rate", content: `{"pairs":{"USD-EUR":0}}`, expectedError: "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMockOverrides(writeMockOverrides(t, tt.content))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+
+	_, err := LoadMockOverrides(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestMockOverridesRatesRepository_GetRates(t *testing.T) {
+	inner := staticRatesRepository{rates: map[string]float64{"USD": 1.0, "EUR": 0.85, "GBP": 0.73}}
+	repo := NewMockOverridesRatesRepository(inner, map[string]decimal.Decimal{
+		"USD-EUR": decimal.RequireFromString("1.10"),
+		"EUR-USD": decimal.RequireFromString("0.90"),
+	})
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, inner.rates, rates)
+	assert.Equal(t, "static rates | 🎭 Mock overrides: USD-EUR,EUR-USD", info.Source)
+
+	_, info, err = repo.GetRates(context.Background(), []string{"USD", "GBP"})
+	require.NoError(t, err)
+	assert.Equal(t, "static rates", info.Source)
+
+	overrides, ok := repo.(repositories.RateOverrides)
+	require.True(t, ok)
+	assert.Len(t, overrides.RateOverrides(), 2)
+}
+
+func TestMockOverridesRatesRepository_PropagatesErrors(t *testing.T) {
+	repo := NewMockOverridesRatesRepository(staticRatesRepository{err: fmt.Errorf("upstream down")}, nil)
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upstream down")
+}
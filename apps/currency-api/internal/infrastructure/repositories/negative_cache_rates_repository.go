@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+)
+
+// NegativeCacheRatesRepository remembers currencies the provider rejected as
+// unsupported for ttl, so repeated requests for bogus codes fail without an
+// upstream call.
+type NegativeCacheRatesRepository struct {
+	inner repositories.RatesRepository
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu          sync.Mutex
+	unsupported map[string]time.Time
+}
+
+func NewNegativeCacheRatesRepository(inner repositories.RatesRepository, ttl time.Duration) repositories.RatesRepository {
+	return &NegativeCacheRatesRepository{
+		inner:       inner,
+		ttl:         ttl,
+		now:         time.Now,
+		unsupported: make(map[string]time.Time),
+	}
+}
+
+func (r *NegativeCacheRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	if err := r.lookup(currencies); err != nil {
+		return nil, repositories.RatesInfo{}, err
+	}
+
+	rates, info, err := r.inner.GetRates(ctx, currencies)
+	if err != nil {
+		var unsupported *repositories.UnsupportedCurrencyError
+		if errors.As(err, &unsupported) {
+			unsupported.CachedUntil = r.remember(unsupported.Currency)
+		}
+		return nil, repositories.RatesInfo{}, err
+	}
+	return rates, info, nil
+}
+
+func (r *NegativeCacheRatesRepository) lookup(currencies []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, currency := range currencies {
+		expiresAt, cached := r.unsupported[currency]
+		if !cached {
+			continue
+		}
+		if !now.Before(expiresAt) {
+			delete(r.unsupported, currency)
+			continue
+		}
+		return &repositories.UnsupportedCurrencyError{Currency: currency, Cached: true, CachedUntil: expiresAt}
+	}
+	return nil
+}
+
+func (r *NegativeCacheRatesRepository) remember(currency string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiresAt := r.now().Add(r.ttl)
+	r.unsupported[currency] = expiresAt
+	return expiresAt
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegativeCacheRatesRepository_GetRates(t *testing.T) {
+	inner := &countingRatesRepository{err: fmt.Errorf("failed to fetch live exchange rates: %w", &repositories.UnsupportedCurrencyError{Currency: "XYZ"})}
+	repo := NewNegativeCacheRatesRepository(inner, time.Minute).(*NegativeCacheRatesRepository)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD", "XYZ"})
+	var unsupported *repositories.UnsupportedCurrencyError
+	require.ErrorAs(t, err, &unsupported)
+	assert.False(t, unsupported.Cached)
+	assert.Equal(t, now.Add(time.Minute), unsupported.CachedUntil)
+
+	now = now.Add(30 * time.Second)
+	_, _, err = repo.GetRates(context.Background(), []string{"EUR", "XYZ"})
+	require.ErrorAs(t, err, &unsupported)
+	assert.True(t, unsupported.Cached)
+	assert.Equal(t, "XYZ", unsupported.Currency)
+	assert.Len(t, inner.requested, 1, "cached unsupported currencies are not fetched")
+
+	now = now.Add(30 * time.Second)
+	inner.err = nil
+	inner.rates = map[string]float64{"USD": 1, "XYZ": 2}
+	rates, _, err := repo.GetRates(context.Background(), []string{"USD", "XYZ"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "XYZ": 2}, rates, "the provider is asked again once the entry expires")
+}
+
+func TestNegativeCacheRatesRepository_GetRates_OtherErrorsNotCached(t *testing.T) {
+	inner := &countingRatesRepository{err: fmt.Errorf("API returned status 500")}
+	repo := NewNegativeCacheRatesRepository(inner, time.Minute)
+
+	for range 2 {
+		_, _, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+		require.EqualError(t, err, "API returned status 500")
+	}
+	assert.Len(t, inner.requested, 2)
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/pkg/providerplugin"
+	"github.com/ajs/go-common/logger"
+)
+
+// PluginRatesRepository serves rates from an out-of-process provider plugin,
+// (re)starting the plugin on demand if it is not running. A plugin that does
+// not answer within timeout is killed and started on the next request.
+type PluginRatesRepository struct {
+	path    string
+	args    []string
+	timeout time.Duration
+	logger  logger.Logger
+
+	mu     sync.Mutex
+	client *providerplugin.Client
+	name   string
+}
+
+// NewPluginRatesRepository runs the plugin command line, split on
+// whitespace into the executable and its arguments.
+func NewPluginRatesRepository(command string, timeout time.Duration, log logger.Logger) (repositories.RatesRepository, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("provider plugin command is empty")
+	}
+	return &PluginRatesRepository{
+		path:    fields[0],
+		args:    fields[1:],
+		timeout: timeout,
+		logger:  log,
+	}, nil
+}
+
+func (r *PluginRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	client, name, err := r.ensureClient(ctx)
+	if err != nil {
+		// The plugin is started again on the next request.
+		return nil, repositories.RatesInfo{}, &repositories.UpstreamError{Source: r.path, Unavailable: true, Retryable: true, Err: err}
+	}
+
+	rates, err := client.GetRates(ctx, currencies)
+	if err != nil {
+		r.logger.Error("Provider plugin failed", err, "plugin", name)
+		return nil, repositories.RatesInfo{}, &repositories.UpstreamError{Source: name, Retryable: true, Err: fmt.Errorf("failed to fetch rates from plugin %s: %w", name, err)}
+	}
+
+	return rates, repositories.RatesInfo{Source: fmt.Sprintf("🧩 Provider plugin: Using rates from %s", name)}, nil
+}
+
+func (r *PluginRatesRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client == nil {
+		return nil
+	}
+	err := r.client.Close()
+	r.client = nil
+	return err
+}
+
+func (r *PluginRatesRepository) ensureClient(ctx context.Context) (*providerplugin.Client, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil && !r.client.Exited() {
+		return r.client, r.name, nil
+	}
+
+	if r.client != nil {
+		r.logger.Warn("🧩 Provider plugin exited, restarting", "plugin", r.name)
+		_ = r.client.Close()
+		r.client = nil
+	}
+
+	client, err := providerplugin.Start(r.path, r.args, providerplugin.WithCallTimeout(r.timeout))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start provider plugin: %w", err)
+	}
+
+	name, err := client.Name(ctx)
+	if err != nil {
+		_ = client.Close()
+		return nil, "", fmt.Errorf("provider plugin handshake failed: %w", err)
+	}
+
+	r.logger.Info("🧩 Provider plugin started", "plugin", name, "path", r.path)
+	r.client = client
+	r.name = name
+	return client, name, nil
+}
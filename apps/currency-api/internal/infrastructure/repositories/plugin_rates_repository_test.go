@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/pkg/providerplugin"
+	"github.com/ajs/go-common/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type helperProvider struct{}
+
+func (helperProvider) Name() string {
+	return "helper"
+}
+
+func (helperProvider) GetRates(ctx context.Context, currencies []string) (map[string]float64, error) {
+	if len(currencies) > 0 && currencies[0] == "HANG" {
+		time.Sleep(time.Hour)
+	}
+	rates := map[string]float64{"USD": 1.0, "PLN": 3.9}
+	result := make(map[string]float64)
+	for _, currency := range currencies {
+		if rate, exists := rates[currency]; exists {
+			result[currency] = rate
+		}
+	}
+	return result, nil
+}
+
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_PLUGIN_HELPER") != "1" {
+		return
+	}
+	if err := providerplugin.Serve(helperProvider{}); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestPluginRatesRepository_GetRates(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "1")
+
+	repo, err := NewPluginRatesRepository(os.Args[0]+" -test.run=TestPluginHelperProcess", time.Second, logger.New("error"))
+	require.NoError(t, err)
+	defer repo.(*PluginRatesRepository).Close()
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "PLN", "XYZ"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "🧩 Provider plugin: Using rates from helper", info.Source)
+	assert.Equal(t, map[string]float64{"USD": 1.0, "PLN": 3.9}, rates)
+}
+
+func TestPluginRatesRepository_RestartsAHungPlugin(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "1")
+
+	repo, err := NewPluginRatesRepository(os.Args[0]+" -test.run=TestPluginHelperProcess", 200*time.Millisecond, logger.New("error"))
+	require.NoError(t, err)
+	defer repo.(*PluginRatesRepository).Close()
+
+	_, _, err = repo.GetRates(context.Background(), []string{"HANG"})
+
+	var upstreamErr *repositories.UpstreamError
+	require.ErrorAs(t, err, &upstreamErr)
+	assert.True(t, upstreamErr.Retryable)
+	assert.ErrorIs(t, err, providerplugin.ErrCallTimeout)
+
+	rates, _, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	require.NoError(t, err, "the killed plugin is started again")
+	assert.Equal(t, map[string]float64{"USD": 1.0}, rates)
+}
+
+func TestPluginRatesRepository_MissingBinary(t *testing.T) {
+	repo, err := NewPluginRatesRepository("/nonexistent/provider-plugin", time.Second, logger.New("error"))
+	require.NoError(t, err)
+
+	_, _, err = repo.GetRates(context.Background(), []string{"USD", "EUR"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start provider plugin")
+}
+
+func TestPluginRatesRepository_BlankCommand(t *testing.T) {
+	for _, command := range []string{"", "   ", "\t\n"} {
+		_, err := NewPluginRatesRepository(command, time.Second, logger.New("error"))
+
+		assert.EqualError(t, err, "provider plugin command is empty")
+	}
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecisionAuditRepositories(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repos := map[string]repositories.PrecisionAuditRepository{
+		"memory": NewMemoryPrecisionAuditRepository(2),
+		"redis":  NewRedisPrecisionAuditRepository(client, 2),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+			sample := func(at time.Time, result string) entities.ConversionRecord {
+				return entities.ConversionRecord{
+					At:            at,
+					From:          entities.CryptoCurrencies["WBTC"],
+					To:            entities.CryptoCurrencies["USDT"],
+					Amount:        decimal.RequireFromString("1"),
+					Fee:           &entities.Fee{SpreadBps: decimal.RequireFromString("25"), Flat: decimal.RequireFromString("0")},
+					DecimalPlaces: 6,
+					Result:        decimal.RequireFromString(result),
+				}
+			}
+
+			first := sample(day.Add(time.Hour), "56951.578529")
+			second := sample(day.Add(23*time.Hour), "56951.578530")
+			require.NoError(t, repo.AddConversion(ctx, first))
+			require.NoError(t, repo.AddConversion(ctx, second))
+			// The day already holds its maximum.
+			require.NoError(t, repo.AddConversion(ctx, sample(day.Add(23*time.Hour+time.Minute), "1")))
+			require.NoError(t, repo.AddConversion(ctx, sample(day.Add(25*time.Hour), "2")))
+
+			records, err := repo.Conversions(ctx, day.Add(12*time.Hour))
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			assert.True(t, first.At.Equal(records[0].At))
+			assert.Equal(t, "56951.578529", records[0].Result.String())
+			assert.Equal(t, "25", records[0].Fee.SpreadBps.String())
+			assert.Equal(t, "56951.57853", records[1].Result.String())
+
+			records, err = repo.Conversions(ctx, day.AddDate(0, 0, 1))
+			require.NoError(t, err)
+			assert.Len(t, records, 1)
+
+			_, ok, err := repo.Report(ctx, day)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			report := entities.PrecisionAuditReport{
+				Date:     day,
+				RanAt:    day.Add(26 * time.Hour),
+				Sampled:  2,
+				Checked:  2,
+				MaxULPs:  decimal.RequireFromString("0.42"),
+				Examples: []entities.PrecisionDiscrepancy{},
+			}
+			require.NoError(t, repo.SaveReport(ctx, report))
+
+			stored, ok, err := repo.Report(ctx, day.Add(8*time.Hour))
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, 2, stored.Checked)
+			assert.Equal(t, "0.42", stored.MaxULPs.String())
+			assert.True(t, report.RanAt.Equal(stored.RanAt))
+		})
+	}
+}
+
+func TestMemoryPrecisionAuditRepository_Retention(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPrecisionAuditRepository(10)
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.AddConversion(ctx, entities.ConversionRecord{At: day.Add(time.Hour)}))
+	require.NoError(t, repo.AddConversion(ctx, entities.ConversionRecord{At: day.Add(24*time.Hour + conversionSamplesTTL + time.Minute)}))
+
+	records, err := repo.Conversions(ctx, day)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/providers"
+)
+
+// ProviderStatsRatesRepository records availability, latency and the returned
+// rates of every request to a provider for the comparison report.
+type ProviderStatsRatesRepository struct {
+	inner    repositories.RatesRepository
+	provider string
+	tracker  *providers.Tracker
+}
+
+func NewProviderStatsRatesRepository(inner repositories.RatesRepository, provider string, tracker *providers.Tracker) repositories.RatesRepository {
+	return &ProviderStatsRatesRepository{
+		inner:    inner,
+		provider: provider,
+		tracker:  tracker,
+	}
+}
+
+func (r *ProviderStatsRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	started := time.Now()
+	rates, info, err := r.inner.GetRates(ctx, currencies)
+
+	// An unknown currency is the caller's mistake, not a provider outage.
+	recorded := err
+	var unsupported *repositories.UnsupportedCurrencyError
+	if errors.As(err, &unsupported) {
+		recorded = nil
+	}
+	r.tracker.Record(r.provider, time.Since(started), rates, recorded)
+
+	return rates, info, err
+}
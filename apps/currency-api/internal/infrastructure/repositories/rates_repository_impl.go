@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -21,6 +22,13 @@ type RatesRepositoryImpl struct {
 	circuitBreaker *gobreaker.CircuitBreaker
 }
 
+// openExchangeSource names the provider in upstream errors.
+const openExchangeSource = "openexchangerates"
+
+// circuitBreakerTimeout is how long the breaker stays open before letting a
+// trial request through, and so the longest a client has to wait.
+const circuitBreakerTimeout = 30 * time.Second
+
 type OpenExchangeResponse struct {
 	Rates map[string]float64 `json:"rates"`
 }
@@ -30,10 +38,15 @@ func NewRatesRepositoryImpl(cfg *config.Config, log logger.Logger) repositories.
 		Name:        "openexchange-api",
 		MaxRequests: 3,
 		Interval:    60 * time.Second,
-		Timeout:     30 * time.Second,
+		Timeout:     circuitBreakerTimeout,
 		ReadyToTrip: func(counts gobreaker.Counts) bool {
 			return counts.ConsecutiveFailures >= 3
 		},
+		// An unknown currency is a bad request, not an unhealthy provider.
+		IsSuccessful: func(err error) bool {
+			var unsupported *repositories.UnsupportedCurrencyError
+			return err == nil || errors.As(err, &unsupported)
+		},
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			log.Info("🔌 Circuit breaker state changed",
 				"service", name,
@@ -53,10 +66,10 @@ func NewRatesRepositoryImpl(cfg *config.Config, log logger.Logger) repositories.
 	}
 }
 
-func (r *RatesRepositoryImpl) GetRates(ctx context.Context, currencies []string) (map[string]float64, string, error) {
+func (r *RatesRepositoryImpl) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
 	if r.config.OpenExchangeAPIKey == "" {
-		info := "🤖 No API key: Using mock rates"
-		r.logger.Info(info)
+		info := repositories.RatesInfo{Source: "🤖 No API key: Using mock rates"}
+		r.logger.Info(info.Source)
 		return r.getMockRates(currencies), info, nil
 	}
 
@@ -67,22 +80,39 @@ func (r *RatesRepositoryImpl) GetRates(ctx context.Context, currencies []string)
 	if err != nil {
 		if err == gobreaker.ErrOpenState {
 			r.logger.Error("⚡ Circuit breaker is OPEN - external API unavailable", err)
-			return nil, "", fmt.Errorf("external rates API is currently unavailable (service protection active)")
+			return nil, repositories.RatesInfo{}, &repositories.UpstreamError{
+				Source:      openExchangeSource,
+				Unavailable: true,
+				Retryable:   true,
+				RetryAfter:  circuitBreakerTimeout,
+				Err:         errors.New("external rates API is currently unavailable (service protection active)"),
+			}
 		}
 
 		if err == gobreaker.ErrTooManyRequests {
 			r.logger.Error("🚦 Circuit breaker limiting requests", err)
-			return nil, "", fmt.Errorf("external rates API is being rate limited (too many requests)")
+			return nil, repositories.RatesInfo{}, &repositories.UpstreamError{
+				Source:      openExchangeSource,
+				Unavailable: true,
+				Retryable:   true,
+				Err:         errors.New("external rates API is being rate limited (too many requests)"),
+			}
+		}
+
+		var unsupported *repositories.UnsupportedCurrencyError
+		if errors.As(err, &unsupported) {
+			r.logger.Warn("Currency not supported by the exchange rates provider", "currency", unsupported.Currency)
+			return nil, repositories.RatesInfo{}, fmt.Errorf("failed to fetch live exchange rates: %w", err)
 		}
 
 		r.logger.Error("External API failed", err,
 			"circuit_state", r.circuitBreaker.State().String(),
 		)
-		return nil, "", fmt.Errorf("failed to fetch live exchange rates: %w", err)
+		return nil, repositories.RatesInfo{}, fmt.Errorf("failed to fetch live exchange rates: %w", err)
 	}
 
 	rates := result.(map[string]float64)
-	info := "🔑 API key provided: Using live rates"
+	info := repositories.RatesInfo{Source: "🔑 API key provided: Using live rates"}
 	r.logger.Info("✅ Successfully fetched live rates",
 		"currencies", len(currencies),
 		"circuit_state", r.circuitBreaker.State().String(),
@@ -107,17 +137,19 @@ func (r *RatesRepositoryImpl) fetchRatesFromAPI(ctx context.Context, currencies
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, &repositories.UpstreamError{Source: openExchangeSource, Retryable: true, Err: fmt.Errorf("failed to make request: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		// A rejected API key or plan stays rejected; overload and outages pass.
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, &repositories.UpstreamError{Source: openExchangeSource, Retryable: retryable, Err: fmt.Errorf("API returned status %d", resp.StatusCode)}
 	}
 
 	var openExchangeResp OpenExchangeResponse
 	if err := json.NewDecoder(resp.Body).Decode(&openExchangeResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, &repositories.UpstreamError{Source: openExchangeSource, Err: fmt.Errorf("failed to decode response: %w", err)}
 	}
 
 	result := make(map[string]float64)
@@ -134,7 +166,7 @@ func (r *RatesRepositoryImpl) fetchRatesFromAPI(ctx context.Context, currencies
 			if rate, exists := openExchangeResp.Rates[currency]; exists {
 				result[currency] = rate
 			} else {
-				return nil, fmt.Errorf("currency '%s' is not supported by the exchange rates provider", currency)
+				return nil, &repositories.UnsupportedCurrencyError{Currency: currency}
 			}
 		}
 	}
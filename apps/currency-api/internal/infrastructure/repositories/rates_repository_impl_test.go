@@ -8,8 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ajs/currency-api/internal/domain/repositories"
 	"github.com/ajs/currency-api/internal/infrastructure/config"
 	"github.com/ajs/go-common/logger"
+	"github.com/sony/gobreaker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,7 +31,7 @@ func TestRatesRepositoryImpl_GetRates_MockData(t *testing.T) {
 	rates, info, err := repo.GetRates(ctx, currencies)
 
 	require.NoError(t, err)
-	assert.Equal(t, "🤖 No API key: Using mock rates", info)
+	assert.Equal(t, "🤖 No API key: Using mock rates", info.Source)
 
 	for _, currency := range currencies {
 		assert.Contains(t, rates, currency, "missing rate for currency %s", currency)
@@ -63,7 +65,7 @@ func TestRatesRepositoryImpl_GetRates_MockData_UnknownCurrency(t *testing.T) {
 	rates, info, err := repo.GetRates(ctx, currencies)
 
 	require.NoError(t, err)
-	assert.Equal(t, "🤖 No API key: Using mock rates", info)
+	assert.Equal(t, "🤖 No API key: Using mock rates", info.Source)
 
 	// Should have USD but not UNKNOWN
 	assert.Contains(t, rates, "USD", "expected USD rate in mock data")
@@ -103,7 +105,7 @@ func TestRatesRepositoryImpl_GetRates_WithAPIKey_Success(t *testing.T) {
 	rates, info, err := repo.GetRates(ctx, currencies)
 
 	require.NoError(t, err)
-	assert.Equal(t, "🔑 API key provided: Using live rates", info)
+	assert.Equal(t, "🔑 API key provided: Using live rates", info.Source)
 
 	expectedRates := map[string]float64{
 		"USD": 1.0,  // USD should always be 1.0
@@ -147,6 +149,15 @@ func TestRatesRepositoryImpl_GetRates_WithAPIKey_UnsupportedCurrency(t *testing.
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "currency 'INVALID' is not supported by the exchange rates provider")
+	var unsupported *repositories.UnsupportedCurrencyError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "INVALID", unsupported.Currency)
+
+	for range 3 {
+		_, _, err = repo.GetRates(ctx, currencies)
+		require.ErrorAs(t, err, &unsupported)
+	}
+	assert.Equal(t, gobreaker.StateClosed, repo.(*RatesRepositoryImpl).circuitBreaker.State(), "unsupported currencies must not trip the breaker")
 }
 
 func TestRatesRepositoryImpl_GetRates_WithAPIKey_APIError(t *testing.T) {
@@ -172,6 +183,31 @@ func TestRatesRepositoryImpl_GetRates_WithAPIKey_APIError(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to fetch live exchange rates")
+
+	var upstreamErr *repositories.UpstreamError
+	require.ErrorAs(t, err, &upstreamErr)
+	assert.False(t, upstreamErr.Unavailable)
+	assert.True(t, upstreamErr.Retryable, "a provider outage may pass")
+}
+
+func TestRatesRepositoryImpl_GetRates_WithAPIKey_Rejected(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer testServer.Close()
+
+	cfg := &config.Config{
+		OpenExchangeAPIKey:  "revoked-api-key",
+		OpenExchangeBaseURL: testServer.URL,
+	}
+	repo := NewRatesRepositoryImpl(cfg, logger.New("error"))
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD", "EUR"})
+
+	var upstreamErr *repositories.UpstreamError
+	require.ErrorAs(t, err, &upstreamErr)
+	assert.Equal(t, "openexchangerates", upstreamErr.Source)
+	assert.False(t, upstreamErr.Retryable, "a rejected API key stays rejected")
 }
 
 func TestRatesRepositoryImpl_GetRates_WithAPIKey_InvalidJSON(t *testing.T) {
@@ -197,6 +233,10 @@ func TestRatesRepositoryImpl_GetRates_WithAPIKey_InvalidJSON(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to decode response")
+
+	var upstreamErr *repositories.UpstreamError
+	require.ErrorAs(t, err, &upstreamErr)
+	assert.False(t, upstreamErr.Retryable)
 }
 
 func TestRatesRepositoryImpl_GetRates_ContextCancellation(t *testing.T) {
@@ -258,6 +298,10 @@ func TestRatesRepositoryImpl_CircuitBreaker(t *testing.T) {
 		// After 3 failures, subsequent calls should be circuit breaker errors
 		if i >= 3 && (assert.Contains(t, err.Error(), "external rates API is currently unavailable") ||
 			assert.Contains(t, err.Error(), "external rates API is being rate limited")) {
+			var upstreamErr *repositories.UpstreamError
+			require.ErrorAs(t, err, &upstreamErr)
+			assert.True(t, upstreamErr.Unavailable)
+			assert.True(t, upstreamErr.Retryable)
 			circuitBreakerTriggered = true
 			break
 		}
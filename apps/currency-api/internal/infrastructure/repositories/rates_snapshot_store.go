@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const ratesSnapshotKey = "currency-api:rates:snapshot"
+
+// RatesSnapshotEntry is one currency's rate as published by the primary region.
+type RatesSnapshotEntry struct {
+	Rate        float64   `json:"rate"`
+	PublishedAt time.Time `json:"published_at"`
+	Region      string    `json:"region"`
+}
+
+// RatesSnapshotStore keeps the latest published rate per currency in a Redis
+// hash so read replicas in other regions can serve them without upstream access.
+type RatesSnapshotStore struct {
+	client redis.UniversalClient
+}
+
+func NewRatesSnapshotStore(client redis.UniversalClient) *RatesSnapshotStore {
+	return &RatesSnapshotStore{client: client}
+}
+
+func (s *RatesSnapshotStore) Publish(ctx context.Context, region string, rates map[string]float64, publishedAt time.Time) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]any, len(rates))
+	for currency, rate := range rates {
+		data, err := json.Marshal(RatesSnapshotEntry{Rate: rate, PublishedAt: publishedAt.UTC(), Region: region})
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot for %s: %w", currency, err)
+		}
+		fields[currency] = data
+	}
+
+	if err := s.client.HSet(ctx, ratesSnapshotKey, fields).Err(); err != nil {
+		return fmt.Errorf("failed to publish rates snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load returns the published entries for the given currencies; currencies
+// that were never published are omitted.
+func (s *RatesSnapshotStore) Load(ctx context.Context, currencies []string) (map[string]RatesSnapshotEntry, error) {
+	values, err := s.client.HMGet(ctx, ratesSnapshotKey, currencies...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rates snapshot: %w", err)
+	}
+
+	entries := make(map[string]RatesSnapshotEntry, len(currencies))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var entry RatesSnapshotEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot for %s: %w", currencies[i], err)
+		}
+		entries[currencies[i]] = entry
+	}
+
+	return entries, nil
+}
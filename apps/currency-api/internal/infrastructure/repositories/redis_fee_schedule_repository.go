@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	feeVersionKey   = "currency-api:fees:version"
+	feeSchedulesKey = "currency-api:fees:schedules"
+	feeAuditKey     = "currency-api:fees:audit"
+	feeChannel      = "currency-api:fees:changed"
+)
+
+// RedisFeeScheduleRepository shares fee schedules between instances. Versions
+// live in a sorted set scored by version number, and every publish is
+// announced on a pub/sub channel so watchers reload right away.
+type RedisFeeScheduleRepository struct {
+	client redis.UniversalClient
+}
+
+func NewRedisFeeScheduleRepository(client redis.UniversalClient) repositories.FeeScheduleRepository {
+	return &RedisFeeScheduleRepository{client: client}
+}
+
+func (r *RedisFeeScheduleRepository) Publish(ctx context.Context, schedule entities.FeeSchedule, audit entities.FeeAuditEntry) (entities.FeeSchedule, error) {
+	version, err := r.client.Incr(ctx, feeVersionKey).Result()
+	if err != nil {
+		return entities.FeeSchedule{}, fmt.Errorf("failed to allocate fee schedule version: %w", err)
+	}
+	schedule.Version = version
+	audit.Version = version
+
+	scheduleData, err := json.Marshal(schedule)
+	if err != nil {
+		return entities.FeeSchedule{}, fmt.Errorf("failed to encode fee schedule %d: %w", version, err)
+	}
+	auditData, err := json.Marshal(audit)
+	if err != nil {
+		return entities.FeeSchedule{}, fmt.Errorf("failed to encode audit entry of fee schedule %d: %w", version, err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, feeSchedulesKey, redis.Z{Score: float64(version), Member: scheduleData})
+	pipe.ZRemRangeByRank(ctx, feeSchedulesKey, 0, -feeSchedulesKept-1)
+	pipe.LPush(ctx, feeAuditKey, auditData)
+	pipe.LTrim(ctx, feeAuditKey, 0, feeAuditKept-1)
+	pipe.Publish(ctx, feeChannel, version)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return entities.FeeSchedule{}, fmt.Errorf("failed to store fee schedule %d: %w", version, err)
+	}
+	return schedule, nil
+}
+
+func (r *RedisFeeScheduleRepository) Versions(ctx context.Context) ([]entities.FeeSchedule, error) {
+	values, err := r.client.ZRange(ctx, feeSchedulesKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee schedules: %w", err)
+	}
+
+	versions := make([]entities.FeeSchedule, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &versions[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode fee schedule: %w", err)
+		}
+	}
+	return versions, nil
+}
+
+func (r *RedisFeeScheduleRepository) Audit(ctx context.Context, limit int) ([]entities.FeeAuditEntry, error) {
+	values, err := r.client.LRange(ctx, feeAuditKey, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee schedule audit: %w", err)
+	}
+
+	entries := make([]entities.FeeAuditEntry, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &entries[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode fee schedule audit entry: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+func (r *RedisFeeScheduleRepository) Changes(ctx context.Context) (<-chan int64, error) {
+	subscription := r.client.Subscribe(ctx, feeChannel)
+	// Receive waits for the subscription to be confirmed, so no publish after
+	// Changes returns is missed.
+	if _, err := subscription.Receive(ctx); err != nil {
+		subscription.Close()
+		return nil, fmt.Errorf("failed to subscribe to fee schedule changes: %w", err)
+	}
+
+	changes := make(chan int64, 1)
+	go func() {
+		defer close(changes)
+		defer subscription.Close()
+
+		messages := subscription.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				version, err := strconv.ParseInt(message.Payload, 10, 64)
+				if err != nil {
+					continue
+				}
+				select {
+				case changes <- version:
+				default:
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const jobKeyPrefix = "currency-api:jobs:"
+
+// RedisJobRepository shares jobs between instances, so any instance can
+// report progress, serve results and record cancellations. Jobs and results
+// expire retention after their last update.
+type RedisJobRepository struct {
+	client    redis.UniversalClient
+	retention time.Duration
+}
+
+func NewRedisJobRepository(client redis.UniversalClient, retention time.Duration) repositories.JobRepository {
+	return &RedisJobRepository{client: client, retention: retention}
+}
+
+func (r *RedisJobRepository) Save(ctx context.Context, job entities.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", job.ID, err)
+	}
+	if err := r.client.Set(ctx, jobKeyPrefix+job.ID, data, r.retention).Err(); err != nil {
+		return fmt.Errorf("failed to store job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+func (r *RedisJobRepository) Get(ctx context.Context, id string) (entities.Job, error) {
+	var job entities.Job
+	if err := r.load(ctx, jobKeyPrefix+id, &job); err != nil {
+		return entities.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *RedisJobRepository) SaveResult(ctx context.Context, id string, result entities.JobResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result of job %s: %w", id, err)
+	}
+	if err := r.client.Set(ctx, jobKeyPrefix+id+":result", data, r.retention).Err(); err != nil {
+		return fmt.Errorf("failed to store result of job %s: %w", id, err)
+	}
+	return nil
+}
+
+func (r *RedisJobRepository) GetResult(ctx context.Context, id string) (entities.JobResult, error) {
+	var result entities.JobResult
+	if err := r.load(ctx, jobKeyPrefix+id+":result", &result); err != nil {
+		return entities.JobResult{}, err
+	}
+	return result, nil
+}
+
+func (r *RedisJobRepository) load(ctx context.Context, key string, target any) error {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return repositories.ErrJobNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return nil
+}
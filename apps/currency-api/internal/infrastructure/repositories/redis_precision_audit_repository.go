@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	conversionSamplesKeyPrefix = "currency-api:precision-audit:conversions:"
+	precisionReportKeyPrefix   = "currency-api:precision-audit:reports:"
+)
+
+// RedisPrecisionAuditRepository pools the samples of every instance, so one
+// audit covers all of the day's traffic. Each day's samples are a list that
+// expires once the audit has read it; reports expire after 90 days.
+type RedisPrecisionAuditRepository struct {
+	client     redis.UniversalClient
+	maxSamples int
+}
+
+// NewRedisPrecisionAuditRepository keeps up to maxSamples conversions per
+// day; later ones are dropped.
+func NewRedisPrecisionAuditRepository(client redis.UniversalClient, maxSamples int) repositories.PrecisionAuditRepository {
+	return &RedisPrecisionAuditRepository{client: client, maxSamples: maxSamples}
+}
+
+func (r *RedisPrecisionAuditRepository) AddConversion(ctx context.Context, record entities.ConversionRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode conversion sample: %w", err)
+	}
+
+	key := conversionSamplesKeyPrefix + auditDay(record.At)
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	// Trimming to the first maxSamples drops the sample just pushed once the
+	// day is full.
+	pipe.LTrim(ctx, key, 0, int64(r.maxSamples)-1)
+	pipe.Expire(ctx, key, 24*time.Hour+conversionSamplesTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store conversion sample: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisPrecisionAuditRepository) Conversions(ctx context.Context, date time.Time) ([]entities.ConversionRecord, error) {
+	values, err := r.client.LRange(ctx, conversionSamplesKeyPrefix+auditDay(date), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conversion samples: %w", err)
+	}
+
+	records := make([]entities.ConversionRecord, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &records[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode conversion sample: %w", err)
+		}
+	}
+	return records, nil
+}
+
+func (r *RedisPrecisionAuditRepository) SaveReport(ctx context.Context, report entities.PrecisionAuditReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode precision audit report: %w", err)
+	}
+	if err := r.client.Set(ctx, precisionReportKeyPrefix+auditDay(report.Date), data, precisionReportsTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store precision audit report: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisPrecisionAuditRepository) Report(ctx context.Context, date time.Time) (entities.PrecisionAuditReport, bool, error) {
+	data, err := r.client.Get(ctx, precisionReportKeyPrefix+auditDay(date)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return entities.PrecisionAuditReport{}, false, nil
+	}
+	if err != nil {
+		return entities.PrecisionAuditReport{}, false, fmt.Errorf("failed to load precision audit report: %w", err)
+	}
+
+	var report entities.PrecisionAuditReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return entities.PrecisionAuditReport{}, false, fmt.Errorf("failed to decode precision audit report: %w", err)
+	}
+	return report, true, nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+)
+
+// ReplicaRatesRepository serves rates purely from snapshots published by the
+// primary region, so a replica needs Redis access but no upstream credentials.
+type ReplicaRatesRepository struct {
+	store  *RatesSnapshotStore
+	logger logger.Logger
+	now    func() time.Time
+}
+
+func NewReplicaRatesRepository(store *RatesSnapshotStore, log logger.Logger) repositories.RatesRepository {
+	return &ReplicaRatesRepository{
+		store:  store,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+func (r *ReplicaRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	entries, err := r.store.Load(ctx, currencies)
+	if err != nil {
+		r.logger.Error("Failed to read replicated rates", err)
+		return nil, repositories.RatesInfo{}, &repositories.UpstreamError{
+			Source:      "rates snapshot store",
+			Unavailable: true,
+			Retryable:   true,
+			Err:         fmt.Errorf("replicated rates are currently unavailable: %w", err),
+		}
+	}
+
+	rates := make(map[string]float64, len(entries))
+	var oldest RatesSnapshotEntry
+	for currency, entry := range entries {
+		rates[currency] = entry.Rate
+		if oldest.PublishedAt.IsZero() || entry.PublishedAt.Before(oldest.PublishedAt) {
+			oldest = entry
+		}
+	}
+
+	if len(entries) == 0 {
+		return rates, repositories.RatesInfo{Source: "📡 Read replica: no replicated rates available yet"}, nil
+	}
+
+	lag := r.now().Sub(oldest.PublishedAt).Round(time.Second)
+	return rates, repositories.RatesInfo{
+		Source:      fmt.Sprintf("📡 Read replica: rates published by %s %s ago", oldest.Region, lag),
+		PublishedAt: oldest.PublishedAt,
+	}, nil
+}
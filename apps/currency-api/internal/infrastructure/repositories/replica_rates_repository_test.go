@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSnapshotStore(t *testing.T) (*RatesSnapshotStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRatesSnapshotStore(client), server
+}
+
+func TestRatesSnapshotStore_PublishAndLoad(t *testing.T) {
+	store, _ := newTestSnapshotStore(t)
+	publishedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Publish(context.Background(), "us-east-1", map[string]float64{"USD": 1, "EUR": 0.92}, publishedAt))
+
+	entries, err := store.Load(context.Background(), []string{"USD", "EUR", "GBP"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RatesSnapshotEntry{
+		"USD": {Rate: 1, PublishedAt: publishedAt, Region: "us-east-1"},
+		"EUR": {Rate: 0.92, PublishedAt: publishedAt, Region: "us-east-1"},
+	}, entries)
+}
+
+func TestSnapshotPublishingRatesRepository(t *testing.T) {
+	store, _ := newTestSnapshotStore(t)
+	repo := NewSnapshotPublishingRatesRepository(staticRatesRepository{rates: map[string]float64{"USD": 1, "PLN": 3.9}}, store, "us-east-1", logger.New("error"))
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "PLN"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "PLN": 3.9}, rates)
+	assert.Equal(t, "static rates", info.Source)
+
+	entries, err := store.Load(context.Background(), []string{"USD", "PLN"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "us-east-1", entries["PLN"].Region)
+}
+
+func TestSnapshotPublishingRatesRepository_PublishFailureDoesNotFailRequest(t *testing.T) {
+	store, server := newTestSnapshotStore(t)
+	server.Close()
+	repo := NewSnapshotPublishingRatesRepository(staticRatesRepository{rates: map[string]float64{"USD": 1}}, store, "us-east-1", logger.New("error"))
+
+	rates, _, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1}, rates)
+}
+
+func TestSnapshotPublishingRatesRepository_InnerError(t *testing.T) {
+	store, _ := newTestSnapshotStore(t)
+	repo := NewSnapshotPublishingRatesRepository(staticRatesRepository{err: errors.New("upstream down")}, store, "us-east-1", logger.New("error"))
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	require.EqualError(t, err, "upstream down")
+	entries, err := store.Load(context.Background(), []string{"USD"})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReplicaRatesRepository(t *testing.T) {
+	store, _ := newTestSnapshotStore(t)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Publish(context.Background(), "us-east-1", map[string]float64{"USD": 1}, now.Add(-5*time.Second)))
+	require.NoError(t, store.Publish(context.Background(), "us-east-1", map[string]float64{"EUR": 0.92}, now.Add(-30*time.Second)))
+
+	repo := NewReplicaRatesRepository(store, logger.New("error"))
+	repo.(*ReplicaRatesRepository).now = func() time.Time { return now }
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD", "EUR", "GBP"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 0.92}, rates)
+	assert.Equal(t, "📡 Read replica: rates published by us-east-1 30s ago", info.Source)
+	lag, ok := info.ReplicationLag(now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, lag)
+
+	_, info, err = repo.GetRates(context.Background(), []string{"USD"})
+
+	require.NoError(t, err)
+	lag, ok = info.ReplicationLag(now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, lag, "the lag covers only the rates of this call")
+}
+
+func TestReplicaRatesRepository_NothingPublished(t *testing.T) {
+	store, _ := newTestSnapshotStore(t)
+	repo := NewReplicaRatesRepository(store, logger.New("error"))
+
+	rates, info, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+	assert.Equal(t, "📡 Read replica: no replicated rates available yet", info.Source)
+	_, ok := info.ReplicationLag(time.Now())
+	assert.False(t, ok)
+}
+
+func TestReplicaRatesRepository_StoreUnavailable(t *testing.T) {
+	store, server := newTestSnapshotStore(t)
+	server.Close()
+	repo := NewReplicaRatesRepository(store, logger.New("error"))
+
+	_, _, err := repo.GetRates(context.Background(), []string{"USD"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replicated rates are currently unavailable")
+}
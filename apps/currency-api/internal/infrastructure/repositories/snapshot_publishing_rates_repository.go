@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/go-common/logger"
+)
+
+// SnapshotPublishingRatesRepository publishes every successful fetch of the
+// primary region to the snapshot store read by replicas. Publishing failures
+// are logged and never fail the request.
+type SnapshotPublishingRatesRepository struct {
+	inner  repositories.RatesRepository
+	store  *RatesSnapshotStore
+	region string
+	logger logger.Logger
+}
+
+func NewSnapshotPublishingRatesRepository(inner repositories.RatesRepository, store *RatesSnapshotStore, region string, log logger.Logger) repositories.RatesRepository {
+	return &SnapshotPublishingRatesRepository{
+		inner:  inner,
+		store:  store,
+		region: region,
+		logger: log,
+	}
+}
+
+func (r *SnapshotPublishingRatesRepository) GetRates(ctx context.Context, currencies []string) (map[string]float64, repositories.RatesInfo, error) {
+	rates, info, err := r.inner.GetRates(ctx, currencies)
+	if err != nil {
+		return nil, repositories.RatesInfo{}, err
+	}
+
+	if err := r.store.Publish(ctx, r.region, rates, time.Now()); err != nil {
+		r.logger.Error("Failed to publish rates snapshot", err, "region", r.region)
+	}
+
+	return rates, info, nil
+}
@@ -0,0 +1,79 @@
+package signedurl
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(now time.Time) *Signer {
+	signer := NewSigner([]byte("0123456789abcdef0123456789abcdef"), 15*time.Minute, "https://downloads.example.com/")
+	signer.now = func() time.Time { return now }
+	return signer
+}
+
+func parseSignedURL(t *testing.T, raw string) (string, string, string) {
+	t.Helper()
+	parsed, err := url.Parse(raw)
+	require.NoError(t, err)
+	return parsed.Path, parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner(now)
+
+	signed := signer.Sign("exports/job-42/results.csv")
+
+	assert.True(t, strings.HasPrefix(signed.URL, "https://downloads.example.com/exports/job-42/results.csv?"))
+	assert.Equal(t, now.Add(15*time.Minute), signed.ExpiresAt)
+
+	path, expires, signature := parseSignedURL(t, signed.URL)
+	assert.Equal(t, "/exports/job-42/results.csv", path)
+	assert.NoError(t, signer.Verify(path, expires, signature))
+}
+
+func TestSigner_Verify_Rejects(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner(now)
+	path, expires, signature := parseSignedURL(t, signer.Sign("/exports/job-42/results.csv").URL)
+
+	tests := []struct {
+		name        string
+		path        string
+		expires     string
+		signature   string
+		at          time.Time
+		expectedErr error
+	}{
+		{name: "other object", path: "/exports/job-43/results.csv", expires: expires, signature: signature, at: now, expectedErr: ErrInvalidSignature},
+		{name: "extended expiry", path: path, expires: "4102444800", signature: signature, at: now, expectedErr: ErrInvalidSignature},
+		{name: "malformed expiry", path: path, expires: "tomorrow", signature: signature, at: now, expectedErr: ErrInvalidSignature},
+		{name: "tampered signature", path: path, expires: expires, signature: strings.Repeat("0", len(signature)), at: now, expectedErr: ErrInvalidSignature},
+		{name: "expired", path: path, expires: expires, signature: signature, at: now.Add(15 * time.Minute), expectedErr: ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer.now = func() time.Time { return tt.at }
+
+			err := signer.Verify(tt.path, tt.expires, tt.signature)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestSigner_DifferentSecretsDoNotVerify(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	path, expires, signature := parseSignedURL(t, newTestSigner(now).Sign("/exports/a.csv").URL)
+
+	other := NewSigner([]byte("another-secret-another-secret-00"), 15*time.Minute, "")
+	other.now = func() time.Time { return now }
+
+	assert.ErrorIs(t, other.Verify(path, expires, signature), ErrInvalidSignature)
+}
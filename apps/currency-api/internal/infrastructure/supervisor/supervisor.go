@@ -0,0 +1,226 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+)
+
+const (
+	StateRunning    = "running"
+	StateRestarting = "restarting"
+	StateFailed     = "failed"
+	StateStopped    = "stopped"
+)
+
+var errExited = errors.New("worker exited before shutdown")
+
+// Worker is a background loop that runs until ctx is cancelled. Returning
+// earlier, with or without an error, or panicking counts as a crash.
+type Worker func(ctx context.Context) error
+
+// Policy decides how crashed workers are restarted. The delay before a
+// restart starts at InitialBackoff and doubles with every crash up to
+// MaxBackoff; a worker that ran longer than MaxBackoff before crashing starts
+// over at InitialBackoff. A worker that crashes more than MaxRestarts times
+// within Window is given up on; zero MaxRestarts restarts forever and zero
+// Window counts every crash since start.
+type Policy struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxRestarts    int
+	Window         time.Duration
+}
+
+// WorkerStatus describes one supervised worker. LastError and LastFailure are
+// empty until the worker crashed once.
+type WorkerStatus struct {
+	Name        string
+	State       string
+	Restarts    int
+	LastError   string
+	LastFailure time.Time
+}
+
+// Supervisor runs background workers and restarts them when they crash, so a
+// failed loop does not silently disable its subsystem until the next deploy.
+type Supervisor struct {
+	policy Policy
+	logger logger.Logger
+	now    func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	names   []string
+	workers map[string]*WorkerStatus
+
+	restarts *prometheus.CounterVec
+	up       *prometheus.GaugeVec
+}
+
+func New(policy Policy, registerer prometheus.Registerer, log logger.Logger) *Supervisor {
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaultInitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaultMaxBackoff
+	}
+	policy.MaxBackoff = max(policy.MaxBackoff, policy.InitialBackoff)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Supervisor{
+		policy:  policy,
+		logger:  log,
+		now:     time.Now,
+		ctx:     ctx,
+		cancel:  cancel,
+		workers: make(map[string]*WorkerStatus),
+		restarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "currency_api_worker_restarts_total",
+			Help: "Restarts of supervised background workers after a crash.",
+		}, []string{"worker"}),
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "currency_api_worker_up",
+			Help: "Whether a supervised background worker is running (1) or crashed, failed or stopped (0).",
+		}, []string{"worker"}),
+	}
+
+	registerer.MustRegister(s.restarts, s.up)
+	return s
+}
+
+// Go starts worker under supervision. Names must be unique.
+func (s *Supervisor) Go(name string, worker Worker) {
+	s.mu.Lock()
+	s.names = append(s.names, name)
+	s.workers[name] = &WorkerStatus{Name: name, State: StateRunning}
+	s.mu.Unlock()
+
+	s.restarts.WithLabelValues(name).Add(0)
+	s.wg.Add(1)
+	go s.supervise(name, worker)
+}
+
+// Status returns every worker in the order it was started.
+func (s *Supervisor) Status() []WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]WorkerStatus, 0, len(s.names))
+	for _, name := range s.names {
+		result = append(result, *s.workers[name])
+	}
+	return result
+}
+
+// Close cancels every worker and waits for them to return.
+func (s *Supervisor) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Supervisor) supervise(name string, worker Worker) {
+	defer s.wg.Done()
+
+	backoff := s.policy.InitialBackoff
+	var crashes []time.Time
+	for {
+		s.up.WithLabelValues(name).Set(1)
+		started := s.now()
+		err := s.run(name, worker)
+		s.up.WithLabelValues(name).Set(0)
+
+		if s.ctx.Err() != nil {
+			s.update(name, func(status *WorkerStatus) { status.State = StateStopped })
+			return
+		}
+		if err == nil {
+			err = errExited
+		}
+
+		crashed := s.now()
+		if crashed.Sub(started) > s.policy.MaxBackoff {
+			backoff = s.policy.InitialBackoff
+		}
+		crashes = s.recentCrashes(append(crashes, crashed), crashed)
+
+		if s.policy.MaxRestarts > 0 && len(crashes) > s.policy.MaxRestarts {
+			s.logger.Error("Worker keeps crashing, giving up", err, "worker", name, "crashes", len(crashes))
+			s.update(name, func(status *WorkerStatus) {
+				status.State = StateFailed
+				status.LastError = err.Error()
+				status.LastFailure = crashed
+			})
+			return
+		}
+
+		s.logger.Error("Worker crashed, restarting", err, "worker", name, "backoff", backoff.String())
+		s.update(name, func(status *WorkerStatus) {
+			status.State = StateRestarting
+			status.LastError = err.Error()
+			status.LastFailure = crashed
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			s.update(name, func(status *WorkerStatus) { status.State = StateStopped })
+			return
+		case <-timer.C:
+		}
+
+		s.restarts.WithLabelValues(name).Inc()
+		s.update(name, func(status *WorkerStatus) {
+			status.State = StateRunning
+			status.Restarts++
+		})
+		backoff = min(backoff*2, s.policy.MaxBackoff)
+	}
+}
+
+// run calls worker, turning a panic into an error so it is restarted like
+// any other crash.
+func (s *Supervisor) run(name string, worker Worker) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.logger.Error("Worker panicked", fmt.Errorf("%v", recovered), "worker", name, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return worker(s.ctx)
+}
+
+// recentCrashes drops the crashes that fell out of the policy window.
+func (s *Supervisor) recentCrashes(crashes []time.Time, now time.Time) []time.Time {
+	if s.policy.Window <= 0 {
+		return crashes
+	}
+
+	cutoff := now.Add(-s.policy.Window)
+	for len(crashes) > 0 && crashes[0].Before(cutoff) {
+		crashes = crashes[1:]
+	}
+	return crashes
+}
+
+func (s *Supervisor) update(name string, change func(*WorkerStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(s.workers[name])
+}
@@ -0,0 +1,100 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ajs/go-common/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSupervisor(policy Policy) *Supervisor {
+	return New(policy, prometheus.NewRegistry(), logger.New("error"))
+}
+
+func TestSupervisor_RestartsCrashedWorker(t *testing.T) {
+	supervisor := newTestSupervisor(Policy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	var runs atomic.Int32
+
+	supervisor.Go("refresher", func(ctx context.Context) error {
+		switch runs.Add(1) {
+		case 1:
+			return errors.New("connection reset")
+		case 2:
+			panic("nil map")
+		}
+		<-ctx.Done()
+		return nil
+	})
+
+	require.Eventually(t, func() bool {
+		return supervisor.Status()[0].Restarts == 2 && supervisor.Status()[0].State == StateRunning
+	}, time.Second, time.Millisecond)
+	status := supervisor.Status()[0]
+	assert.Equal(t, "panic: nil map", status.LastError)
+	assert.False(t, status.LastFailure.IsZero())
+	assert.Equal(t, 2.0, testutil.ToFloat64(supervisor.restarts.WithLabelValues("refresher")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(supervisor.up.WithLabelValues("refresher")))
+
+	require.NoError(t, supervisor.Close())
+	assert.Equal(t, StateStopped, supervisor.Status()[0].State)
+	assert.Equal(t, 0.0, testutil.ToFloat64(supervisor.up.WithLabelValues("refresher")))
+}
+
+func TestSupervisor_GivesUpAfterMaxRestarts(t *testing.T) {
+	supervisor := newTestSupervisor(Policy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, MaxRestarts: 2, Window: time.Hour})
+	var runs atomic.Int32
+
+	supervisor.Go("poller", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	require.Eventually(t, func() bool {
+		return supervisor.Status()[0].State == StateFailed
+	}, time.Second, time.Millisecond)
+	require.NoError(t, supervisor.Close())
+
+	status := supervisor.Status()[0]
+	assert.Equal(t, StateFailed, status.State, "a failed worker stays failed on shutdown")
+	assert.Equal(t, 2, status.Restarts)
+	assert.Equal(t, int32(3), runs.Load())
+	assert.Equal(t, errExited.Error(), status.LastError)
+}
+
+func TestSupervisor_BacksOffExponentially(t *testing.T) {
+	supervisor := newTestSupervisor(Policy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond, MaxRestarts: 4})
+	var starts []time.Time
+
+	supervisor.Go("consumer", func(ctx context.Context) error {
+		starts = append(starts, time.Now())
+		return errors.New("broker unavailable")
+	})
+
+	require.Eventually(t, func() bool {
+		return supervisor.Status()[0].State == StateFailed
+	}, time.Second, time.Millisecond)
+	require.NoError(t, supervisor.Close())
+
+	require.Len(t, starts, 5)
+	for i, minimum := range []time.Duration{10, 20, 40, 40} {
+		assert.GreaterOrEqual(t, starts[i+1].Sub(starts[i]), minimum*time.Millisecond, "restart %d", i+1)
+	}
+}
+
+func TestSupervisor_ForgetsCrashesOutsideWindow(t *testing.T) {
+	supervisor := newTestSupervisor(Policy{})
+	supervisor.policy.Window = time.Minute
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	crashes := supervisor.recentCrashes([]time.Time{now.Add(-2 * time.Minute), now.Add(-30 * time.Second), now}, now)
+
+	assert.Equal(t, []time.Time{now.Add(-30 * time.Second), now}, crashes)
+	require.NoError(t, supervisor.Close())
+}
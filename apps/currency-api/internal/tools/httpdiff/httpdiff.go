@@ -0,0 +1,144 @@
+package httpdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+type Difference struct {
+	Path      string `json:"path"`
+	Baseline  any    `json:"baseline"`
+	Candidate any    `json:"candidate"`
+}
+
+type Options struct {
+	// IgnoreFields are object keys skipped at any depth (e.g. "timestamp").
+	IgnoreFields []string
+	// FloatTolerance is the absolute difference under which numbers are equal.
+	FloatTolerance float64
+}
+
+// Compare reports status and body differences. JSON bodies are compared
+// structurally; anything else is compared byte for byte.
+func Compare(baseline, candidate Response, opts Options) []Difference {
+	var diffs []Difference
+
+	if baseline.StatusCode != candidate.StatusCode {
+		diffs = append(diffs, Difference{Path: "status", Baseline: baseline.StatusCode, Candidate: candidate.StatusCode})
+	}
+
+	baselineJSON, baselineErr := decode(baseline.Body)
+	candidateJSON, candidateErr := decode(candidate.Body)
+	if baselineErr != nil || candidateErr != nil {
+		if !bytes.Equal(baseline.Body, candidate.Body) {
+			diffs = append(diffs, Difference{Path: "body", Baseline: string(baseline.Body), Candidate: string(candidate.Body)})
+		}
+		return diffs
+	}
+
+	ignored := make(map[string]bool, len(opts.IgnoreFields))
+	for _, field := range opts.IgnoreFields {
+		ignored[field] = true
+	}
+
+	return compareValues("$", baselineJSON, candidateJSON, ignored, opts.FloatTolerance, diffs)
+}
+
+func decode(body []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func compareValues(path string, baseline, candidate any, ignored map[string]bool, tolerance float64, diffs []Difference) []Difference {
+	switch b := baseline.(type) {
+	case map[string]any:
+		c, ok := candidate.(map[string]any)
+		if !ok {
+			return append(diffs, Difference{Path: path, Baseline: baseline, Candidate: candidate})
+		}
+		for _, key := range unionKeys(b, c) {
+			if ignored[key] {
+				continue
+			}
+			childPath := path + "." + key
+			bValue, bExists := b[key]
+			cValue, cExists := c[key]
+			if !bExists || !cExists {
+				diffs = append(diffs, Difference{Path: childPath, Baseline: bValue, Candidate: cValue})
+				continue
+			}
+			diffs = compareValues(childPath, bValue, cValue, ignored, tolerance, diffs)
+		}
+		return diffs
+
+	case []any:
+		c, ok := candidate.([]any)
+		if !ok {
+			return append(diffs, Difference{Path: path, Baseline: baseline, Candidate: candidate})
+		}
+		if len(b) != len(c) {
+			return append(diffs, Difference{Path: path + ".length", Baseline: len(b), Candidate: len(c)})
+		}
+		for i := range b {
+			diffs = compareValues(fmt.Sprintf("%s[%d]", path, i), b[i], c[i], ignored, tolerance, diffs)
+		}
+		return diffs
+
+	case json.Number:
+		c, ok := candidate.(json.Number)
+		if !ok || !numbersEqual(b, c, tolerance) {
+			return append(diffs, Difference{Path: path, Baseline: baseline, Candidate: candidate})
+		}
+		return diffs
+
+	default:
+		if !reflect.DeepEqual(baseline, candidate) {
+			return append(diffs, Difference{Path: path, Baseline: baseline, Candidate: candidate})
+		}
+		return diffs
+	}
+}
+
+func numbersEqual(a, b json.Number, tolerance float64) bool {
+	if a == b {
+		return true
+	}
+
+	aFloat, aErr := strconv.ParseFloat(string(a), 64)
+	bFloat, bErr := strconv.ParseFloat(string(b), 64)
+	if aErr != nil || bErr != nil {
+		return false
+	}
+
+	return math.Abs(aFloat-bFloat) <= tolerance
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package httpdiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseline  Response
+		candidate Response
+		opts      Options
+		expected  []Difference
+	}{
+		{
+			name:      "identical JSON with different key order",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"from":"WBTC","to":"USDT","amount":1.5}`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`{"amount":1.5,"to":"USDT","from":"WBTC"}`)},
+		},
+		{
+			name:      "status and field differences",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"from":"WBTC","amount":1.5}`)},
+			candidate: Response{StatusCode: 400, Body: []byte(`{"from":"WBTC","error":"boom"}`)},
+			expected: []Difference{
+				{Path: "status", Baseline: 200, Candidate: 400},
+				{Path: "$.amount", Baseline: jsonNumber("1.5"), Candidate: nil},
+				{Path: "$.error", Baseline: nil, Candidate: "boom"},
+			},
+		},
+		{
+			name:      "ignored fields at any depth",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"timestamp":1,"meta":{"timestamp":2,"ok":true}}`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`{"timestamp":3,"meta":{"timestamp":4,"ok":true}}`)},
+			opts:      Options{IgnoreFields: []string{"timestamp"}},
+		},
+		{
+			name:      "numbers within tolerance",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"rates":[{"rate":0.85}]}`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`{"rates":[{"rate":"0.85"}]}`)},
+			expected: []Difference{
+				{Path: "$.rates[0].rate", Baseline: jsonNumber("0.85"), Candidate: "0.85"},
+			},
+		},
+		{
+			name:      "numeric formatting differences are tolerated",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"amount":100}`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`{"amount":100.0000001}`)},
+			opts:      Options{FloatTolerance: 1e-6},
+		},
+		{
+			name:      "array length mismatch",
+			baseline:  Response{StatusCode: 200, Body: []byte(`{"rates":[1,2]}`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`{"rates":[1]}`)},
+			expected: []Difference{
+				{Path: "$.rates.length", Baseline: 2, Candidate: 1},
+			},
+		},
+		{
+			name:      "non-JSON bodies",
+			baseline:  Response{StatusCode: 200, Body: []byte(`<html>a</html>`)},
+			candidate: Response{StatusCode: 200, Body: []byte(`<html>b</html>`)},
+			expected: []Difference{
+				{Path: "body", Baseline: "<html>a</html>", Candidate: "<html>b</html>"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Compare(tt.baseline, tt.candidate, tt.opts))
+		})
+	}
+}
+
+func jsonNumber(value string) json.Number {
+	return json.Number(value)
+}
@@ -0,0 +1,191 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/tools/httpdiff"
+)
+
+// Request is a recorded request as written by the access log middleware.
+type Request struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Query  string    `json:"query"`
+}
+
+func (r Request) URI() string {
+	if r.Query == "" {
+		return r.Path
+	}
+	return r.Path + "?" + r.Query
+}
+
+type Config struct {
+	Target   string
+	Baseline string
+	// Speed scales the recorded pacing: 1 replays in real time, 2 twice as
+	// fast, 0 sends requests back to back.
+	Speed  float64
+	Diff   httpdiff.Options
+	Client *http.Client
+}
+
+type Result struct {
+	Request     Request               `json:"request"`
+	StatusCode  int                   `json:"status_code"`
+	Latency     time.Duration         `json:"latency"`
+	Differences []httpdiff.Difference `json:"differences,omitempty"`
+	Error       string                `json:"error,omitempty"`
+}
+
+type Summary struct {
+	Total      int `json:"total"`
+	Failed     int `json:"failed"`
+	Mismatched int `json:"mismatched"`
+}
+
+type Replayer struct {
+	config Config
+}
+
+func NewReplayer(cfg Config) *Replayer {
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	cfg.Target = strings.TrimRight(cfg.Target, "/")
+	cfg.Baseline = strings.TrimRight(cfg.Baseline, "/")
+	return &Replayer{config: cfg}
+}
+
+// ReadRequests parses JSON log lines, keeping only replayable access log
+// entries. Request bodies are not recorded, so only GET and HEAD are kept.
+// Any other line fails the read, so console logs are not mistaken for an
+// empty recording.
+func ReadRequests(input io.Reader) ([]Request, error) {
+	var requests []Request
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var request Request
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			return nil, fmt.Errorf("line %d is not a JSON log line, record with LOG_FORMAT=json: %w", line, err)
+		}
+		if request.Path == "" || (request.Method != http.MethodGet && request.Method != http.MethodHead) {
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recorded requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (r *Replayer) Run(ctx context.Context, requests []Request, onResult func(Result)) (Summary, error) {
+	var summary Summary
+
+	for i, request := range requests {
+		if i > 0 {
+			if err := r.wait(ctx, requests[i-1].Time, request.Time); err != nil {
+				return summary, err
+			}
+		}
+
+		result := r.replay(ctx, request)
+
+		summary.Total++
+		if result.Error != "" {
+			summary.Failed++
+		}
+		if len(result.Differences) > 0 {
+			summary.Mismatched++
+		}
+
+		onResult(result)
+	}
+
+	return summary, nil
+}
+
+func (r *Replayer) wait(ctx context.Context, previous, current time.Time) error {
+	if r.config.Speed <= 0 || previous.IsZero() || current.IsZero() {
+		return ctx.Err()
+	}
+
+	delay := time.Duration(float64(current.Sub(previous)) / r.config.Speed)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *Replayer) replay(ctx context.Context, request Request) Result {
+	result := Result{Request: request}
+
+	start := time.Now()
+	target, err := r.send(ctx, r.config.Target, request)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = fmt.Sprintf("target: %v", err)
+		return result
+	}
+	result.StatusCode = target.StatusCode
+
+	if r.config.Baseline == "" {
+		return result
+	}
+
+	baseline, err := r.send(ctx, r.config.Baseline, request)
+	if err != nil {
+		result.Error = fmt.Sprintf("baseline: %v", err)
+		return result
+	}
+
+	result.Differences = httpdiff.Compare(baseline, target, r.config.Diff)
+	return result
+}
+
+func (r *Replayer) send(ctx context.Context, baseURL string, request Request) (httpdiff.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, baseURL+request.URI(), nil)
+	if err != nil {
+		return httpdiff.Response{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := r.config.Client.Do(req)
+	if err != nil {
+		return httpdiff.Response{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return httpdiff.Response{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return httpdiff.Response{StatusCode: resp.StatusCode, Body: body}, nil
+}
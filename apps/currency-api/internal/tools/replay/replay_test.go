@@ -0,0 +1,117 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/tools/httpdiff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRequests(t *testing.T) {
+	input := strings.Join([]string{
+		`{"time":"2026-10-16T10:00:00Z","level":"INFO","msg":"HTTP request","method":"GET","path":"/api/v1/rates","query":"currencies=USD,EUR","status":200}`,
+		`{"time":"2026-10-16T10:00:01Z","level":"INFO","msg":"✅ Successfully fetched live rates","currencies":2}`,
+		``,
+		`{"time":"2026-10-16T10:00:02Z","msg":"HTTP request","method":"POST","path":"/api/v1/exchange/bulk"}`,
+		`{"time":"2026-10-16T10:00:03Z","msg":"HTTP request","method":"GET","path":"/health","query":""}`,
+	}, "\n")
+
+	requests, err := ReadRequests(strings.NewReader(input))
+
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/api/v1/rates?currencies=USD,EUR", requests[0].URI())
+	assert.Equal(t, "/health", requests[1].URI())
+}
+
+func TestReadRequests_RejectsConsoleLogs(t *testing.T) {
+	input := strings.Join([]string{
+		`{"time":"2026-10-16T10:00:00Z","level":"INFO","msg":"HTTP request","method":"GET","path":"/api/v1/rates","query":"currencies=USD,EUR","status":200}`,
+		`10:00:01 INFO  HTTP request method=GET path=/api/v1/rates query=currencies=USD,EUR status=200`,
+	}, "\n")
+
+	_, err := ReadRequests(strings.NewReader(input))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestReplayer_Run_ComparesAgainstBaseline(t *testing.T) {
+	baseline := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"from":"WBTC","to":"USDT","amount":57094.314314}`))
+	}))
+	defer baseline.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("amount") == "2" {
+			_, _ = w.Write([]byte(`{"from":"WBTC","to":"USDT","amount":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"amount":57094.314314,"from":"WBTC","to":"USDT"}`))
+	}))
+	defer target.Close()
+
+	replayer := NewReplayer(Config{Target: target.URL, Baseline: baseline.URL})
+	requests := []Request{
+		{Method: http.MethodGet, Path: "/api/v1/exchange", Query: "from=WBTC&to=USDT&amount=1"},
+		{Method: http.MethodGet, Path: "/api/v1/exchange", Query: "from=WBTC&to=USDT&amount=2"},
+	}
+
+	var results []Result
+	summary, err := replayer.Run(context.Background(), requests, func(result Result) {
+		results = append(results, result)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Total: 2, Mismatched: 1}, summary)
+	assert.Empty(t, results[0].Differences)
+	assert.Equal(t, []httpdiff.Difference{
+		{Path: "$.amount", Baseline: jsonNumber("57094.314314"), Candidate: jsonNumber("1")},
+	}, results[1].Differences)
+}
+
+func TestReplayer_Run_PacesRequests(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer target.Close()
+
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	requests := []Request{
+		{Time: start, Method: http.MethodGet, Path: "/health"},
+		{Time: start.Add(200 * time.Millisecond), Method: http.MethodGet, Path: "/health"},
+	}
+
+	replayer := NewReplayer(Config{Target: target.URL, Speed: 2})
+
+	began := time.Now()
+	summary, err := replayer.Run(context.Background(), requests, func(Result) {})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Total)
+	assert.GreaterOrEqual(t, time.Since(began), 100*time.Millisecond)
+}
+
+func TestReplayer_Run_TargetUnavailable(t *testing.T) {
+	replayer := NewReplayer(Config{Target: "http://127.0.0.1:1"})
+
+	var result Result
+	summary, err := replayer.Run(context.Background(), []Request{{Method: http.MethodGet, Path: "/health"}}, func(r Result) {
+		result = r
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Contains(t, result.Error, "target:")
+}
+
+func jsonNumber(value string) json.Number {
+	return json.Number(value)
+}
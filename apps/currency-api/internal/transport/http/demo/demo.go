@@ -0,0 +1,19 @@
+package demo
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// FileSystem exposes the embedded demo page (conversion form and live rate table).
+func FileSystem() http.FileSystem {
+	sub, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(sub)
+}
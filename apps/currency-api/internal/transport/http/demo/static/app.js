@@ -0,0 +1,75 @@
+(function () {
+  const cryptos = ["BEER", "FLOKI", "GATE", "USDT", "WBTC"];
+
+  const fromSelect = document.getElementById("from");
+  const toSelect = document.getElementById("to");
+  cryptos.forEach((code) => {
+    fromSelect.add(new Option(code, code));
+    toSelect.add(new Option(code, code));
+  });
+  fromSelect.value = "WBTC";
+  toSelect.value = "USDT";
+
+  async function getJSON(url) {
+    const response = await fetch(url, { headers: { accept: "application/json" } });
+    const body = await response.json().catch(() => ({}));
+    return { ok: response.ok, status: response.status, body };
+  }
+
+  const exchangeResult = document.getElementById("exchange-result");
+  document.getElementById("exchange-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const params = new URLSearchParams({
+      from: fromSelect.value,
+      to: toSelect.value,
+      amount: document.getElementById("amount").value.trim(),
+    });
+    const { ok, status, body } = await getJSON("/api/v1/exchange?" + params);
+    exchangeResult.classList.toggle("error", !ok);
+    exchangeResult.textContent = ok
+      ? `${params.get("amount")} ${body.from} = ${body.amount} ${body.to}`
+      : `HTTP ${status}\n${JSON.stringify(body, null, 2)}`;
+  });
+
+  const ratesBody = document.getElementById("rates-body");
+  const sourceInfo = document.getElementById("source-info");
+  const ratesUpdated = document.getElementById("rates-updated");
+  const currenciesInput = document.getElementById("currencies");
+  const intervalSelect = document.getElementById("interval");
+  let timer = null;
+
+  async function refreshRates() {
+    const currencies = currenciesInput.value.replace(/\s+/g, "");
+    const { ok, status, body } = await getJSON("/api/v1/rates?currencies=" + encodeURIComponent(currencies));
+    ratesBody.replaceChildren();
+    if (!ok) {
+      sourceInfo.classList.add("error");
+      sourceInfo.textContent = `HTTP ${status}: ${body.error || "request failed"}`;
+      return;
+    }
+    sourceInfo.classList.remove("error");
+    sourceInfo.textContent = body.source_info || "";
+    (body.rates || []).forEach((rate) => {
+      const row = ratesBody.insertRow();
+      row.insertCell().textContent = rate.from;
+      row.insertCell().textContent = rate.to;
+      row.insertCell().textContent = rate.rate;
+    });
+    ratesUpdated.textContent = "Updated " + new Date().toLocaleTimeString();
+  }
+
+  function schedule() {
+    clearInterval(timer);
+    refreshRates();
+    timer = setInterval(refreshRates, Number(intervalSelect.value));
+  }
+
+  document.getElementById("rates-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    schedule();
+  });
+  currenciesInput.addEventListener("change", schedule);
+  intervalSelect.addEventListener("change", schedule);
+
+  schedule();
+})();
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactQuery(t *testing.T) {
+	tests := map[string]struct {
+		raw      string
+		expected string
+	}{
+		"no secrets":    {raw: "currencies=USD,EUR", expected: "currencies=USD,EUR"},
+		"stream key":    {raw: "currencies=USD&api_key=k-3f9a", expected: "api_key=REDACTED&currencies=USD"},
+		"download link": {raw: "expires=1760608800&signature=9c1e", expected: "expires=1760608800&signature=REDACTED"},
+		"empty":         {raw: "", expected: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactQuery(tt.raw))
+		})
+	}
+}
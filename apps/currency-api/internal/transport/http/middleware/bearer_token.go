@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// BearerToken only lets requests through that present one of credentials in
+// an "Authorization: Bearer" header, and records its holder as the request's
+// principal. It protects the operator endpoints.
+func BearerToken(credentials auth.Credentials) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if holder, known := credentials.Identify(presented); ok && known {
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), holder))
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", "Bearer")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "a valid bearer token is required"})
+	}
+}
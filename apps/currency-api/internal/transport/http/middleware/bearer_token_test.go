@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(BearerToken(auth.Credentials{"t-alice": "alice", "t-bob": "bob"}))
+	r.GET("/admin", func(c *gin.Context) {
+		principal, _ := auth.Principal(c.Request.Context())
+		c.String(http.StatusOK, principal)
+	})
+
+	tests := map[string]struct {
+		header   string
+		status   int
+		expected string
+	}{
+		"known token":   {header: "Bearer t-bob", status: http.StatusOK, expected: "bob"},
+		"unknown token": {header: "Bearer t-carol", status: http.StatusUnauthorized},
+		"no scheme":     {header: "t-alice", status: http.StatusUnauthorized},
+		"no header":     {status: http.StatusUnauthorized},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.expected, recorder.Body.String())
+			} else {
+				assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
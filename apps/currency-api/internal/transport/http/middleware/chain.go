@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Dependencies are what the configurable middleware need from the server.
+type Dependencies struct {
+	Logger      logger.Logger
+	Environment string
+}
+
+type factory func(options *optionReader, deps Dependencies) gin.HandlerFunc
+
+var factories = map[string]factory{
+	"recovery": func(_ *optionReader, _ Dependencies) gin.HandlerFunc {
+		return gin.Recovery()
+	},
+	"access_log": func(_ *optionReader, deps Dependencies) gin.HandlerFunc {
+		return AccessLog(deps.Logger)
+	},
+	"compression": func(options *optionReader, _ Dependencies) gin.HandlerFunc {
+		level := options.int("level", gzip.DefaultCompression, gzip.HuffmanOnly, gzip.BestCompression)
+		minBytes := options.int("min_bytes", 1024, 0, 1<<30)
+		return Compression(level, minBytes)
+	},
+	"chaos": func(options *optionReader, _ Dependencies) gin.HandlerFunc {
+		opts := ChaosOptions{
+			ErrorRate:   options.float("error_rate", 0),
+			Status:      options.int("status", http.StatusServiceUnavailable, 500, 599),
+			Latency:     options.duration("latency", 0),
+			LatencyRate: options.float("latency_rate", 1),
+			PathPrefix:  options.string("paths", "/api/"),
+		}
+		if opts.ErrorRate == 0 && opts.Latency == 0 {
+			options.fail("chaos needs error_rate or latency, otherwise it injects nothing")
+		}
+		return Chaos(opts)
+	},
+}
+
+// Chain builds the global middleware stack from MIDDLEWARE, in the configured
+// order. Unknown middleware and options, invalid values and combinations that
+// cannot work together are rejected so a bad stack fails the boot.
+func Chain(specs []config.MiddlewareSpec, deps Dependencies) ([]gin.HandlerFunc, error) {
+	if err := checkCombination(specs, deps.Environment); err != nil {
+		return nil, err
+	}
+
+	chain := make([]gin.HandlerFunc, 0, len(specs))
+	for _, spec := range specs {
+		options := &optionReader{middleware: spec.Name, values: spec.Options, read: make(map[string]bool)}
+		handler := factories[spec.Name](options, deps)
+		if err := options.finish(); err != nil {
+			return nil, err
+		}
+		chain = append(chain, handler)
+	}
+	return chain, nil
+}
+
+func checkCombination(specs []config.MiddlewareSpec, environment string) error {
+	position := make(map[string]int, len(specs))
+	for i, spec := range specs {
+		if _, known := factories[spec.Name]; !known {
+			return fmt.Errorf("unknown middleware %q (available: %s)", spec.Name, strings.Join(available(), ", "))
+		}
+		if _, listed := position[spec.Name]; listed {
+			return fmt.Errorf("%s is listed more than once", spec.Name)
+		}
+		position[spec.Name] = i
+	}
+
+	if i, ok := position["recovery"]; ok && i != 0 {
+		return fmt.Errorf("recovery must come first to catch panics in the middleware after it")
+	}
+
+	if i, ok := position["chaos"]; ok {
+		if environment == "production" {
+			return fmt.Errorf("chaos cannot be enabled in production")
+		}
+		if j, logged := position["access_log"]; logged && i < j {
+			return fmt.Errorf("chaos must come after access_log so injected failures are logged")
+		}
+	}
+	return nil
+}
+
+func available() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// optionReader reads typed middleware options, remembering the first invalid
+// value and which options were used so misspelled ones can be reported.
+type optionReader struct {
+	middleware string
+	values     map[string]string
+	read       map[string]bool
+	err        error
+}
+
+func (r *optionReader) string(key, defaultValue string) string {
+	r.read[key] = true
+	if value, ok := r.values[key]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (r *optionReader) int(key string, defaultValue, minimum, maximum int) int {
+	raw := r.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		r.fail("%s option %s must be a number: %w", r.middleware, key, err)
+		return defaultValue
+	}
+	if value < minimum || value > maximum {
+		r.fail("%s option %s must be between %d and %d", r.middleware, key, minimum, maximum)
+	}
+	return value
+}
+
+func (r *optionReader) float(key string, defaultValue float64) float64 {
+	raw := r.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.fail("%s option %s must be a number: %w", r.middleware, key, err)
+		return defaultValue
+	}
+	if value < 0 || value > 1 {
+		r.fail("%s option %s must be between 0 and 1", r.middleware, key)
+	}
+	return value
+}
+
+func (r *optionReader) duration(key string, defaultValue time.Duration) time.Duration {
+	raw := r.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		r.fail("%s option %s must be a valid duration: %w", r.middleware, key, err)
+		return defaultValue
+	}
+	if value < 0 {
+		r.fail("%s option %s cannot be negative", r.middleware, key)
+	}
+	return value
+}
+
+func (r *optionReader) fail(format string, args ...any) {
+	if r.err == nil {
+		r.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (r *optionReader) finish() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	var unknown []string
+	for key := range r.values {
+		if !r.read[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s has no option %s", r.middleware, strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/go-common/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func specs(entries ...string) []config.MiddlewareSpec {
+	result := make([]config.MiddlewareSpec, len(entries))
+	for i, entry := range entries {
+		fields := strings.Fields(entry)
+		result[i] = config.MiddlewareSpec{Name: fields[0], Options: map[string]string{}}
+		for _, option := range fields[1:] {
+			key, value, _ := strings.Cut(option, "=")
+			result[i].Options[key] = value
+		}
+	}
+	return result
+}
+
+func TestChain_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		specs       []config.MiddlewareSpec
+		environment string
+		expected    string
+	}{
+		"unknown middleware": {
+			specs:    specs("recovery", "cors"),
+			expected: `unknown middleware "cors" (available: access_log, chaos, compression, recovery)`,
+		},
+		"listed twice": {
+			specs:    specs("access_log", "access_log"),
+			expected: "access_log is listed more than once",
+		},
+		"recovery not first": {
+			specs:    specs("access_log", "recovery"),
+			expected: "recovery must come first to catch panics in the middleware after it",
+		},
+		"chaos in production": {
+			specs:       specs("chaos error_rate=0.1"),
+			environment: "production",
+			expected:    "chaos cannot be enabled in production",
+		},
+		"chaos before access log": {
+			specs:    specs("chaos error_rate=0.1", "access_log"),
+			expected: "chaos must come after access_log so injected failures are logged",
+		},
+		"chaos without faults": {
+			specs:    specs("chaos status=500"),
+			expected: "chaos needs error_rate or latency, otherwise it injects nothing",
+		},
+		"rate out of range": {
+			specs:    specs("chaos error_rate=5"),
+			expected: "chaos option error_rate must be between 0 and 1",
+		},
+		"invalid duration": {
+			specs:    specs("chaos latency=soon"),
+			expected: `chaos option latency must be a valid duration: time: invalid duration "soon"`,
+		},
+		"level out of range": {
+			specs:    specs("compression level=12"),
+			expected: "compression option level must be between -2 and 9",
+		},
+		"unknown option": {
+			specs:    specs("compression levl=5"),
+			expected: "compression has no option levl",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Chain(tt.specs, Dependencies{Logger: logger.New("error"), Environment: tt.environment})
+
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func newEngine(t *testing.T, body string, entries ...string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	chain, err := Chain(specs(entries...), Dependencies{Logger: logger.New("error")})
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(chain...)
+	engine.GET("/api/v1/rates", func(c *gin.Context) {
+		c.String(http.StatusOK, body)
+	})
+	engine.GET("/health", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return engine
+}
+
+func TestChain_Compression(t *testing.T) {
+	body := strings.Repeat(`{"currency":"EUR","rate":0.92}`, 100)
+	engine := newEngine(t, body, "recovery", "compression min_bytes=1024")
+
+	t.Run("large body is gzipped", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/rates", nil)
+		request.Header.Set("Accept-Encoding", "br, gzip")
+		engine.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+		reader, err := gzip.NewReader(bytes.NewReader(recorder.Body.Bytes()))
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("small body is sent as is", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/health", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		engine.ServeHTTP(recorder, request)
+
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, "ok", recorder.Body.String())
+	})
+
+	t.Run("client without gzip", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/rates", nil)
+		request.Header.Set("Accept-Encoding", "gzip;q=0, identity")
+		engine.ServeHTTP(recorder, request)
+
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, recorder.Body.String())
+	})
+}
+
+func TestChain_CompressionSkipsEventStreams(t *testing.T) {
+	chain, err := Chain(specs("compression min_bytes=0"), Dependencies{})
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(chain...)
+	engine.GET("/stream", func(c *gin.Context) {
+		c.SSEvent("rates", "EUR")
+		c.Writer.Flush()
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	engine.ServeHTTP(recorder, request)
+
+	assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+	assert.Contains(t, recorder.Body.String(), "event:rates")
+	assert.True(t, recorder.Flushed)
+}
+
+func TestChain_Chaos(t *testing.T) {
+	engine := newEngine(t, "rates", "recovery", "access_log", "chaos error_rate=1 status=502")
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/rates", nil))
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.Equal(t, "error", recorder.Header().Get("X-Chaos-Injected"))
+
+	recorder = httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code, "paths outside /api/ are not affected")
+}
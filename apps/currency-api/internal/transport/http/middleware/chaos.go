@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosOptions configure fault injection. Rates are probabilities between 0
+// and 1 drawn independently per request.
+type ChaosOptions struct {
+	// ErrorRate is the share of requests failed with Status.
+	ErrorRate float64
+	Status    int
+	// Latency delays the share of requests given by LatencyRate.
+	Latency     time.Duration
+	LatencyRate float64
+	// PathPrefix limits injection to matching paths, so health checks and
+	// metrics keep working while the API misbehaves.
+	PathPrefix string
+}
+
+// Chaos injects latency and failures into requests to exercise client
+// retries and timeouts in test environments. Affected responses carry an
+// X-Chaos-Injected header.
+func Chaos(opts ChaosOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, opts.PathPrefix) {
+			c.Next()
+			return
+		}
+
+		if opts.Latency > 0 && rand.Float64() < opts.LatencyRate {
+			c.Header("X-Chaos-Injected", "latency")
+			timer := time.NewTimer(opts.Latency)
+			select {
+			case <-timer.C:
+			case <-c.Request.Context().Done():
+				timer.Stop()
+				c.Abort()
+				return
+			}
+		}
+
+		if rand.Float64() < opts.ErrorRate {
+			c.Header("X-Chaos-Injected", "error")
+			c.AbortWithStatusJSON(opts.Status, gin.H{"error": http.StatusText(opts.Status) + " (injected by chaos testing)"})
+			return
+		}
+
+		c.Next()
+	}
+}
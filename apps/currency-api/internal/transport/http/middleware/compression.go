@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Compression gzips responses for clients that accept it. Bodies smaller
+// than minBytes are not worth the CPU and are sent as is, as are event
+// streams, which must reach the client event by event.
+func Compression(level, minBytes int) gin.HandlerFunc {
+	pool := sync.Pool{New: func() any {
+		// The level is validated when the chain is built.
+		gz, _ := gzip.NewWriterLevel(nil, level)
+		return gz
+	}}
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, pool: &pool, minBytes: minBytes}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+func acceptsGzip(header string) bool {
+	for _, encoding := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(encoding, ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			quality, _ = strconv.ParseFloat(value, 64)
+		}
+		return quality > 0
+	}
+	return false
+}
+
+// compressWriter buffers the start of a body until it knows whether the
+// response is worth compressing, then either gzips or passes it through.
+type compressWriter struct {
+	gin.ResponseWriter
+	pool     *sync.Pool
+	minBytes int
+
+	buffer  []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.write(data)
+	}
+
+	if !w.compressible() {
+		if err := w.passThrough(); err != nil {
+			return 0, err
+		}
+		return w.write(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+	if len(w.buffer) >= w.minBytes {
+		if err := w.compress(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers immediately, so the body can no longer
+// be compressed.
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.passThrough()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush is a streaming response asking for what it wrote so far to reach
+// the client, so buffering stops here.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.passThrough()
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to lift
+// the write deadline of a stream.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) compressible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || strings.HasPrefix(header.Get("Content-Type"), "text/event-stream") {
+		return false
+	}
+	status := w.Status()
+	return status != http.StatusNoContent && status != http.StatusNotModified && status >= http.StatusOK
+}
+
+func (w *compressWriter) compress() error {
+	w.decided = true
+
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	w.gz = w.pool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+	return w.flushBuffer()
+}
+
+func (w *compressWriter) passThrough() error {
+	w.decided = true
+	return w.flushBuffer()
+}
+
+func (w *compressWriter) flushBuffer() error {
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+	_, err := w.write(buffer)
+	return err
+}
+
+func (w *compressWriter) write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// finish sends a body that stayed below minBytes and completes the gzip
+// stream.
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.passThrough()
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
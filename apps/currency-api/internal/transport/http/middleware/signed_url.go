@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/infrastructure/signedurl"
+	"github.com/gin-gonic/gin"
+)
+
+// URLVerifier checks the expiry and signature of a download link.
+type URLVerifier interface {
+	Verify(objectPath, expires, signature string) error
+}
+
+// SignedURL only lets requests through whose expires and signature query
+// parameters were issued for the requested path. It protects download routes
+// served by the API itself, e.g. when no object storage is configured.
+func SignedURL(verifier URLVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := verifier.Verify(c.Request.URL.Path, c.Query("expires"), c.Query("signature"))
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		status := http.StatusForbidden
+		if errors.Is(err, signedurl.ErrExpired) {
+			status = http.StatusGone
+		}
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// TenantKey resolves the tenant from the "X-API-Key" header against keys, so
+// tenant pricing rules only apply to callers holding that tenant's key.
+// Requests without a key go through as no tenant; an unknown key is refused
+// rather than silently priced with the default rule.
+func TenantKey(keys auth.Credentials) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := c.GetHeader("X-API-Key")
+		if presented == "" {
+			c.Next()
+			return
+		}
+
+		tenant, known := keys.Identify(presented)
+		if !known {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unknown API key", "code": "UNKNOWN_API_KEY"})
+			return
+		}
+		c.Request = c.Request.WithContext(auth.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(TenantKey(auth.Credentials{"k-acme": "acme"}))
+	r.GET("/exchange", func(c *gin.Context) {
+		c.String(http.StatusOK, auth.Tenant(c.Request.Context()))
+	})
+
+	tests := map[string]struct {
+		key      string
+		tenant   string
+		status   int
+		expected string
+	}{
+		"known key":         {key: "k-acme", status: http.StatusOK, expected: "acme"},
+		"no key":            {status: http.StatusOK, expected: ""},
+		"unknown key":       {key: "k-globex", status: http.StatusUnauthorized},
+		"claimed tenant":    {tenant: "acme", status: http.StatusOK, expected: ""},
+		"key beats claimed": {key: "k-acme", tenant: "globex", status: http.StatusOK, expected: "acme"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/exchange", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant-ID", tt.tenant)
+			}
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.expected, recorder.Body.String())
+			}
+		})
+	}
+}
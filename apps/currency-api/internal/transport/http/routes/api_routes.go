@@ -1,30 +1,162 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/ajs/currency-api/internal/app/handlers"
+	"github.com/ajs/currency-api/internal/transport/http/demo"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(
-	r *gin.Engine,
-	healthHandler *handlers.HealthHandler,
-	ratesHandler *handlers.RatesHandler,
-	exchangeHandler *handlers.ExchangeHandler,
-) {
+// Handlers are what the routes dispatch to. DownloadGuard, AdminGuard and
+// TenantGuard are optional. So are RatesStream, BulkExchange and Jobs, which need background
+// workers, and Webhooks, which needs a notification producer; without them
+// their routes answer 501.
+type Handlers struct {
+	Health                *handlers.HealthHandler
+	Rates                 *handlers.RatesHandler
+	RatesStream           *handlers.RatesStreamHandler
+	Exchange              *handlers.ExchangeHandler
+	Changelog             *handlers.ChangelogHandler
+	BulkExchange          *handlers.BulkExchangeHandler
+	Jobs                  *handlers.JobsHandler
+	NotificationTemplates *handlers.NotificationTemplatesHandler
+	Webhooks              *handlers.WebhooksHandler
+	Admin                 *handlers.AdminHandler
+	Fees                  *handlers.FeesHandler
+	DownloadGuard         gin.HandlerFunc
+	AdminGuard            gin.HandlerFunc
+	TenantGuard           gin.HandlerFunc
+	Metrics               http.Handler
+}
+
+// SetupRoutes registers every route on r, for a single listener.
+func SetupRoutes(r *gin.Engine, h Handlers) {
+	setupProbeRoutes(r, h)
+	setupQueryRoutes(r, h)
+	setupCommandRoutes(r, h)
+}
+
+// SetupReadRoutes registers the query endpoints, docs, metrics and health
+// probes, for the read listener when commands have a listener of their own.
+func SetupReadRoutes(r *gin.Engine, h Handlers) {
+	setupProbeRoutes(r, h)
+	setupQueryRoutes(r, h)
+}
+
+// SetupWriteRoutes registers the command endpoints and health probes, for
+// the write listener.
+func SetupWriteRoutes(r *gin.Engine, h Handlers) {
+	setupProbeRoutes(r, h)
+	setupCommandRoutes(r, h)
+}
+
+func setupProbeRoutes(r *gin.Engine, h Handlers) {
+	r.GET("/health", h.Health.Health)
+	r.HEAD("/health", h.Health.Health)
+	r.GET("/health/ready", h.Health.Ready)
+}
+
+func setupQueryRoutes(r *gin.Engine, h Handlers) {
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	r.GET("/", func(c *gin.Context) {
 		c.Redirect(302, "/swagger/index.html")
 	})
 
-	r.GET("/health", healthHandler.Health)
-	r.HEAD("/health", healthHandler.Health)
+	r.StaticFS("/demo", demo.FileSystem())
+
+	r.GET("/metrics", gin.WrapH(h.Metrics))
 
 	v1 := r.Group("/api/v1")
 	{
-		v1.GET("/rates", ratesHandler.GetRates)
-		v1.GET("/exchange", exchangeHandler.Exchange)
+		v1.GET("/rates", h.Rates.GetRates)
+		v1.GET("/exchange", withGuard(h.TenantGuard, h.Exchange.Exchange)...)
+		v1.POST("/notifications/templates/validate", h.NotificationTemplates.Validate)
+		if h.Webhooks != nil {
+			v1.GET("/webhooks/:id/deliveries", requireGuard(h.AdminGuard, h.Webhooks.ListDeliveries)...)
+		} else {
+			v1.GET("/webhooks/:id/deliveries", notImplemented)
+		}
+		v1.GET("/changelog", h.Changelog.GetChangelog)
+
+		if h.RatesStream != nil {
+			v1.GET("/rates/stream", h.RatesStream.Stream)
+		} else {
+			v1.GET("/rates/stream", notImplemented)
+		}
+		if h.Jobs != nil {
+			// A signed link authorizes the download on its own, so it can be
+			// followed without the API key the job was created with.
+			resultGuard := h.DownloadGuard
+			if resultGuard == nil {
+				resultGuard = h.TenantGuard
+			}
+			v1.GET("/jobs/:id", withGuard(h.TenantGuard, h.Jobs.Get)...)
+			v1.GET("/jobs/:id/events", withGuard(h.TenantGuard, h.Jobs.Events)...)
+			v1.GET("/jobs/:id/result", withGuard(resultGuard, h.Jobs.GetResult)...)
+		} else {
+			v1.GET("/jobs/:id", notImplemented)
+			v1.GET("/jobs/:id/events", notImplemented)
+			v1.GET("/jobs/:id/result", notImplemented)
+		}
+	}
+
+	r.GET("/admin/config", withGuard(h.AdminGuard, h.Admin.GetConfig)...)
+	r.GET("/admin/providers/report", withGuard(h.AdminGuard, h.Admin.GetProviderReport)...)
+	r.GET("/admin/fees", withGuard(h.AdminGuard, h.Fees.Get)...)
+	r.GET("/admin/fees/audit", withGuard(h.AdminGuard, h.Fees.Audit)...)
+	r.GET("/admin/audits/precision", withGuard(h.AdminGuard, h.Admin.GetPrecisionAudit)...)
+}
+
+// setupCommandRoutes registers the endpoints that change state, which are
+// kept off the read listener so heavy read traffic cannot starve them.
+func setupCommandRoutes(r *gin.Engine, h Handlers) {
+	v1 := r.Group("/api/v1")
+	{
+		if h.Webhooks != nil {
+			v1.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", requireGuard(h.AdminGuard, h.Webhooks.Redeliver)...)
+		} else {
+			v1.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", notImplemented)
+		}
+
+		if h.BulkExchange != nil {
+			v1.POST("/exchange/bulk", withGuard(h.TenantGuard, h.BulkExchange.Create)...)
+		} else {
+			v1.POST("/exchange/bulk", notImplemented)
+		}
+		if h.Jobs != nil {
+			v1.DELETE("/jobs/:id", withGuard(h.TenantGuard, h.Jobs.Cancel)...)
+		} else {
+			v1.DELETE("/jobs/:id", notImplemented)
+		}
+	}
+
+	r.PUT("/admin/fees", requireGuard(h.AdminGuard, h.Fees.Publish)...)
+}
+
+// notImplemented answers the routes whose handler this deployment lacks.
+func notImplemented(c *gin.Context) {
+	c.JSON(http.StatusNotImplemented, gin.H{"error": "this endpoint is not available in this deployment"})
+}
+
+// withGuard prepends guard to handler when one is configured.
+func withGuard(guard gin.HandlerFunc, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if guard == nil {
+		return []gin.HandlerFunc{handler}
+	}
+	return []gin.HandlerFunc{guard, handler}
+}
+
+// requireGuard is withGuard for routes that must never be open: without a
+// guard they answer 403.
+func requireGuard(guard gin.HandlerFunc, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if guard == nil {
+		return []gin.HandlerFunc{func(c *gin.Context) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "set ADMIN_TOKEN or ADMIN_TOKENS to enable this endpoint"})
+		}}
 	}
+	return []gin.HandlerFunc{guard, handler}
 }
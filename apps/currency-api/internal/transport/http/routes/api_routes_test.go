@@ -0,0 +1,167 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ajs/currency-api/internal/app/handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupRoutes_WithoutOptionalHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRoutes(r, Handlers{})
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/rates/stream?currencies=USD"},
+		{http.MethodGet, "/api/v1/jobs/job-1"},
+		{http.MethodGet, "/api/v1/jobs/job-1/events"},
+		{http.MethodGet, "/api/v1/jobs/job-1/result"},
+		{http.MethodPost, "/api/v1/exchange/bulk"},
+		{http.MethodDelete, "/api/v1/jobs/job-1"},
+		{http.MethodGet, "/api/v1/webhooks/sub-1/deliveries"},
+		{http.MethodPost, "/api/v1/webhooks/sub-1/deliveries/d-1/redeliver"},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(route.method, route.path, nil))
+
+			assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+			assert.JSONEq(t, `{"error":"this endpoint is not available in this deployment"}`, recorder.Body.String())
+		})
+	}
+}
+
+func TestSetupRoutes_RequireAdminGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRoutes(r, Handlers{Webhooks: handlers.NewWebhooksHandler(nil, nil, nil)})
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPut, "/admin/fees"},
+		{http.MethodGet, "/api/v1/webhooks/sub-1/deliveries"},
+		{http.MethodPost, "/api/v1/webhooks/sub-1/deliveries/d-1/redeliver"},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(route.method, route.path, strings.NewReader(`{}`)))
+
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+			assert.JSONEq(t, `{"error":"set ADMIN_TOKEN or ADMIN_TOKENS to enable this endpoint"}`, recorder.Body.String())
+		})
+	}
+}
+
+func TestSetupRoutes_JobsRequireTenantGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rejectTenant := func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) }
+	rejectLink := func(c *gin.Context) { c.AbortWithStatus(http.StatusForbidden) }
+	jobs := handlers.NewJobsHandler(nil, nil, nil)
+
+	r := gin.New()
+	SetupRoutes(r, Handlers{Jobs: jobs, TenantGuard: rejectTenant})
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/jobs/job-1"},
+		{http.MethodGet, "/api/v1/jobs/job-1/events"},
+		{http.MethodGet, "/api/v1/jobs/job-1/result"},
+		{http.MethodDelete, "/api/v1/jobs/job-1"},
+	} {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(route.method, route.path, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, "%s %s", route.method, route.path)
+	}
+
+	// Signed links are followed without the tenant's API key.
+	r = gin.New()
+	SetupRoutes(r, Handlers{Jobs: jobs, TenantGuard: rejectTenant, DownloadGuard: rejectLink})
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/job-1/result", nil))
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+var (
+	queryRoutes = []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/rates"},
+		{http.MethodGet, "/api/v1/exchange"},
+		{http.MethodPost, "/api/v1/notifications/templates/validate"},
+		{http.MethodGet, "/api/v1/webhooks/:id/deliveries"},
+		{http.MethodGet, "/api/v1/changelog"},
+		{http.MethodGet, "/api/v1/rates/stream"},
+		{http.MethodGet, "/api/v1/jobs/:id"},
+		{http.MethodGet, "/api/v1/jobs/:id/events"},
+		{http.MethodGet, "/api/v1/jobs/:id/result"},
+		{http.MethodGet, "/admin/config"},
+		{http.MethodGet, "/admin/providers/report"},
+		{http.MethodGet, "/admin/fees"},
+		{http.MethodGet, "/admin/fees/audit"},
+		{http.MethodGet, "/admin/audits/precision"},
+		{http.MethodGet, "/metrics"},
+	}
+	commandRoutes = []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/webhooks/:id/deliveries/:delivery_id/redeliver"},
+		{http.MethodPost, "/api/v1/exchange/bulk"},
+		{http.MethodDelete, "/api/v1/jobs/:id"},
+		{http.MethodPut, "/admin/fees"},
+	}
+)
+
+func registered(r *gin.Engine) map[string]bool {
+	routes := make(map[string]bool)
+	for _, route := range r.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	return routes
+}
+
+// concrete fills in path parameters, so the path can be requested.
+func concrete(path string) string {
+	return strings.NewReplacer(":delivery_id", "d-1", ":id", "x-1").Replace(path)
+}
+
+func TestSetupReadRoutes_ServesNoCommands(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupReadRoutes(r, Handlers{})
+	routes := registered(r)
+
+	for _, route := range queryRoutes {
+		assert.True(t, routes[route.method+" "+route.path], "%s %s is missing", route.method, route.path)
+	}
+	for _, route := range commandRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(route.method, concrete(route.path), strings.NewReader(`{}`)))
+
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		})
+	}
+	assert.True(t, routes["GET /health"])
+	assert.True(t, routes["GET /health/ready"])
+}
+
+func TestSetupWriteRoutes_ServesNoQueries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupWriteRoutes(r, Handlers{})
+	routes := registered(r)
+
+	for _, route := range commandRoutes {
+		assert.True(t, routes[route.method+" "+route.path], "%s %s is missing", route.method, route.path)
+	}
+	for _, route := range queryRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(route.method, concrete(route.path), nil))
+
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		})
+	}
+	assert.True(t, routes["GET /health"])
+	assert.True(t, routes["GET /health/ready"])
+}
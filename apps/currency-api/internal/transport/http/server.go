@@ -2,64 +2,799 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ajs/currency-api/internal/app/commands"
 	"github.com/ajs/currency-api/internal/app/handlers"
+	"github.com/ajs/currency-api/internal/app/jobs"
 	"github.com/ajs/currency-api/internal/app/queries"
+	"github.com/ajs/currency-api/internal/app/streaming"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	domainrepositories "github.com/ajs/currency-api/internal/domain/repositories"
+	"github.com/ajs/currency-api/internal/domain/services"
+	"github.com/ajs/currency-api/internal/infrastructure/audit"
+	"github.com/ajs/currency-api/internal/infrastructure/chain"
 	"github.com/ajs/currency-api/internal/infrastructure/config"
+	"github.com/ajs/currency-api/internal/infrastructure/election"
+	"github.com/ajs/currency-api/internal/infrastructure/encoding"
+	"github.com/ajs/currency-api/internal/infrastructure/fees"
+	"github.com/ajs/currency-api/internal/infrastructure/freshness"
+	"github.com/ajs/currency-api/internal/infrastructure/market"
+	"github.com/ajs/currency-api/internal/infrastructure/notification"
+	"github.com/ajs/currency-api/internal/infrastructure/pricing"
+	"github.com/ajs/currency-api/internal/infrastructure/providers"
 	"github.com/ajs/currency-api/internal/infrastructure/repositories"
+	"github.com/ajs/currency-api/internal/infrastructure/signedurl"
+	"github.com/ajs/currency-api/internal/infrastructure/supervisor"
+	"github.com/ajs/currency-api/internal/transport/http/middleware"
 	"github.com/ajs/currency-api/internal/transport/http/routes"
+	"github.com/ajs/currency-api/internal/version"
 	"github.com/ajs/go-common/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 )
 
+// feeReloadTimeout bounds the initial fee schedule load so an unreachable
+// store cannot hold up startup; the watcher keeps retrying.
+const feeReloadTimeout = 5 * time.Second
+
 type Server struct {
-	config *config.Config
-	logger logger.Logger
-	server *http.Server
+	config    *config.Config
+	logger    logger.Logger
+	servers   []*http.Server
+	closers   []io.Closer
+	registry  *prometheus.Registry
+	freshness *freshness.Tracker
+	rateHub   *streaming.Hub
+	workers   *supervisor.Supervisor
+
+	providerStats *providers.Tracker
+	redis         *redis.Client
+	elector       *election.RedisElector
+	fees          *fees.Watcher
+
+	// serverless is set for the Lambda entrypoint, which runs no background
+	// workers: Lambda freezes the process between invocations.
+	serverless bool
+
+	listening chan struct{}
 }
 
 func NewServer(cfg *config.Config, log logger.Logger) *Server {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
 	return &Server{
-		config: cfg,
-		logger: log,
+		config:    cfg,
+		logger:    log,
+		registry:  registry,
+		listening: make(chan struct{}),
 	}
 }
 
-func (s *Server) Start() error {
+// Listening is closed once Start has bound every port, i.e. when the instance
+// can be announced to service discovery.
+func (s *Server) Listening() <-chan struct{} {
+	return s.listening
+}
+
+// listener is one HTTP server and the routes it serves.
+type listener struct {
+	name       string
+	port       string
+	timeouts   config.ServerTimeouts
+	middleware []config.MiddlewareSpec
+	routes     func(*gin.Engine, routes.Handlers)
+}
+
+// listeners serves everything on READ_PORT, or moves the command endpoints
+// to WRITE_PORT so read traffic cannot exhaust the connections they need.
+func (s *Server) listeners() []listener {
+	read := listener{
+		name:       "read",
+		port:       s.config.ReadPort,
+		timeouts:   s.config.ReadServerTimeouts,
+		middleware: s.config.ReadMiddleware,
+		routes:     routes.SetupRoutes,
+	}
+	if !s.config.SplitListeners() {
+		return []listener{read}
+	}
+
+	read.routes = routes.SetupReadRoutes
+	return []listener{read, {
+		name:       "write",
+		port:       s.config.WritePort,
+		timeouts:   s.config.WriteServerTimeouts,
+		middleware: s.config.WriteMiddleware,
+		routes:     routes.SetupWriteRoutes,
+	}}
+}
+
+// Handler builds the gin engine of the Lambda entrypoint, serving every
+// route of a single listener. It starts no background workers, so the rate
+// stream and the asynchronous job endpoints, which depend on them and on
+// streamed responses, answer 501. Fee schedules are reloaded on requests
+// instead of watched.
+func (s *Server) Handler() (http.Handler, error) {
 	gin.SetMode(s.config.GinMode)
+	s.serverless = true
+
+	read := s.listeners()[0]
+	r, err := s.newEngine(read)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := s.routeHandlers()
+	if err != nil {
+		return nil, err
+	}
+	r.Use(s.reloadFees())
+	routes.SetupRoutes(r, h)
+
+	return r, nil
+}
+
+// reloadFees stands in for the fee schedule watcher where it does not run:
+// the versions are reloaded before a request once FEE_RESYNC_INTERVAL has
+// passed since the last reload, or before every request when it is 0.
+func (s *Server) reloadFees() gin.HandlerFunc {
+	var (
+		mu     sync.Mutex
+		loaded = time.Now()
+	)
+	return func(c *gin.Context) {
+		mu.Lock()
+		if time.Since(loaded) >= s.config.FeeResyncInterval {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), feeReloadTimeout)
+			if err := s.fees.Reload(ctx); err != nil {
+				s.logger.Error("Failed to reload fee schedules", err)
+			} else {
+				loaded = time.Now()
+			}
+			cancel()
+		}
+		mu.Unlock()
+		c.Next()
+	}
+}
+
+// newEngine builds the gin engine of a listener with its middleware stack.
+// Each engine gets its own middleware instances, so e.g. rate limits are
+// counted per listener.
+func (s *Server) newEngine(l listener) (*gin.Engine, error) {
+	stack, err := middleware.Chain(l.middleware, middleware.Dependencies{Logger: s.logger, Environment: s.config.Environment})
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s middleware: %w", l.name, err)
+	}
+	for _, spec := range l.middleware {
+		if spec.Name == "chaos" {
+			s.logger.Warn("Chaos injection is enabled, requests will fail or slow down on purpose", "listener", l.name, "middleware", spec.String())
+		}
+	}
 
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(stack...)
+	return r, nil
+}
+
+// routeHandlers wires the application and starts its background workers,
+// unless the server is serverless.
+func (s *Server) routeHandlers() (routes.Handlers, error) {
+	s.workers = supervisor.New(supervisor.Policy{
+		InitialBackoff: s.config.WorkerRestartBackoff,
+		MaxBackoff:     s.config.WorkerMaxBackoff,
+		MaxRestarts:    s.config.WorkerMaxRestarts,
+		Window:         s.config.WorkerRestartWindow,
+	}, s.registry, s.logger)
+
+	healthOptions := []handlers.HealthHandlerOption{handlers.WithWorkerReporter(s.workers)}
+	if s.config.LeaderElection && !s.serverless {
+		elector, err := s.newElector()
+		if err != nil {
+			return routes.Handlers{}, err
+		}
+		healthOptions = append(healthOptions, handlers.WithLeadershipReporter(elector))
+	}
+
+	s.providerStats = providers.NewTracker()
+	ratesRepo, err := s.newRatesRepository()
+	if err != nil {
+		return routes.Handlers{}, err
+	}
+	if err := s.startProviderComparison(); err != nil {
+		return routes.Handlers{}, err
+	}
+
+	changelogRepo := repositories.NewChangelogRepositoryImpl()
+
+	ratesQueryHandler := queries.NewGetRatesQueryHandler(ratesRepo, queries.WithResponseBudget(s.config.RatesResponseBudget))
+	exchangeOptions, err := s.exchangeQueryOptions()
+	if err != nil {
+		return routes.Handlers{}, err
+	}
+
+	feeRepo, err := s.newFeeScheduleRepository()
+	if err != nil {
+		return routes.Handlers{}, err
+	}
+	feeWatcher := fees.NewWatcher(feeRepo, s.config.FeeResyncInterval, s.registry, s.logger)
+	reloadCtx, cancelReload := context.WithTimeout(context.Background(), feeReloadTimeout)
+	if err := feeWatcher.Reload(reloadCtx); err != nil {
+		s.logger.Error("Failed to load fee schedules, conversions are free until the watcher catches up", err)
+	}
+	cancelReload()
+	s.startWorker("fee_schedule_watcher", feeWatcher.Run)
+	s.fees = feeWatcher
+	exchangeOptions = append(exchangeOptions, queries.WithFeeSchedules(feeWatcher))
+
+	var auditor *audit.Auditor
+	adminOptions := []handlers.AdminHandlerOption{handlers.WithProviderReporter(s.providerStats)}
+	if s.config.PrecisionAuditSampleRate > 0 && !s.serverless {
+		auditRepo, err := s.newPrecisionAuditRepository()
+		if err != nil {
+			return routes.Handlers{}, err
+		}
+		sampler := audit.NewSampler(auditRepo, s.config.PrecisionAuditSampleRate, s.registry, s.logger)
+		s.startWorker("conversion_sampler", sampler.Run)
+		exchangeOptions = append(exchangeOptions, queries.WithConversionRecorder(sampler))
+		auditor = audit.NewAuditor(auditRepo, s.config.PrecisionAuditAt, s.registry, s.logger)
+		adminOptions = append(adminOptions, handlers.WithPrecisionAudits(queries.NewGetPrecisionAuditQueryHandler(auditRepo)))
+	}
+
+	exchangeQueryHandler := queries.NewExchangeQueryHandler(exchangeOptions...)
+	changelogQueryHandler := queries.NewGetChangelogQueryHandler(changelogRepo)
+
+	healthOptions = append(healthOptions, handlers.WithFreshnessReporter(s.freshness))
+	if auditor != nil {
+		s.runLeaderJob("precision_audit", auditor.Run)
+	}
+	if s.elector != nil {
+		// Leader-only jobs have to be registered before the campaign starts.
+		s.startWorker("leader_election", s.elector.Run)
+	}
+
+	encoder, err := encoding.NewJSONEncoder(s.config.JSONEncoder)
+	if err != nil {
+		return routes.Handlers{}, err
+	}
+	encodingMetrics := encoding.NewMetrics(s.registry)
+
+	ratesOptions := []handlers.RatesHandlerOption{handlers.WithRatesJSONEncoder(encodingMetrics.Instrument(encoder, "rates"))}
+	marketCalendar, err := market.NewCalendar(s.config.MarketHolidays, s.config.MarketUpdateInterval)
+	if err != nil {
+		return routes.Handlers{}, fmt.Errorf("failed to create market calendar: %w", err)
+	}
+	ratesOptions = append(ratesOptions, handlers.WithMarketCalendar(marketCalendar))
+	exchangeHandlerOptions := []handlers.ExchangeHandlerOption{handlers.WithExchangeJSONEncoder(encodingMetrics.Instrument(encoder, "exchange"))}
+
+	healthHandler := handlers.NewHealthHandler(s.config, s.logger, healthOptions...)
+	ratesHandler := handlers.NewRatesHandler(ratesQueryHandler, s.logger, ratesOptions...)
+
+	exchangeHandler := handlers.NewExchangeHandler(exchangeQueryHandler, s.logger, exchangeHandlerOptions...)
+	changelogHandler := handlers.NewChangelogHandler(changelogQueryHandler, s.logger)
+
+	templatesQueryHandler := queries.NewValidateNotificationTemplateQueryHandler(notification.NewTemplateEngine())
+	notificationTemplatesHandler := handlers.NewNotificationTemplatesHandler(templatesQueryHandler, s.logger)
+
+	// Nothing in this service produces notifications yet, so the delivery
+	// endpoints answer 501 until an alert or digest producer sends through a
+	// notification.DeliveryLog here. The channels are still built, so bad
+	// credentials fail at startup rather than at the first alert.
+	if _, err := s.newNotificationRouter(); err != nil {
+		return routes.Handlers{}, err
+	}
+
+	feesHandler := handlers.NewFeesHandler(queries.NewGetFeeScheduleQueryHandler(feeWatcher), queries.NewListFeeAuditQueryHandler(feeRepo), commands.NewPublishFeeScheduleCommandHandler(feeRepo), s.logger)
+
+	adminHandler := handlers.NewAdminHandler(s.config, s.logger, adminOptions...)
+	var adminGuard gin.HandlerFunc
+	if credentials := s.config.AdminCredentials(); len(credentials) > 0 {
+		adminGuard = middleware.BearerToken(credentials)
+	} else if s.config.IsProduction() {
+		s.logger.Warn("⚠️ ADMIN_TOKEN is not set: admin endpoints are read-only and unauthenticated")
+	}
+	var tenantGuard gin.HandlerFunc
+	if len(s.config.TenantAPIKeys) > 0 {
+		tenantGuard = middleware.TenantKey(s.config.TenantAPIKeys)
+	} else if s.config.PricingRulesFile != "" {
+		s.logger.Warn("⚠️ TENANT_API_KEYS is not set: every exchange is priced with the default pricing rule")
+	}
+
+	h := routes.Handlers{
+		Health:                healthHandler,
+		Rates:                 ratesHandler,
+		Exchange:              exchangeHandler,
+		Changelog:             changelogHandler,
+		NotificationTemplates: notificationTemplatesHandler,
+		Admin:                 adminHandler,
+		Fees:                  feesHandler,
+		AdminGuard:            adminGuard,
+		TenantGuard:           tenantGuard,
+		Metrics:               promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}),
+	}
+	if s.serverless {
+		return h, nil
+	}
+
+	s.rateHub = streaming.NewHub(ratesRepo, s.config.StreamRefreshInterval, s.config.StreamCoalesceInterval, s.config.StreamBufferSize, s.logger)
+	s.startWorker("rates_stream_refresher", s.rateHub.Run)
+	s.closers = append(s.closers, s.rateHub)
+	streamGate := streaming.NewGate(s.config.StreamAPIKeys, streaming.Limits{
+		MaxPairs:                s.config.StreamMaxPairs,
+		MaxConnectionsPerClient: s.config.StreamMaxConnections,
+	}, s.registry)
+	if len(s.config.StreamAPIKeys) == 0 && s.config.IsProduction() {
+		s.logger.Warn("⚠️ STREAM_API_KEYS is not set: rate streams are unauthenticated")
+	}
+	h.RatesStream = handlers.NewRatesStreamHandler(s.rateHub, streamGate, s.logger)
+
+	jobRepo, err := s.newJobRepository()
+	if err != nil {
+		return routes.Handlers{}, err
+	}
+	jobManager := jobs.NewManager(jobRepo, s.config.JobWorkers, s.config.JobQueueSize, s.logger)
+	for i := 1; i <= jobManager.Workers(); i++ {
+		s.startWorker(fmt.Sprintf("job_worker_%d", i), jobManager.Work)
+	}
+	s.closers = append(s.closers, jobManager)
+
+	bulkCommandHandler := commands.NewBulkExchangeCommandHandler(exchangeQueryHandler, jobManager, s.config.BulkMaxRows)
+	h.BulkExchange = handlers.NewBulkExchangeHandler(bulkCommandHandler, s.config.BulkMaxUploadBytes, s.logger)
+
+	jobsOptions := []handlers.JobsHandlerOption{handlers.WithJobWatcher(jobManager)}
+	if s.config.DownloadURLSecret != "" {
+		signer := signedurl.NewSigner([]byte(s.config.DownloadURLSecret), s.config.DownloadURLTTL, s.config.DownloadBaseURL)
+		jobsOptions = append(jobsOptions, handlers.WithJobResultSigner(signer))
+		h.DownloadGuard = middleware.SignedURL(signer)
+	}
+	h.Jobs = handlers.NewJobsHandler(queries.NewGetJobQueryHandler(jobRepo), commands.NewCancelJobCommandHandler(jobManager), s.logger, jobsOptions...)
+
+	return h, nil
+}
+
+func (s *Server) Start() error {
+	gin.SetMode(s.config.GinMode)
+
+	listeners := s.listeners()
+	engines := make([]*gin.Engine, len(listeners))
+	for i, l := range listeners {
+		engine, err := s.newEngine(l)
+		if err != nil {
+			return err
+		}
+		engines[i] = engine
+	}
 
-	ratesRepo := repositories.NewRatesRepositoryImpl(s.config, s.logger)
+	h, err := s.routeHandlers()
+	if err != nil {
+		return err
+	}
+
+	// Every port is bound before any is served, so a port in use fails
+	// startup instead of leaving a half-started instance.
+	netListeners := make([]net.Listener, 0, len(listeners))
+	addresses := make([]string, 0, len(listeners))
+	for i, l := range listeners {
+		l.routes(engines[i], h)
+
+		server := &http.Server{
+			Addr:         ":" + l.port,
+			Handler:      engines[i],
+			ReadTimeout:  l.timeouts.Read,
+			WriteTimeout: l.timeouts.Write,
+			IdleTimeout:  l.timeouts.Idle,
+		}
 
-	ratesQueryHandler := queries.NewGetRatesQueryHandler(ratesRepo)
-	exchangeQueryHandler := queries.NewExchangeQueryHandler()
+		netListener, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			for _, bound := range netListeners {
+				_ = bound.Close()
+			}
+			return fmt.Errorf("failed to listen on %s for %s traffic: %w", server.Addr, l.name, err)
+		}
 
-	healthHandler := handlers.NewHealthHandler(s.config, s.logger)
-	ratesHandler := handlers.NewRatesHandler(ratesQueryHandler, s.logger)
-	exchangeHandler := handlers.NewExchangeHandler(exchangeQueryHandler, s.logger)
+		s.servers = append(s.servers, server)
+		netListeners = append(netListeners, netListener)
+		addresses = append(addresses, netListener.Addr().String())
+	}
+
+	// Rate streams never end on their own; close them so shutdown does not
+	// wait for its deadline. They are only served by the read listener.
+	s.servers[0].RegisterOnShutdown(func() { _ = s.rateHub.Close() })
 
-	routes.SetupRoutes(r, healthHandler, ratesHandler, exchangeHandler)
+	s.LogStartup(addresses...)
+	close(s.listening)
 
-	s.server = &http.Server{
-		Addr:         ":" + s.config.Port,
-		Handler:      r,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	errs := make(chan error, len(s.servers))
+	for i, server := range s.servers {
+		go func() {
+			if err := server.Serve(netListeners[i]); !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("%s listener failed: %w", listeners[i].name, err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+	for range s.servers {
+		if err := <-errs; err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// LogStartup emits the single structured startup event log pipelines index
+// deploys by. The Lambda entrypoint has no listen addresses. A readable
+// banner is only logged at debug level.
+func (s *Server) LogStartup(listenAddresses ...string) {
+	configAttrs := []any{
+		"gin_mode", s.config.GinMode,
+		"log_level", s.config.LogLevel,
+		"log_format", s.config.LogFormat,
+		"rate_source", s.rateSource(),
+		"json_encoder", s.config.JSONEncoder,
+		"job_store", s.config.JobStore,
+		"job_workers", s.config.JobWorkers,
+		"middleware", middlewareStack(s.config.ReadMiddleware),
+	}
+	if s.config.SplitListeners() {
+		configAttrs = append(configAttrs, "write_middleware", middlewareStack(s.config.WriteMiddleware))
+	}
+
+	s.logger.Info("Service started",
+		"event", "startup",
+		"service", s.config.ServiceName,
+		"version", version.Version,
+		"revision", version.Revision(),
+		"environment", s.config.Environment,
+		"region", s.config.Region,
+		"instance_id", s.config.InstanceID,
+		"listen_addresses", listenAddresses,
+		"features", s.config.Features(),
+		slog.Group("config", configAttrs...),
+	)
 
-	s.logger.Info(fmt.Sprintf("🚀 Starting server on port %s", s.config.Port))
-	s.logger.Info(fmt.Sprintf("🔧 Environment: %s", s.config.Environment))
-	s.logger.Info(fmt.Sprintf("⚙️ Gin Mode: %s", s.config.GinMode))
-	return s.server.ListenAndServe()
+	s.logger.Debug(fmt.Sprintf("🚀 %s %s (%s, gin %s) listening on %s",
+		s.config.ServiceName, version.Version, s.config.Environment, s.config.GinMode, strings.Join(listenAddresses, ", ")))
+}
+
+// middlewareStack lists middleware in order, as in MIDDLEWARE.
+func middlewareStack(specs []config.MiddlewareSpec) []string {
+	stack := make([]string, len(specs))
+	for i, spec := range specs {
+		stack[i] = spec.String()
+	}
+	return stack
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	s.logger.Info("🛑 Shutting down server...")
-	return s.server.Shutdown(ctx)
+	s.logger.Info("Service shutting down", "event", "shutdown")
+	var errs []error
+	for _, server := range s.servers {
+		errs = append(errs, server.Shutdown(ctx))
+	}
+	err := errors.Join(errs...)
+
+	// Stop the background workers before the resources they use.
+	if s.workers != nil {
+		_ = s.workers.Close()
+	}
+
+	// Close in reverse order so resources outlive the components built on them.
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if closeErr := s.closers[i].Close(); closeErr != nil {
+			s.logger.Error("Failed to release resource", closeErr)
+		}
+	}
+
+	return err
+}
+
+// rateSource names where rates come from, matching newRatesRepository.
+func (s *Server) rateSource() string {
+	switch {
+	case s.config.ReplicaMode:
+		return "replica"
+	case s.config.RateProviderPlugin != "":
+		return "plugin"
+	case s.config.OpenExchangeAPIKey != "":
+		return "openexchangerates"
+	default:
+		return "mock"
+	}
+}
+
+func (s *Server) exchangeQueryOptions() ([]queries.ExchangeQueryOption, error) {
+	var opts []queries.ExchangeQueryOption
+
+	if s.config.PricingRulesFile != "" {
+		rules, err := pricing.LoadExprPricingRules(s.config.PricingRulesFile, s.config.PricingRuleTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pricing rules: %w", err)
+		}
+		s.logger.Info("💱 Pricing rules loaded", "file", s.config.PricingRulesFile)
+		opts = append(opts, queries.WithPricingRules(rules))
+	}
+
+	chainRules := chain.DefaultRules()
+	if s.config.ChainRulesFile != "" {
+		rules, err := chain.LoadRules(s.config.ChainRulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chain rules: %w", err)
+		}
+		s.logger.Info("⛓️ Chain rules loaded", "file", s.config.ChainRulesFile)
+		chainRules = rules
+	}
+	opts = append(opts, queries.WithChainRules(chainRules))
+
+	return opts, nil
+}
+
+// startProviderComparison polls the COMPARE_RATE_PROVIDERS plugins so the
+// provider report can compare them with the primary source. Only the leader
+// polls, so the quota used does not grow with the number of instances. A
+// serverless server cannot poll and leaves them out.
+func (s *Server) startProviderComparison() error {
+	if len(s.config.CompareRateProviders) == 0 || s.serverless {
+		return nil
+	}
+
+	compared := make(map[string]domainrepositories.RatesRepository, len(s.config.CompareRateProviders))
+	for name, command := range s.config.CompareRateProviders {
+		plugin, err := repositories.NewPluginRatesRepository(command, s.config.RateProviderPluginTimeout, s.logger)
+		if err != nil {
+			return fmt.Errorf("invalid COMPARE_RATE_PROVIDERS entry %q: %w", name, err)
+		}
+		if closer, ok := plugin.(io.Closer); ok {
+			s.closers = append(s.closers, closer)
+		}
+		s.providerStats.Register(name, false)
+		compared[name] = repositories.NewProviderStatsRatesRepository(plugin, name, s.providerStats)
+	}
+
+	poller := providers.NewPoller(compared, s.config.ProviderCompareCurrencies, s.config.ProviderCompareInterval, s.logger)
+	s.runLeaderJob("provider_poller", poller.Run)
+	s.logger.Info("📊 Comparing rate providers", "providers", len(compared), "interval", s.config.ProviderCompareInterval.String())
+	return nil
+}
+
+func (s *Server) newRatesRepository() (domainrepositories.RatesRepository, error) {
+	var repo domainrepositories.RatesRepository
+	switch {
+	case s.config.ReplicaMode:
+		store, err := s.newRatesSnapshotStore()
+		if err != nil {
+			return nil, err
+		}
+		s.logger.Info("📡 Read replica mode: serving rates published by the primary region", "region", s.config.Region)
+		repo = repositories.NewReplicaRatesRepository(store, s.logger)
+	case s.config.RateProviderPlugin == "":
+		repo = repositories.NewRatesRepositoryImpl(s.config, s.logger)
+	default:
+		plugin, err := repositories.NewPluginRatesRepository(s.config.RateProviderPlugin, s.config.RateProviderPluginTimeout, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_PROVIDER_PLUGIN: %w", err)
+		}
+		repo = plugin
+		if closer, ok := repo.(io.Closer); ok {
+			s.closers = append(s.closers, closer)
+		}
+	}
+
+	if !s.config.ReplicaMode {
+		s.providerStats.Register(s.rateSource(), true)
+		repo = repositories.NewProviderStatsRatesRepository(repo, s.rateSource(), s.providerStats)
+	}
+
+	if s.config.SnapshotPublish {
+		store, err := s.newRatesSnapshotStore()
+		if err != nil {
+			return nil, err
+		}
+		repo = repositories.NewSnapshotPublishingRatesRepository(repo, store, s.config.Region, s.logger)
+	}
+
+	s.freshness = freshness.NewTracker(
+		s.config.FreshnessSLOs,
+		s.config.FreshnessSLOTarget,
+		s.config.FreshnessSLOWindow,
+		s.config.FreshnessCheckInterval,
+		s.registry,
+	)
+	s.freshness.Check()
+	if s.config.FreshnessCheckInterval > 0 {
+		s.startWorker("freshness_check", s.freshness.Run)
+	}
+	repo = repositories.NewFreshnessRatesRepository(repo, s.freshness)
+
+	if s.config.RatesCacheMaxTTL > 0 {
+		repo = repositories.NewCachingRatesRepository(repo, repositories.VolatilityTTLPolicy{
+			MinTTL:         s.config.RatesCacheMinTTL,
+			MaxTTL:         s.config.RatesCacheMaxTTL,
+			HighVolatility: s.config.RatesCacheHighVolatility,
+			HistorySize:    s.config.RatesCacheHistorySize,
+		}, s.registry, repositories.WithStaleFallback(s.config.RatesCacheMaxStale))
+	}
+
+	if s.config.RatesNegativeCacheTTL > 0 {
+		repo = repositories.NewNegativeCacheRatesRepository(repo, s.config.RatesNegativeCacheTTL)
+	}
+
+	if s.config.MockOverridesFile != "" {
+		overrides, err := repositories.LoadMockOverrides(s.config.MockOverridesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mock overrides: %w", err)
+		}
+		s.logger.Warn("🎭 Mock rate overrides active", "file", s.config.MockOverridesFile, "pairs", len(overrides))
+		repo = repositories.NewMockOverridesRatesRepository(repo, overrides)
+	}
+
+	return repo, nil
+}
+
+func (s *Server) newRatesSnapshotStore() (*repositories.RatesSnapshotStore, error) {
+	client, err := s.redisClient()
+	if err != nil {
+		return nil, err
+	}
+	return repositories.NewRatesSnapshotStore(client), nil
+}
+
+// newElector sets up the leadership campaign. Write-side background jobs
+// register on s.elector through runLeaderJob so only the leader runs them.
+func (s *Server) newElector() (*election.RedisElector, error) {
+	client, err := s.redisClient()
+	if err != nil {
+		return nil, err
+	}
+
+	instanceID := s.config.InstanceID
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve hostname for INSTANCE_ID: %w", err)
+		}
+		instanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	s.elector = election.NewRedisElector(client, s.config.ServiceName+":leader", instanceID, s.config.LeaderLeaseTTL, s.logger)
+	s.closers = append(s.closers, s.elector)
+	return s.elector, nil
+}
+
+// startWorker supervises run in the background, except on a serverless
+// server.
+func (s *Server) startWorker(name string, run func(context.Context) error) {
+	if s.serverless {
+		return
+	}
+	s.workers.Go(name, run)
+}
+
+// runLeaderJob runs job on the elected leader when LEADER_ELECTION is on and
+// on this instance otherwise.
+func (s *Server) runLeaderJob(name string, job func(context.Context) error) {
+	if s.elector == nil {
+		s.startWorker(name, job)
+		return
+	}
+
+	s.elector.Register(name, func(ctx context.Context) {
+		if err := job(ctx); err != nil {
+			s.logger.Error("Leader-only job failed", err, "job", name)
+		}
+	})
+}
+
+// newNotificationRouter enables Slack, which needs no credentials, plus email
+// and Telegram when they are configured.
+func (s *Server) newNotificationRouter() (*notification.Router, error) {
+	retry := notification.RetryPolicy{MaxAttempts: s.config.NotifyMaxAttempts, Backoff: s.config.NotifyRetryBackoff}
+
+	slack, err := notification.NewSlackNotifier(retry, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	channels := map[entities.NotificationChannel]services.Notifier{entities.ChannelSlack: slack}
+
+	if s.config.SMTPHost != "" {
+		email, err := notification.NewEmailNotifier(notification.EmailConfig{
+			Host:     s.config.SMTPHost,
+			Port:     s.config.SMTPPort,
+			Username: s.config.SMTPUsername,
+			Password: s.config.SMTPPassword,
+			From:     s.config.SMTPFrom,
+			Senders:  s.config.SMTPTenantSenders,
+			Retry:    retry,
+		}, s.logger)
+		if err != nil {
+			return nil, err
+		}
+		channels[entities.ChannelEmail] = email
+	}
+
+	if s.config.TelegramBotToken != "" {
+		telegram, err := notification.NewTelegramNotifier(s.config.TelegramBotToken, retry, s.logger)
+		if err != nil {
+			return nil, err
+		}
+		channels[entities.ChannelTelegram] = telegram
+	}
+
+	return notification.NewRouter(channels), nil
+}
+
+// newJobRepository stores jobs in Redis when JOB_STORE=redis so any instance
+// can serve their status and results; the default keeps them in memory.
+func (s *Server) newJobRepository() (domainrepositories.JobRepository, error) {
+	if s.config.JobStore != "redis" {
+		return repositories.NewMemoryJobRepository(), nil
+	}
+
+	client, err := s.redisClient()
+	if err != nil {
+		return nil, err
+	}
+	return repositories.NewRedisJobRepository(client, s.config.JobRetention), nil
+}
+
+// newFeeScheduleRepository shares fee schedules through Redis when
+// FEE_STORE=redis so a publish reaches every instance; the default keeps
+// them in memory for a single instance.
+func (s *Server) newFeeScheduleRepository() (domainrepositories.FeeScheduleRepository, error) {
+	if s.config.FeeStore != "redis" {
+		return repositories.NewMemoryFeeScheduleRepository(), nil
+	}
+
+	client, err := s.redisClient()
+	if err != nil {
+		return nil, err
+	}
+	return repositories.NewRedisFeeScheduleRepository(client), nil
+}
+
+// newPrecisionAuditRepository pools the conversion samples of every instance
+// in Redis when PRECISION_AUDIT_STORE=redis; the default keeps each
+// instance's samples in its own memory.
+func (s *Server) newPrecisionAuditRepository() (domainrepositories.PrecisionAuditRepository, error) {
+	if s.config.PrecisionAuditStore != "redis" {
+		return repositories.NewMemoryPrecisionAuditRepository(s.config.PrecisionAuditMaxSamples), nil
+	}
+
+	client, err := s.redisClient()
+	if err != nil {
+		return nil, err
+	}
+	return repositories.NewRedisPrecisionAuditRepository(client, s.config.PrecisionAuditMaxSamples), nil
+}
+
+// redisClient lazily connects to REDIS_URL; the client is shared by every
+// Redis-backed feature and closed on shutdown.
+func (s *Server) redisClient() (*redis.Client, error) {
+	if s.redis != nil {
+		return s.redis, nil
+	}
+
+	options, err := redis.ParseURL(s.config.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+
+	s.redis = redis.NewClient(options)
+	s.closers = append(s.closers, s.redis)
+	return s.redis, nil
 }
@@ -0,0 +1,111 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Adapter translates API Gateway proxy events into http.Requests so the same
+// gin engine used by the HTTP server can run inside Lambda.
+type Adapter struct {
+	handler http.Handler
+}
+
+func NewAdapter(handler http.Handler) *Adapter {
+	return &Adapter{handler: handler}
+}
+
+func (a *Adapter) Proxy(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	req, err := newRequest(ctx, event)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, fmt.Errorf("failed to convert API Gateway event: %w", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	a.handler.ServeHTTP(recorder, req)
+
+	return newResponse(recorder), nil
+}
+
+func newRequest(ctx context.Context, event events.APIGatewayProxyRequest) (*http.Request, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode body: %w", err)
+		}
+		body = decoded
+	}
+
+	query := url.Values{}
+	for key, values := range event.MultiValueQueryStringParameters {
+		query[key] = values
+	}
+	for key, value := range event.QueryStringParameters {
+		if _, exists := query[key]; !exists {
+			query.Set(key, value)
+		}
+	}
+
+	target := &url.URL{Path: event.Path, RawQuery: query.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, event.HTTPMethod, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, values := range event.MultiValueHeaders {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	for key, value := range event.Headers {
+		if req.Header.Get(key) == "" {
+			req.Header.Set(key, value)
+		}
+	}
+
+	req.Host = req.Header.Get("Host")
+	req.RemoteAddr = event.RequestContext.Identity.SourceIP
+
+	return req, nil
+}
+
+func newResponse(recorder *httptest.ResponseRecorder) events.APIGatewayProxyResponse {
+	headers := make(map[string][]string, len(recorder.Header()))
+	for key, values := range recorder.Header() {
+		headers[key] = values
+	}
+
+	response := events.APIGatewayProxyResponse{
+		StatusCode:        recorder.Code,
+		MultiValueHeaders: headers,
+	}
+
+	if isTextContent(recorder.Header().Get("Content-Type")) {
+		response.Body = recorder.Body.String()
+	} else {
+		response.Body = base64.StdEncoding.EncodeToString(recorder.Body.Bytes())
+		response.IsBase64Encoded = true
+	}
+
+	return response
+}
+
+func isTextContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "xml") ||
+		strings.Contains(contentType, "javascript")
+}
@@ -0,0 +1,98 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/rates", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"currencies": c.QueryArray("currencies"),
+			"client_ip":  c.Request.RemoteAddr,
+			"trace":      c.GetHeader("X-Trace-Id"),
+		})
+	})
+	r.POST("/echo", func(c *gin.Context) {
+		body, _ := c.GetRawData()
+		c.Data(http.StatusCreated, "application/octet-stream", body)
+	})
+	return r
+}
+
+func TestAdapter_Proxy_GetWithQuery(t *testing.T) {
+	adapter := NewAdapter(newTestEngine())
+
+	response, err := adapter.Proxy(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/api/v1/rates",
+		MultiValueQueryStringParameters: map[string][]string{
+			"currencies": {"USD,EUR"},
+		},
+		Headers: map[string]string{"X-Trace-Id": "abc"},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Identity: events.APIGatewayRequestIdentity{SourceIP: "203.0.113.7"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.False(t, response.IsBase64Encoded)
+	assert.JSONEq(t, `{"currencies":["USD,EUR"],"client_ip":"203.0.113.7","trace":"abc"}`, response.Body)
+	assert.Contains(t, response.MultiValueHeaders["Content-Type"][0], "application/json")
+}
+
+func TestAdapter_Proxy_BinaryBody(t *testing.T) {
+	adapter := NewAdapter(newTestEngine())
+	payload := []byte{0x00, 0x01, 0xff}
+
+	response, err := adapter.Proxy(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Path:            "/echo",
+		Body:            base64.StdEncoding.EncodeToString(payload),
+		IsBase64Encoded: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.True(t, response.IsBase64Encoded)
+
+	decoded, err := base64.StdEncoding.DecodeString(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+}
+
+func TestAdapter_Proxy_NotFound(t *testing.T) {
+	adapter := NewAdapter(newTestEngine())
+
+	response, err := adapter.Proxy(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/missing",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestAdapter_Proxy_InvalidBase64(t *testing.T) {
+	adapter := NewAdapter(newTestEngine())
+
+	_, err := adapter.Proxy(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Path:            "/echo",
+		Body:            "%%%",
+		IsBase64Encoded: true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode body")
+}
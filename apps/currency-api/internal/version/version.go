@@ -0,0 +1,23 @@
+// Package version identifies the running build.
+package version
+
+import "runtime/debug"
+
+// Version is the release of the service. Release builds override it with
+// -ldflags "-X github.com/ajs/currency-api/internal/version.Version=<tag>".
+var Version = "2.0.0"
+
+// Revision returns the VCS commit the binary was built from, or "" when the
+// build carries no VCS information.
+func Revision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
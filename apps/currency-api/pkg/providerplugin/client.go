@@ -0,0 +1,209 @@
+package providerplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	// closeGracePeriod is how long Close waits for a plugin to exit once its
+	// stdin is closed before killing it.
+	closeGracePeriod = 5 * time.Second
+
+	defaultCallTimeout = 10 * time.Second
+)
+
+// ErrCallTimeout is returned by a call the plugin did not answer within the
+// call timeout. The plugin is killed, as a hung plugin would block every
+// later call too.
+var ErrCallTimeout = errors.New("plugin did not answer in time")
+
+// Client runs a plugin executable and calls it over JSON-RPC.
+type Client struct {
+	cmd         *exec.Cmd
+	rpc         *rpc.Client
+	exited      chan struct{}
+	closeGrace  time.Duration
+	callTimeout time.Duration
+
+	mu     sync.Mutex
+	closed bool
+}
+
+type ClientOption func(*Client)
+
+// WithCallTimeout bounds how long a single call may wait for the plugin.
+func WithCallTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.callTimeout = timeout
+	}
+}
+
+func Start(path string, args []string, opts ...ClientOption) (*Client, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), HandshakeKey+"="+HandshakeValue)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+
+	client := &Client{
+		cmd:         cmd,
+		rpc:         jsonrpc.NewClient(pipe{ReadCloser: stdout, WriteCloser: stdin}),
+		exited:      make(chan struct{}),
+		closeGrace:  closeGracePeriod,
+		callTimeout: defaultCallTimeout,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	go func() {
+		_ = cmd.Wait()
+		close(client.exited)
+	}()
+
+	return client, nil
+}
+
+func NewClient(conn io.ReadWriteCloser, opts ...ClientOption) *Client {
+	client := &Client{rpc: jsonrpc.NewClient(conn), exited: make(chan struct{}), callTimeout: defaultCallTimeout}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+func (c *Client) Name(ctx context.Context) (string, error) {
+	var reply NameReply
+	if err := c.call(ctx, serviceName+".Name", NameArgs{}, &reply); err != nil {
+		return "", err
+	}
+	return reply.Name, nil
+}
+
+func (c *Client) GetRates(ctx context.Context, currencies []string) (map[string]float64, error) {
+	var reply GetRatesReply
+	if err := c.call(ctx, serviceName+".GetRates", GetRatesArgs{Currencies: currencies}, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Rates, nil
+}
+
+// Exited reports whether the plugin process has terminated, or for a client
+// over a plain connection, whether it was killed.
+func (c *Client) Exited() bool {
+	select {
+	case <-c.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the connection, which tells the plugin to exit, and waits for
+// it to. A plugin still running after the grace period is killed.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	err := c.rpc.Close()
+	if c.cmd == nil {
+		return err
+	}
+
+	timer := time.NewTimer(c.closeGrace)
+	defer timer.Stop()
+	select {
+	case <-c.exited:
+	case <-timer.C:
+		_ = c.cmd.Process.Kill()
+		<-c.exited
+	}
+	return err
+}
+
+// Kill stops the plugin at once, failing the calls still waiting for it, and
+// returns once it has exited.
+func (c *Client) Kill() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	_ = c.rpc.Close()
+	if c.cmd == nil {
+		close(c.exited)
+		return
+	}
+	_ = c.cmd.Process.Kill()
+	<-c.exited
+}
+
+// call kills the plugin when it does not answer in time or the connection
+// breaks, e.g. because the plugin wrote something other than a JSON-RPC frame
+// to stdout, so its owner starts a fresh one. Errors returned by the provider
+// leave it running.
+func (c *Client) call(ctx context.Context, method string, args any, reply any) error {
+	call := c.rpc.Go(method, args, reply, make(chan *rpc.Call, 1))
+
+	timer := time.NewTimer(c.callTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		c.Kill()
+		return fmt.Errorf("plugin call %s failed after %s: %w", method, c.callTimeout, ErrCallTimeout)
+	case <-call.Done:
+		if call.Error == nil {
+			return nil
+		}
+		var providerErr rpc.ServerError
+		if !errors.As(call.Error, &providerErr) {
+			c.Kill()
+		}
+		return fmt.Errorf("plugin call %s failed: %w", method, call.Error)
+	}
+}
+
+type pipe struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (p pipe) Close() error {
+	writeErr := p.WriteCloser.Close()
+	readErr := p.ReadCloser.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return readErr
+}
@@ -0,0 +1,109 @@
+// Package providerplugin lets rate sources live outside the core binary. A
+// plugin is an executable that calls Serve; the API starts it as a
+// subprocess and talks JSON-RPC over its stdin/stdout.
+//
+// The protocol is net/rpc's JSON-RPC 1.0 codec rather than
+// hashicorp/go-plugin: a provider has two methods, so gRPC, yamux and
+// go-plugin's own handshake would add dependencies to every plugin without
+// adding anything this interface needs, and plain JSON-RPC on stdio can be
+// served from any language. What go-plugin does provide is covered here:
+// Serve moves os.Stdout to stderr so stray prints cannot corrupt the stream,
+// and the client kills a plugin that hangs or breaks the framing so it is
+// restarted.
+package providerplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+)
+
+const (
+	// HandshakeKey/HandshakeValue are set by the host so a plugin binary
+	// refuses to run when started by hand.
+	HandshakeKey   = "CURRENCY_API_PROVIDER_PLUGIN"
+	HandshakeValue = "provider-v1"
+
+	serviceName = "Provider"
+)
+
+// Provider returns rates relative to USD for the requested currency codes.
+// Unknown codes must be omitted rather than returned as zero.
+type Provider interface {
+	Name() string
+	GetRates(ctx context.Context, currencies []string) (map[string]float64, error)
+}
+
+type GetRatesArgs struct {
+	Currencies []string
+}
+
+type GetRatesReply struct {
+	Rates map[string]float64
+}
+
+type NameArgs struct{}
+
+type NameReply struct {
+	Name string
+}
+
+// Serve blocks serving provider on stdin/stdout until the host closes the
+// pipe. Stdout belongs to the protocol from then on: os.Stdout is pointed at
+// stderr, so the provider's own output ends up in the host's logs.
+func Serve(provider Provider) error {
+	if os.Getenv(HandshakeKey) != HandshakeValue {
+		return errors.New("this binary is a currency-api provider plugin and must be started by the API")
+	}
+	conn := stdio{in: os.Stdin, out: os.Stdout}
+	os.Stdout = os.Stderr
+	return ServeConn(provider, conn)
+}
+
+func ServeConn(provider Provider, conn io.ReadWriteCloser) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &rpcService{provider: provider}); err != nil {
+		return fmt.Errorf("failed to register provider: %w", err)
+	}
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	return nil
+}
+
+type rpcService struct {
+	provider Provider
+}
+
+func (s *rpcService) GetRates(args GetRatesArgs, reply *GetRatesReply) error {
+	rates, err := s.provider.GetRates(context.Background(), args.Currencies)
+	if err != nil {
+		return err
+	}
+	reply.Rates = rates
+	return nil
+}
+
+func (s *rpcService) Name(args NameArgs, reply *NameReply) error {
+	reply.Name = s.provider.Name()
+	return nil
+}
+
+type stdio struct {
+	in  *os.File
+	out *os.File
+}
+
+func (s stdio) Read(p []byte) (int, error) {
+	return s.in.Read(p)
+}
+
+func (s stdio) Write(p []byte) (int, error) {
+	return s.out.Write(p)
+}
+
+func (s stdio) Close() error {
+	return s.out.Close()
+}
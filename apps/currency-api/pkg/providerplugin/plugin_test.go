@@ -0,0 +1,214 @@
+package providerplugin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	err   error
+	delay time.Duration
+	noisy bool
+}
+
+func (fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p fakeProvider) GetRates(ctx context.Context, currencies []string) (map[string]float64, error) {
+	time.Sleep(p.delay)
+	if p.noisy {
+		fmt.Println("debug: fetching", currencies)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	rates := make(map[string]float64)
+	for _, currency := range currencies {
+		if currency != "UNKNOWN" {
+			rates[currency] = float64(len(currency))
+		}
+	}
+	return rates, nil
+}
+
+func TestHelperProcess(t *testing.T) {
+	provider := fakeProvider{}
+	switch os.Getenv("GO_WANT_PLUGIN_HELPER") {
+	case "1":
+	case "ignore-eof":
+		// A plugin that keeps running after its stdin is closed.
+		time.Sleep(time.Hour)
+	case "hang":
+		provider.delay = time.Hour
+	case "noisy":
+		provider.noisy = true
+	default:
+		return
+	}
+	if err := Serve(provider); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestClient_InProcess(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_ = ServeConn(fakeProvider{}, serverConn)
+	}()
+
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	name, err := client.Name(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "fake", name)
+
+	rates, err := client.GetRates(context.Background(), []string{"USD", "UNKNOWN"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 3}, rates)
+}
+
+func TestClient_ProviderError(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_ = ServeConn(fakeProvider{err: errors.New("upstream down")}, serverConn)
+	}()
+
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	_, err := client.GetRates(context.Background(), []string{"USD"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upstream down")
+}
+
+func TestClient_Subprocess(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "1")
+
+	client, err := Start(os.Args[0], []string{"-test.run=TestHelperProcess"})
+	require.NoError(t, err)
+
+	rates, err := client.GetRates(context.Background(), []string{"EUR", "GBP"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 3, "GBP": 3}, rates)
+	assert.False(t, client.Exited())
+
+	require.NoError(t, client.Close())
+	assert.True(t, client.Exited())
+}
+
+func TestClient_Subprocess_PrintsDoNotCorruptTheStream(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "noisy")
+
+	client, err := Start(os.Args[0], []string{"-test.run=TestHelperProcess"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	rates, err := client.GetRates(context.Background(), []string{"EUR"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 3}, rates)
+}
+
+func TestClient_CallTimeoutKillsThePlugin(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "hang")
+
+	client, err := Start(os.Args[0], []string{"-test.run=TestHelperProcess"}, WithCallTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.GetRates(context.Background(), []string{"EUR"})
+
+	require.ErrorIs(t, err, ErrCallTimeout)
+	assert.True(t, client.Exited())
+}
+
+func TestClient_CancelledCallKeepsThePlugin(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_ = ServeConn(fakeProvider{delay: time.Second}, serverConn)
+	}()
+
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetRates(ctx, []string{"USD"})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, client.Exited())
+}
+
+func TestClient_MalformedReplyKillsThePlugin(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_, _ = bufio.NewReader(serverConn).ReadBytes('\n')
+		_, _ = serverConn.Write([]byte("debug: not a JSON-RPC frame\n"))
+	}()
+
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	_, err := client.GetRates(context.Background(), []string{"USD"})
+
+	require.Error(t, err)
+	assert.True(t, client.Exited())
+}
+
+func TestClient_ProviderErrorKeepsThePlugin(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	go func() {
+		_ = ServeConn(fakeProvider{err: errors.New("upstream down")}, serverConn)
+	}()
+
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	_, err := client.GetRates(context.Background(), []string{"USD"})
+
+	require.Error(t, err)
+	assert.False(t, client.Exited())
+}
+
+func TestServe_RequiresHandshake(t *testing.T) {
+	t.Setenv(HandshakeKey, "")
+
+	err := Serve(fakeProvider{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be started by the API")
+}
+
+func TestClient_Close_KillsAPluginThatKeepsRunning(t *testing.T) {
+	t.Setenv("GO_WANT_PLUGIN_HELPER", "ignore-eof")
+
+	client, err := Start(os.Args[0], []string{"-test.run=TestHelperProcess"})
+	require.NoError(t, err)
+	client.closeGrace = 50 * time.Millisecond
+
+	closed := make(chan struct{})
+	go func() {
+		_ = client.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	assert.True(t, client.Exited())
+}
@@ -0,0 +1,166 @@
+// Package events ships the canonical payload and JSON schema of every event
+// the API publishes, and a harness that feeds them to a consumer, so teams
+// integrating with the event streams can test their consumers without a
+// running server.
+package events
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ajs/currency-api/internal/infrastructure/configfile"
+)
+
+// Type names a published event as "<stream>.<event name>".
+type Type string
+
+const (
+	RatesSnapshot Type = "rates_stream.snapshot"
+	RatesUpdate   Type = "rates_stream.rates"
+	RatesClose    Type = "rates_stream.close"
+	JobProgress   Type = "job_events.progress"
+	JobCompleted  Type = "job_events.completed"
+	JobFailed     Type = "job_events.failed"
+	JobCancelled  Type = "job_events.cancelled"
+)
+
+// Definition says where an event type is published.
+type Definition struct {
+	Type Type
+	// Stream is the endpoint that publishes the event.
+	Stream string
+	// Event is the server-sent event name.
+	Event  string
+	schema string
+}
+
+var definitions = []Definition{
+	{Type: RatesSnapshot, Stream: "/api/v1/rates/stream", Event: "snapshot", schema: "rates_stream"},
+	{Type: RatesUpdate, Stream: "/api/v1/rates/stream", Event: "rates", schema: "rates_stream"},
+	{Type: RatesClose, Stream: "/api/v1/rates/stream", Event: "close", schema: "rates_stream_close"},
+	{Type: JobProgress, Stream: "/api/v1/jobs/{id}/events", Event: "progress", schema: "job"},
+	{Type: JobCompleted, Stream: "/api/v1/jobs/{id}/events", Event: "completed", schema: "job"},
+	{Type: JobFailed, Stream: "/api/v1/jobs/{id}/events", Event: "failed", schema: "job"},
+	{Type: JobCancelled, Stream: "/api/v1/jobs/{id}/events", Event: "cancelled", schema: "job"},
+}
+
+//go:embed schemas/*.schema.json fixtures/*.json
+var files embed.FS
+
+var schemas = mustLoadSchemas()
+
+// ErrUnknownType is returned for a Type that is not published.
+var ErrUnknownType = errors.New("unknown event type")
+
+// ValidationError lists every way a payload breaks the schema of its type,
+// e.g. "line 1, column 8: rates[0].rate must be a decimal number, got "1,5"".
+type ValidationError struct {
+	Type     Type
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s payload: %s", e.Type, strings.Join(e.Problems, "; "))
+}
+
+// Definitions lists every published event type, grouped by stream.
+func Definitions() []Definition {
+	return append([]Definition(nil), definitions...)
+}
+
+// Lookup returns the definition of t.
+func Lookup(t Type) (Definition, bool) {
+	for _, definition := range definitions {
+		if definition.Type == t {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Schema returns the JSON schema payloads of t must match.
+func Schema(t Type) ([]byte, error) {
+	definition, ok := Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, t)
+	}
+	return files.ReadFile("schemas/" + definition.schema + ".schema.json")
+}
+
+// Fixture returns the canonical payload of t, as indented JSON.
+func Fixture(t Type) ([]byte, error) {
+	if _, ok := Lookup(t); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, t)
+	}
+	return files.ReadFile("fixtures/" + string(t) + ".json")
+}
+
+// Validate checks payload against the schema of t. Problems are returned as
+// a *ValidationError.
+func Validate(t Type, payload []byte) error {
+	definition, ok := Lookup(t)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownType, t)
+	}
+
+	err := configfile.ValidateSchema(schemas[definition.schema], payload)
+	var schemaErr *configfile.ValidationError
+	if !errors.As(err, &schemaErr) {
+		return err
+	}
+
+	problems := make([]string, len(schemaErr.Errors))
+	for i, fieldErr := range schemaErr.Errors {
+		problems[i] = fieldErr.Error()
+	}
+	return &ValidationError{Type: t, Problems: problems}
+}
+
+// NewEvent validates payload and wraps it as the event a consumer receives.
+// The payload is compacted, as it is on the wire.
+func NewEvent(t Type, payload []byte) (Event, error) {
+	if err := Validate(t, payload); err != nil {
+		return Event{}, err
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, payload); err != nil {
+		return Event{}, err
+	}
+
+	definition, _ := Lookup(t)
+	return Event{Type: t, Stream: definition.Stream, Name: definition.Event, Data: compact.Bytes()}, nil
+}
+
+// FixtureEvent is NewEvent for the canonical payload of t.
+func FixtureEvent(t Type) (Event, error) {
+	payload, err := Fixture(t)
+	if err != nil {
+		return Event{}, err
+	}
+	return NewEvent(t, payload)
+}
+
+func mustLoadSchemas() map[string]*configfile.Schema {
+	loaded := make(map[string]*configfile.Schema)
+	for _, definition := range definitions {
+		if _, done := loaded[definition.schema]; done {
+			continue
+		}
+
+		data, err := files.ReadFile("schemas/" + definition.schema + ".schema.json")
+		if err != nil {
+			panic(fmt.Sprintf("missing schema for %s: %v", definition.Type, err))
+		}
+		schema, err := configfile.CompileSchema(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid schema for %s: %v", definition.Type, err))
+		}
+		loaded[definition.schema] = schema
+	}
+	return loaded
+}
@@ -0,0 +1,205 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/app/commands"
+	"github.com/ajs/currency-api/internal/app/handlers"
+	"github.com/ajs/currency-api/internal/app/streaming"
+	"github.com/ajs/currency-api/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures_MatchSchemas(t *testing.T) {
+	for _, definition := range Definitions() {
+		t.Run(string(definition.Type), func(t *testing.T) {
+			schema, err := Schema(definition.Type)
+			require.NoError(t, err)
+			assert.True(t, json.Valid(schema))
+
+			event, err := FixtureEvent(definition.Type)
+			require.NoError(t, err)
+			assert.Equal(t, definition.Event, event.Name)
+			assert.Equal(t, definition.Stream, event.Stream)
+			assert.NotContains(t, string(event.Data), "\n")
+		})
+	}
+}
+
+// The schemas describe the API's own response types, so a field added to or
+// renamed in a handler type has to show up here as well.
+func TestFixtures_MatchHandlerTypes(t *testing.T) {
+	targets := map[string]func() any{
+		"rates_stream":       func() any { return &handlers.RatesStreamEvent{} },
+		"rates_stream_close": func() any { return &handlers.RatesStreamCloseEvent{} },
+		"job":                func() any { return &handlers.JobResponse{} },
+	}
+
+	for _, definition := range Definitions() {
+		t.Run(string(definition.Type), func(t *testing.T) {
+			fixture, err := Fixture(definition.Type)
+			require.NoError(t, err)
+
+			decoder := json.NewDecoder(bytes.NewReader(fixture))
+			decoder.DisallowUnknownFields()
+			assert.NoError(t, decoder.Decode(targets[definition.schema]()))
+		})
+	}
+
+	at := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+	payloads := map[Type]any{
+		RatesUpdate: handlers.RatesStreamEvent{At: at, Rates: []entities.ExchangeRate{
+			{From: "USD", To: "EUR", Rate: decimal.RequireFromString("0.85"), Mocked: true},
+		}},
+		RatesClose: handlers.RatesStreamCloseEvent{Reason: streaming.CloseServerShutdown},
+		JobCompleted: handlers.JobResponse{
+			ID:                 "9f1c2e7a",
+			Type:               commands.BulkConversionJobType,
+			Status:             string(entities.JobCompleted),
+			Progress:           handlers.JobProgress{Total: 3, Processed: 3, Failed: 1, Percent: 100},
+			Error:              "1 row failed",
+			CancelRequested:    true,
+			CreatedAt:          at,
+			StartedAt:          &at,
+			CompletedAt:        &at,
+			StatusURL:          "/api/v1/jobs/9f1c2e7a",
+			ResultURL:          "/api/v1/jobs/9f1c2e7a/result?expires=1&signature=abc",
+			ResultURLExpiresAt: &at,
+		},
+	}
+	for eventType, payload := range payloads {
+		t.Run(string(eventType)+" from handler type", func(t *testing.T) {
+			data, err := json.Marshal(payload)
+			require.NoError(t, err)
+
+			assert.NoError(t, Validate(eventType, data))
+		})
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        Type
+		payload  string
+		expected string
+	}{
+		{"rate with comma", RatesUpdate, `{"at": "2026-10-16T08:32:36Z", "rates": [{"from": "USD", "to": "EUR", "rate": "0,86"}]}`, `line 1, column 79: rates[0].rate must be a decimal number, got "0,86"`},
+		{"numeric rate", RatesSnapshot, `{"at": "2026-10-16T08:32:36Z", "rates": [{"from": "USD", "to": "EUR", "rate": 0.86}]}`, `line 1, column 79: rates[0].rate must be a string`},
+		{"unix timestamp", RatesUpdate, `{"at": 1792139556, "rates": []}`, `line 1, column 8: at must be a string`},
+		{"missing rates", RatesUpdate, `{"at": "2026-10-16T08:32:36Z"}`, `line 1, column 1: rates is required`},
+		{"unknown close reason", RatesClose, `{"reason": "bored"}`, `line 1, column 12: reason must be one of server_shutdown, got "bored"`},
+		{"unknown job status", JobProgress, `{"id": "1", "type": "bulk_conversion", "status": "done", "progress": {"total": 1, "processed": 0, "failed": 0, "percent": 0}, "created_at": "2026-10-16T08:30:00Z", "status_url": "/api/v1/jobs/1"}`, `line 1, column 50: status must be one of pending, running, completed, failed, cancelled, got "done"`},
+		{"percent above 100", JobCompleted, `{"id": "1", "type": "bulk_conversion", "status": "completed", "progress": {"total": 1, "processed": 1, "failed": 0, "percent": 150}, "created_at": "2026-10-16T08:30:00Z", "status_url": "/api/v1/jobs/1"}`, `line 1, column 128: progress.percent must be between 0 and 100`},
+		{"not JSON", JobFailed, `{"id": `, `line 1, column 8: document is not valid JSON: unexpected end of JSON input`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.t, []byte(tt.payload))
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.t, validationErr.Type)
+			assert.Equal(t, []string{tt.expected}, validationErr.Problems)
+		})
+	}
+}
+
+func TestUnknownType(t *testing.T) {
+	_, err := Fixture("rates_stream.heartbeat")
+	assert.ErrorIs(t, err, ErrUnknownType)
+
+	assert.ErrorIs(t, Validate("rates_stream.heartbeat", []byte(`{}`)), ErrUnknownType)
+}
+
+func TestHarness_Replay(t *testing.T) {
+	var received []Type
+	harness := NewHarness(ConsumerFunc(func(ctx context.Context, event Event) error {
+		received = append(received, event.Type)
+		if event.Type == JobFailed {
+			return errors.New("no handler for failed jobs")
+		}
+		return nil
+	}))
+
+	results, err := harness.Replay(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Type{RatesSnapshot, RatesUpdate, RatesClose, JobProgress, JobCompleted, JobFailed, JobCancelled}, received)
+	require.Len(t, results, 7)
+	for _, result := range results {
+		if result.Event.Type == JobFailed {
+			assert.EqualError(t, result.Err, "no handler for failed jobs")
+		} else {
+			assert.NoError(t, result.Err)
+		}
+	}
+
+	received = nil
+	results, err = harness.Replay(context.Background(), RatesClose)
+	require.NoError(t, err)
+	assert.Equal(t, []Type{RatesClose}, received)
+	assert.Equal(t, `{"reason":"server_shutdown"}`, string(results[0].Event.Data))
+
+	_, err = harness.Replay(context.Background(), "jobs.deleted")
+	assert.ErrorIs(t, err, ErrUnknownType)
+}
+
+func TestHarness_Deliver(t *testing.T) {
+	delivered := 0
+	harness := NewHarness(ConsumerFunc(func(ctx context.Context, event Event) error {
+		delivered++
+		return nil
+	}))
+
+	require.NoError(t, harness.Deliver(context.Background(), RatesUpdate, []byte(`{"at": "2026-10-16T08:32:36Z", "rates": []}`)))
+
+	err := harness.Deliver(context.Background(), RatesUpdate, []byte(`{"at": "2026-10-16T08:32:36Z", "rates": [], "source": "ecb"}`))
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Problems[0], "source is not a known field")
+
+	assert.Equal(t, 1, delivered)
+}
+
+func TestWriteSSE_MatchesServerWireFormat(t *testing.T) {
+	event, err := FixtureEvent(RatesUpdate)
+	require.NoError(t, err)
+
+	var payload handlers.RatesStreamEvent
+	require.NoError(t, json.Unmarshal(event.Data, &payload))
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.SSEvent("rates", payload)
+
+	var written bytes.Buffer
+	require.NoError(t, WriteSSE(&written, event))
+	assert.Equal(t, recorder.Body.String(), written.String())
+
+	closing, err := FixtureEvent(RatesClose)
+	require.NoError(t, err)
+	server := httptest.NewServer(Handler(event, closing))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, written.String()+"event:close\ndata:{\"reason\":\"server_shutdown\"}\n\n", string(body))
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Event is one published event as a consumer receives it. Data is the
+// compact JSON payload.
+type Event struct {
+	Type   Type
+	Stream string
+	Name   string
+	Data   []byte
+}
+
+// Consumer is the code under test.
+type Consumer interface {
+	Consume(ctx context.Context, event Event) error
+}
+
+// ConsumerFunc adapts a function to Consumer.
+type ConsumerFunc func(ctx context.Context, event Event) error
+
+func (f ConsumerFunc) Consume(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Result is what the consumer returned for one event.
+type Result struct {
+	Event Event
+	Err   error
+}
+
+// Harness feeds events to a consumer in process. Every payload is checked
+// against its schema before it is delivered, so a consumer cannot pass its
+// tests on a payload the API would never publish.
+type Harness struct {
+	consumer Consumer
+}
+
+func NewHarness(consumer Consumer) *Harness {
+	return &Harness{consumer: consumer}
+}
+
+// Replay delivers the canonical payload of each type, or of every published
+// type when none are given, in order. A consumer error is recorded in the
+// results and does not stop the replay.
+func (h *Harness) Replay(ctx context.Context, types ...Type) ([]Result, error) {
+	if len(types) == 0 {
+		for _, definition := range definitions {
+			types = append(types, definition.Type)
+		}
+	}
+
+	results := make([]Result, 0, len(types))
+	for _, t := range types {
+		event, err := FixtureEvent(t)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, Result{Event: event, Err: h.consumer.Consume(ctx, event)})
+	}
+	return results, nil
+}
+
+// Deliver validates a hand-made payload of type t, e.g. an edited fixture,
+// and delivers it. A payload that breaks the schema is not delivered and
+// its *ValidationError is returned.
+func (h *Harness) Deliver(ctx context.Context, t Type, payload []byte) error {
+	event, err := NewEvent(t, payload)
+	if err != nil {
+		return err
+	}
+	return h.consumer.Consume(ctx, event)
+}
+
+// WriteSSE writes events in the wire format of the streaming endpoints.
+func WriteSSE(w io.Writer, events ...Event) error {
+	for _, event := range events {
+		if _, err := fmt.Fprintf(w, "event:%s\ndata:%s\n\n", event.Name, event.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves events as one event stream and then ends it, for consumers
+// that read the stream over HTTP:
+//
+//	server := httptest.NewServer(events.Handler(snapshot, update, closing))
+func Handler(events ...Event) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		_ = WriteSSE(w, events...)
+	})
+}
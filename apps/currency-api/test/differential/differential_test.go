@@ -0,0 +1,99 @@
+package differential
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajs/currency-api/internal/tools/httpdiff"
+	"github.com/stretchr/testify/require"
+)
+
+// The harness sends the same request suite to two running servers (e.g. the
+// legacy and the consolidated implementation) and fails on any behavioral
+// difference clients could observe:
+//
+//	DIFF_BASELINE_URL=http://localhost:8080 DIFF_CANDIDATE_URL=http://localhost:8081 \
+//	  go test ./test/differential/... -v
+type testCase struct {
+	name   string
+	method string
+	uri    string
+	ignore []string
+}
+
+func suite() []testCase {
+	cases := []testCase{
+		{name: "health", method: http.MethodGet, uri: "/health", ignore: []string{"timestamp", "port"}},
+		{name: "rates fiat pair", method: http.MethodGet, uri: "/api/v1/rates?currencies=USD,EUR"},
+		{name: "rates lowercase and spaces", method: http.MethodGet, uri: "/api/v1/rates?currencies=usd,%20eur,gbp"},
+		{name: "rates matrix", method: http.MethodGet, uri: "/api/v1/rates?currencies=USD,EUR,GBP,JPY,CAD"},
+		{name: "rates missing parameter", method: http.MethodGet, uri: "/api/v1/rates"},
+		{name: "rates single currency", method: http.MethodGet, uri: "/api/v1/rates?currencies=USD"},
+		{name: "rates unknown currency", method: http.MethodGet, uri: "/api/v1/rates?currencies=USD,XYZ"},
+		{name: "exchange missing amount", method: http.MethodGet, uri: "/api/v1/exchange?from=WBTC&to=USDT"},
+		{name: "exchange invalid amount", method: http.MethodGet, uri: "/api/v1/exchange?from=WBTC&to=USDT&amount=abc"},
+		{name: "exchange negative amount", method: http.MethodGet, uri: "/api/v1/exchange?from=WBTC&to=USDT&amount=-1"},
+		{name: "exchange unknown currency", method: http.MethodGet, uri: "/api/v1/exchange?from=MATIC&to=USDT&amount=1"},
+		{name: "exchange tiny amount", method: http.MethodGet, uri: "/api/v1/exchange?from=BEER&to=WBTC&amount=0.000001"},
+		{name: "changelog", method: http.MethodGet, uri: "/api/v1/changelog"},
+		{name: "unknown route", method: http.MethodGet, uri: "/api/v1/does-not-exist"},
+	}
+
+	cryptos := []string{"BEER", "FLOKI", "GATE", "USDT", "WBTC"}
+	for _, from := range cryptos {
+		for _, to := range cryptos {
+			cases = append(cases, testCase{
+				name:   fmt.Sprintf("exchange %s to %s", from, to),
+				method: http.MethodGet,
+				uri:    fmt.Sprintf("/api/v1/exchange?from=%s&to=%s&amount=12.345", from, to),
+			})
+		}
+	}
+
+	return cases
+}
+
+func TestDifferential(t *testing.T) {
+	baselineURL := strings.TrimRight(os.Getenv("DIFF_BASELINE_URL"), "/")
+	candidateURL := strings.TrimRight(os.Getenv("DIFF_CANDIDATE_URL"), "/")
+	if baselineURL == "" || candidateURL == "" {
+		t.Skip("DIFF_BASELINE_URL and DIFF_CANDIDATE_URL must be set to run the differential suite")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	for _, tc := range suite() {
+		t.Run(tc.name, func(t *testing.T) {
+			baseline := send(t, client, baselineURL, tc)
+			candidate := send(t, client, candidateURL, tc)
+
+			differences := httpdiff.Compare(baseline, candidate, httpdiff.Options{IgnoreFields: tc.ignore})
+			for _, difference := range differences {
+				t.Errorf("%s %s: %s differs: baseline=%v candidate=%v",
+					tc.method, tc.uri, difference.Path, difference.Baseline, difference.Candidate)
+			}
+		})
+	}
+}
+
+func send(t *testing.T, client *http.Client, baseURL string, tc testCase) httpdiff.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(tc.method, baseURL+tc.uri, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err, "request to %s failed", baseURL)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return httpdiff.Response{StatusCode: resp.StatusCode, Body: body}
+}
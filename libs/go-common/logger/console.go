@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorDim    = "\033[2m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+)
+
+// consoleHandler writes one human-readable line per record:
+//
+//	15:04:05.000 INFO  Service started version=2.0.0 config.gin_mode=debug
+//
+// Groups are flattened into dotted keys and values are quoted only when they
+// would be ambiguous.
+type consoleHandler struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	level  slog.Leveler
+	colors bool
+
+	attrs  string
+	prefix string
+}
+
+func newConsoleHandler(out io.Writer, level slog.Leveler, colors bool) *consoleHandler {
+	return &consoleHandler{out: out, mu: &sync.Mutex{}, level: level, colors: colors}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(h.paint(colorDim, r.Time.Format("15:04:05.000")))
+	b.WriteByte(' ')
+	b.WriteString(h.paint(levelColor(r.Level), padLevel(r.Level.String())))
+	b.WriteByte(' ')
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		h.appendAttr(&b, h.prefix, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.out, b.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	for _, a := range attrs {
+		h.appendAttr(&b, h.prefix, a)
+	}
+
+	clone := *h
+	clone.attrs += b.String()
+	return &clone
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.prefix += name + "."
+	return &clone
+}
+
+func (h *consoleHandler) appendAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, attr := range a.Value.Group() {
+			h.appendAttr(b, prefix, attr)
+		}
+		return
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(h.paint(colorDim, prefix+a.Key+"="))
+	b.WriteString(quoteIfNeeded(a.Value.String()))
+}
+
+func (h *consoleHandler) paint(color, text string) string {
+	if !h.colors {
+		return text
+	}
+	return color + text + colorReset
+}
+
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed
+	case level >= slog.LevelWarn:
+		return colorYellow
+	case level >= slog.LevelInfo:
+		return colorGreen
+	default:
+		return colorBlue
+	}
+}
+
+func padLevel(level string) string {
+	return level + strings.Repeat(" ", max(0, 5-len(level)))
+}
+
+func quoteIfNeeded(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// isTerminal reports whether w is a character device such as an interactive
+// terminal rather than a file or pipe.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
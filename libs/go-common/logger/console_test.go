@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func consoleLine(t *testing.T, colors bool, log func(*slog.Logger)) string {
+	t.Helper()
+	var out bytes.Buffer
+	log(slog.New(newConsoleHandler(&out, slog.LevelDebug, colors)))
+
+	line := out.String()
+	if !strings.HasSuffix(line, "\n") || strings.Count(line, "\n") != 1 {
+		t.Fatalf("expected one line, got %q", line)
+	}
+	// Drop the timestamp, which depends on the clock.
+	_, rest, _ := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+	return rest
+}
+
+func TestConsoleHandler_FlattensGroups(t *testing.T) {
+	line := consoleLine(t, false, func(l *slog.Logger) {
+		l.With("service", "fx").WithGroup("config").Info("Service started",
+			"gin_mode", "debug",
+			slog.Group("redis", "db", 0, "tls", true),
+			slog.Group("", "inline", "yes"),
+		)
+	})
+
+	expected := "INFO  Service started service=fx config.gin_mode=debug config.redis.db=0 config.redis.tls=true config.inline=yes"
+	if line != expected {
+		t.Errorf("got  %q\nwant %q", line, expected)
+	}
+}
+
+func TestConsoleHandler_QuotesAmbiguousValues(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "plain", expected: "key=plain"},
+		{value: "", expected: `key=""`},
+		{value: "two words", expected: `key="two words"`},
+		{value: "a=b", expected: `key="a=b"`},
+		{value: `say "hi"`, expected: `key="say \"hi\""`},
+		{value: "tab\there", expected: `key="tab\there"`},
+		{value: "line\nbreak", expected: `key="line\nbreak"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			line := consoleLine(t, false, func(l *slog.Logger) { l.Warn("msg", "key", tt.value) })
+
+			if want := "WARN  msg " + tt.expected; line != want {
+				t.Errorf("got  %q\nwant %q", line, want)
+			}
+		})
+	}
+}
+
+func TestConsoleHandler_Colors(t *testing.T) {
+	plain := consoleLine(t, false, func(l *slog.Logger) { l.Error("failed", "code", 7) })
+	if strings.Contains(plain, "\033[") {
+		t.Errorf("expected no escape codes with colors off, got %q", plain)
+	}
+	if plain != "ERROR failed code=7" {
+		t.Errorf("got %q", plain)
+	}
+
+	colored := consoleLine(t, true, func(l *slog.Logger) { l.Error("failed", "code", 7) })
+	if !strings.Contains(colored, colorRed+"ERROR"+colorReset) {
+		t.Errorf("expected a red level, got %q", colored)
+	}
+	if !strings.Contains(colored, colorDim+"code="+colorReset+"7") {
+		t.Errorf("expected a dim key, got %q", colored)
+	}
+}
+
+func TestConsoleHandler_Level(t *testing.T) {
+	var out bytes.Buffer
+	l := slog.New(newConsoleHandler(&out, slog.LevelWarn, false))
+
+	l.Info("hidden")
+	l.Warn("shown", "at", time.Duration(0))
+
+	if strings.Contains(out.String(), "hidden") || !strings.Contains(out.String(), "WARN  shown at=0s") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
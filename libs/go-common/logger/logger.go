@@ -1,11 +1,22 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 )
 
+// Output formats accepted by WithFormat.
+const (
+	// FormatAuto picks FormatConsole when stdout is a terminal and FormatJSON
+	// otherwise, so local runs are readable and shipped logs stay parseable.
+	// In production it always picks FormatJSON.
+	FormatAuto    = "auto"
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
 type Logger interface {
 	Info(msg string, args ...any)
 	Error(msg string, err error, args ...any)
@@ -18,7 +29,43 @@ type slogLogger struct {
 	logger *slog.Logger
 }
 
-func New(level string) Logger {
+type options struct {
+	format     string
+	output     io.Writer
+	production bool
+}
+
+type Option func(*options)
+
+// WithFormat selects the output format (auto, json or console). Unknown
+// values fall back to auto.
+func WithFormat(format string) Option {
+	return func(o *options) {
+		o.format = strings.ToLower(format)
+	}
+}
+
+// WithProduction makes FormatAuto write JSON even on a terminal, so a
+// production process started from a shell still ships parseable logs.
+func WithProduction(production bool) Option {
+	return func(o *options) {
+		o.production = production
+	}
+}
+
+// WithOutput writes log lines to w instead of stdout.
+func WithOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.output = w
+	}
+}
+
+func New(level string, opts ...Option) Logger {
+	o := options{format: FormatAuto, output: os.Stdout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var slogLevel slog.Level
 
 	switch strings.ToLower(level) {
@@ -34,16 +81,31 @@ func New(level string) Logger {
 		slogLevel = slog.LevelInfo
 	}
 
-	opts := &slog.HandlerOptions{
-		Level: slogLevel,
+	var handler slog.Handler
+	terminal := isTerminal(o.output)
+	if resolveFormat(o, terminal) == FormatJSON {
+		handler = slog.NewJSONHandler(o.output, &slog.HandlerOptions{Level: slogLevel})
+	} else {
+		handler = newConsoleHandler(o.output, slogLevel, terminal && os.Getenv("NO_COLOR") == "")
 	}
-
-	handler := slog.NewJSONHandler(os.Stdout, opts)
 	logger := slog.New(handler)
 
 	return &slogLogger{logger: logger}
 }
 
+// resolveFormat turns the requested format into FormatJSON or
+// FormatConsole for output that is or is not a terminal.
+func resolveFormat(o options, terminal bool) string {
+	switch {
+	case o.format == FormatConsole:
+		return FormatConsole
+	case o.format != FormatJSON && terminal && !o.production:
+		return FormatConsole
+	default:
+		return FormatJSON
+	}
+}
+
 func (l *slogLogger) Info(msg string, args ...any) {
 	l.logger.Info(msg, args...)
 }
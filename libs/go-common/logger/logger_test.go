@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		terminal   bool
+		production bool
+		expected   string
+	}{
+		{name: "auto on a terminal", format: FormatAuto, terminal: true, expected: FormatConsole},
+		{name: "auto on a pipe", format: FormatAuto, expected: FormatJSON},
+		{name: "auto on a terminal in production", format: FormatAuto, terminal: true, production: true, expected: FormatJSON},
+		{name: "unknown on a terminal", format: "pretty", terminal: true, expected: FormatConsole},
+		{name: "unknown on a terminal in production", format: "pretty", terminal: true, production: true, expected: FormatJSON},
+		{name: "json on a terminal", format: FormatJSON, terminal: true, expected: FormatJSON},
+		{name: "console on a pipe", format: FormatConsole, expected: FormatConsole},
+		{name: "console in production", format: FormatConsole, production: true, expected: FormatConsole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveFormat(options{format: tt.format, production: tt.production}, tt.terminal)
+			if got != tt.expected {
+				t.Errorf("resolveFormat() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNew_WritesJSONInProduction(t *testing.T) {
+	var out bytes.Buffer
+	log := New("info", WithOutput(&out), WithProduction(true))
+
+	log.Info("Service started", "version", "2.0.0")
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", out.String(), err)
+	}
+	if record["msg"] != "Service started" || record["version"] != "2.0.0" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
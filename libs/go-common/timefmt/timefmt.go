@@ -0,0 +1,63 @@
+// Package timefmt holds the timestamp policy shared by the services:
+// timestamps in responses are RFC 3339 with whole seconds, in UTC unless the
+// client asked for another display time zone.
+package timefmt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embedded so display time zones resolve in minimal images and on Lambda.
+	_ "time/tzdata"
+)
+
+// Layout is the format of every timestamp in a response.
+const Layout = time.RFC3339
+
+// In returns t in loc, or in UTC when loc is nil, cut to whole seconds so it
+// marshals to JSON in Layout.
+func In(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.Truncate(time.Second).In(loc)
+}
+
+// UTC returns t in UTC, cut to whole seconds.
+func UTC(t time.Time) time.Time {
+	return In(t, nil)
+}
+
+// Optional is In for optional response fields: a zero t, meaning unset,
+// becomes nil.
+func Optional(t time.Time, loc *time.Location) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	result := In(t, loc)
+	return &result
+}
+
+// Format formats t in Layout in loc, or in UTC when loc is nil.
+func Format(t time.Time, loc *time.Location) string {
+	return In(t, loc).Format(Layout)
+}
+
+// ParseZone resolves a display time zone parameter: an IANA name such as
+// Europe/Warsaw, or UTC when empty. "Local" is rejected because it would
+// depend on the server the request happened to reach.
+func ParseZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "UTC") {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q, use an IANA name such as Europe/Warsaw", name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q, use an IANA name such as Europe/Warsaw", name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,122 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIn(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	instant := time.Date(2026, 10, 16, 8, 30, 15, 987654321, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		loc      *time.Location
+		expected string
+	}{
+		{name: "nil location is UTC", t: instant, expected: "2026-10-16T08:30:15Z"},
+		{name: "display zone", t: instant, loc: warsaw, expected: "2026-10-16T10:30:15+02:00"},
+		{name: "sub-second truncated, not rounded", t: instant.Add(12 * time.Millisecond), expected: "2026-10-16T08:30:15Z"},
+		{name: "other input zone", t: instant.In(warsaw), expected: "2026-10-16T08:30:15Z"},
+		{name: "zero time", t: time.Time{}, expected: "0001-01-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := In(tt.t, tt.loc)
+
+			if got.Nanosecond() != 0 {
+				t.Errorf("In() kept %d ns", got.Nanosecond())
+			}
+			if formatted := got.Format(Layout); formatted != tt.expected {
+				t.Errorf("In() = %s, want %s", formatted, tt.expected)
+			}
+			if formatted := Format(tt.t, tt.loc); formatted != tt.expected {
+				t.Errorf("Format() = %s, want %s", formatted, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOptional(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		loc      *time.Location
+		expected string
+	}{
+		{name: "zero time is unset", t: time.Time{}},
+		{name: "zero time in a display zone is unset", t: time.Time{}, loc: tokyo},
+		{name: "nil location is UTC", t: time.Date(2026, 10, 16, 23, 59, 59, 999999999, time.UTC), expected: "2026-10-16T23:59:59Z"},
+		{name: "display zone", t: time.Date(2026, 10, 16, 23, 59, 59, 500000000, time.UTC), loc: tokyo, expected: "2026-10-17T08:59:59+09:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Optional(tt.t, tt.loc)
+
+			if tt.expected == "" {
+				if got != nil {
+					t.Errorf("Optional() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("Optional() = nil, want %s", tt.expected)
+			}
+			if formatted := got.Format(Layout); formatted != tt.expected {
+				t.Errorf("Optional() = %s, want %s", formatted, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "", expected: "UTC"},
+		{name: "   ", expected: "UTC"},
+		{name: "utc", expected: "UTC"},
+		{name: "UTC", expected: "UTC"},
+		{name: "Europe/Warsaw", expected: "Europe/Warsaw"},
+		{name: " America/New_York ", expected: "America/New_York"},
+		{name: "Local", wantErr: true},
+		{name: "local", wantErr: true},
+		{name: "Mars/Olympus_Mons", wantErr: true},
+		{name: "+02:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := ParseZone(tt.name)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseZone(%q) = %v, want an error", tt.name, loc)
+				}
+				if loc != nil {
+					t.Errorf("ParseZone(%q) returned %v with its error", tt.name, loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseZone(%q) failed: %v", tt.name, err)
+			}
+			if loc.String() != tt.expected {
+				t.Errorf("ParseZone(%q) = %s, want %s", tt.name, loc, tt.expected)
+			}
+		})
+	}
+}